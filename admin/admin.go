@@ -0,0 +1,24 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StartAdminEndpoint starts admin endpoints on provided localhost port.
+// /tss/sessions returns tss sessions pending on the relayer.
+func StartAdminEndpoint(port uint16, sessions http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle("/tss/sessions", sessions)
+
+	log.Info().Msgf("starting admin endpoint on port %d", port)
+	err := http.ListenAndServe(fmt.Sprintf("localhost:%d", port), mux)
+	if err != nil {
+		log.Error().Err(err).Msgf("admin endpoint on port %d stopped", port)
+	}
+}
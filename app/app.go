@@ -6,10 +6,10 @@ package app
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -28,7 +28,6 @@ import (
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	propStore "github.com/ChainSafe/sygma-relayer/store"
-	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
 	coreSubstrate "github.com/sygmaprotocol/sygma-core/chains/substrate"
 	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
 	"github.com/sygmaprotocol/sygma-core/observability"
@@ -41,28 +40,33 @@ import (
 	btcConnection "github.com/ChainSafe/sygma-relayer/chains/btc/connection"
 	btcExecutor "github.com/ChainSafe/sygma-relayer/chains/btc/executor"
 	btcListener "github.com/ChainSafe/sygma-relayer/chains/btc/listener"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener"
+	substrateConnection "github.com/ChainSafe/sygma-relayer/chains/substrate/connection"
 	substrateExecutor "github.com/ChainSafe/sygma-relayer/chains/substrate/executor"
 	substrateListener "github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	substratePallet "github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	substrateTransactor "github.com/ChainSafe/sygma-relayer/chains/substrate/transactor"
 	"github.com/ChainSafe/sygma-relayer/metrics"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	coreEvm "github.com/sygmaprotocol/sygma-core/chains/evm"
 	evmClient "github.com/sygmaprotocol/sygma-core/chains/evm/client"
-	"github.com/sygmaprotocol/sygma-core/chains/evm/listener"
-	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/monitored"
-	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
 	substrateClient "github.com/sygmaprotocol/sygma-core/chains/substrate/client"
-	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
-	coreSubstrateListener "github.com/sygmaprotocol/sygma-core/chains/substrate/listener"
 
+	"github.com/ChainSafe/sygma-relayer/admin"
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
 	"github.com/ChainSafe/sygma-relayer/comm/p2p"
 	"github.com/ChainSafe/sygma-relayer/config"
+	relayerConfig "github.com/ChainSafe/sygma-relayer/config/relayer"
 	"github.com/ChainSafe/sygma-relayer/health"
 	"github.com/ChainSafe/sygma-relayer/jobs"
+	"github.com/ChainSafe/sygma-relayer/keycheck"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/topology"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa"
+	cmpResharing "github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/resharing"
+	cmpSigning "github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/signing"
+	"github.com/ChainSafe/sygma-relayer/tss/reputation"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/rs/zerolog/log"
@@ -72,24 +76,11 @@ import (
 var Version string
 
 func Run() error {
-	var err error
-
 	configFlag := viper.GetString(config.ConfigFlagName)
 	configURL := viper.GetString("config-url")
 
-	var configuration *config.Config
-	if configURL != "" {
-		configuration, err = config.GetSharedConfigFromNetwork(configURL)
-		panicOnError(err)
-	}
-
-	if strings.ToLower(configFlag) == "env" {
-		configuration, err = config.GetConfigFromENV(configuration)
-		panicOnError(err)
-	} else {
-		configuration, err = config.GetConfigFromFile(configFlag, configuration)
-		panicOnError(err)
-	}
+	configuration, err := config.LoadConfig(configFlag, configURL)
+	panicOnError(err)
 
 	observability.ConfigureLogger(configuration.RelayerConfig.LogLevel, os.Stdout)
 
@@ -124,7 +115,6 @@ func Run() error {
 
 	communication := p2p.NewCommunication(host, "p2p/sygma")
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
-	coordinator := tss.NewCoordinator(host, communication, electorFactory)
 
 	// this is temporary solution related to specifics of aws deployment
 	// effectively it waits until old instance is killed
@@ -140,8 +130,26 @@ func Run() error {
 		}
 	}
 	blockstore := store.NewBlockStore(db)
-	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
-	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath)
+	blockHashStore := propStore.NewBlockHashStore(db)
+	peerReputation, err := reputation.NewReputation(propStore.NewReputationStore(db), configuration.RelayerConfig.MpcConfig.ReputationHalfLife)
+	panicOnError(err)
+	coordinator := tss.NewCoordinator(host, communication, electorFactory, peerReputation)
+	go admin.StartAdminEndpoint(configuration.RelayerConfig.AdminPort, coordinator.SessionRegistry())
+	var keyshareEncrypter keyshare.Encrypter = keyshare.PlaintextEncrypter{}
+	if configuration.RelayerConfig.MpcConfig.KeysharePassphrase != "" {
+		keyshareEncrypter = keyshare.NewPassphraseEncrypter(configuration.RelayerConfig.MpcConfig.KeysharePassphrase)
+	} else {
+		log.Warn().Msg("Keyshare passphrase not configured, keyshares are stored unencrypted")
+	}
+	keyshareStore := keyshare.NewEncryptedECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath, keyshareEncrypter)
+	frostKeyshareStore := keyshare.NewEncryptedFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath, keyshareEncrypter)
+	var ed25519KeyshareStore *keyshare.Ed25519KeyshareStore
+	// Ed25519 key is reshared together with the ECDSA key only if it is configured
+	var ed25519ResharingStorer evmEventHandlers.Ed25519KeyshareStorer
+	if configuration.RelayerConfig.MpcConfig.Ed25519KeysharePath != "" {
+		ed25519KeyshareStore = keyshare.NewEncryptedEd25519KeyshareStore(configuration.RelayerConfig.MpcConfig.Ed25519KeysharePath, keyshareEncrypter)
+		ed25519ResharingStorer = ed25519KeyshareStore
+	}
 	propStore := propStore.NewPropStore(db)
 
 	// wait until executions are done and then stop further executions before exiting
@@ -165,9 +173,35 @@ func Run() error {
 	if err != nil {
 		panic(err)
 	}
+	scheduler := tss.NewScheduler(coordinator, configuration.RelayerConfig.MpcConfig.MaxConcurrentSessions, sygmaMetrics)
+	keyMonitor := keycheck.NewKeyMonitor(sygmaMetrics)
+
+	var signingFactory ecdsa.SigningFactory = ecdsa.NewGG18SigningFactory(host, communication, keyshareStore)
+	var cmpKeyshareStorer cmpResharing.CMPKeyshareStorer
+	var cmpKeyshareStore *keyshare.CMPKeyshareStore
+	if configuration.RelayerConfig.MpcConfig.EcdsaProtocol == relayerConfig.CMP {
+		cmpKeyshareStore = keyshare.NewEncryptedCMPKeyshareStore(configuration.RelayerConfig.MpcConfig.CmpKeysharePath, keyshareEncrypter)
+		cmpKeyshareStorer = cmpKeyshareStore
+	}
+	if configuration.RelayerConfig.MpcConfig.EcdsaSigningProtocol == relayerConfig.CMP {
+		presignatures := cmpSigning.NewPresignaturePool(configuration.RelayerConfig.MpcConfig.PresignaturePoolSize)
+		signingFactory = ecdsa.NewCMPSigningFactory(host, communication, cmpKeyshareStore, presignatures)
+		presignatureGenerator := cmpSigning.NewPresignatureGenerator(
+			scheduler, host, communication, cmpKeyshareStore, presignatures, configuration.RelayerConfig.MpcConfig.PresignInterval,
+		)
+		go presignatureGenerator.Start(ctx)
+	}
+	// FROST key is reshared together with the ECDSA key only if it is used to sign for bitcoin domains
+	var frostResharingStorer evmEventHandlers.FrostKeyshareStorer
+	for _, chainConfig := range configuration.ChainConfigs {
+		if chainConfig["type"] == "btc" {
+			frostResharingStorer = frostKeyshareStore
+		}
+	}
 	msgChan := make(chan []*message.Message)
 
 	domains := make(map[uint8]relayer.RelayedChain)
+	reloader := newConfigReloader()
 	for _, chainConfig := range configuration.ChainConfigs {
 		switch chainConfig["type"] {
 		case "evm":
@@ -180,39 +214,15 @@ func Run() error {
 				client, err := evmClient.NewEVMClient(config.GeneralChainConfig.Endpoint, kp)
 				panicOnError(err)
 
-				log.Info().Str("domain", config.String()).Msgf("Registering EVM domain")
+				log.Info().Str("domain", config.String()).Str("address", kp.Address()).Msgf("Registering EVM domain")
 
 				bridgeAddress := common.HexToAddress(config.Bridge)
 				frostAddress := common.HexToAddress(config.FrostKeygen)
-				gasPricer := gas.NewLondonGasPriceClient(client, &gas.GasPricerOpts{
-					UpperLimitFeePerGas: config.MaxGasPrice,
-					GasPriceFactor:      config.GasMultiplier,
-				})
-				t := monitored.NewMonitoredTransactor(*config.GeneralChainConfig.Id, transaction.NewTransaction, gasPricer, sygmaMetrics, client, config.MaxGasPrice, config.GasIncreasePercentage)
-				go t.Monitor(ctx, time.Minute*3, time.Minute*10, time.Minute)
+				t := newEVMTransactor(ctx, *config.GeneralChainConfig.Id, client, sygmaMetrics, config)
 				bridgeContract := bridge.NewBridgeContract(client, bridgeAddress, t)
 
 				depositHandler := depositHandlers.NewETHDepositHandler(bridgeContract)
-				for _, handler := range config.Handlers {
-					switch handler.Type {
-					case "erc20", "native":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc20DepositHandler{})
-						}
-					case "permissionlessGeneric":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.PermissionlessGenericDepositHandler{})
-						}
-					case "erc721":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc721DepositHandler{})
-						}
-					case "erc1155":
-						{
-							depositHandler.RegisterDepositHandler(handler.Address, &depositHandlers.Erc1155DepositHandler{})
-						}
-					}
-				}
+				depositHandler.SetDepositHandlers(evmDepositHandlers(config.Handlers))
 				depositListener := events.NewListener(client)
 				tssListener := events.NewListener(client)
 				eventHandlers := make([]listener.EventHandler, 0)
@@ -220,19 +230,34 @@ func Run() error {
 
 				depositEventHandler := evmEventHandlers.NewDepositEventHandler(depositListener, depositHandler, bridgeAddress, *config.GeneralChainConfig.Id, msgChan)
 				eventHandlers = append(eventHandlers, depositEventHandler)
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewKeygenEventHandler(l, tssListener, coordinator, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewFrostKeygenEventHandler(l, tssListener, coordinator, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewRefreshEventHandler(l, topologyProvider, topologyStore, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, bridgeAddress))
-				eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan))
+				if configuration.RelayerConfig.MpcConfig.EcdsaSigningProtocol == relayerConfig.CMP {
+					eventHandlers = append(eventHandlers, evmEventHandlers.NewCMPKeygenEventHandler(l, tssListener, scheduler, host, communication, cmpKeyshareStore, bridgeAddress, networkTopology.Threshold))
+				} else {
+					eventHandlers = append(eventHandlers, evmEventHandlers.NewKeygenEventHandler(l, tssListener, scheduler, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
+				}
+				eventHandlers = append(eventHandlers, evmEventHandlers.NewFrostKeygenEventHandler(l, tssListener, scheduler, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
+				if ed25519KeyshareStore != nil {
+					eventHandlers = append(eventHandlers, evmEventHandlers.NewEd25519KeygenEventHandler(l, tssListener, scheduler, host, communication, ed25519KeyshareStore, frostAddress, networkTopology.Threshold))
+				}
+				// keyshares are proactively refreshed on blocks of a single domain so all relayers refresh at the same block
+				var keyRefreshInterval *big.Int
+				if *config.GeneralChainConfig.Id == configuration.RelayerConfig.MpcConfig.KeyRefreshDomainID {
+					keyRefreshInterval = new(big.Int).SetUint64(configuration.RelayerConfig.MpcConfig.KeyRefreshInterval)
+				}
+				eventHandlers = append(eventHandlers, evmEventHandlers.NewRefreshEventHandler(l, topologyProvider, topologyStore, tssListener, scheduler, host, communication, connectionGate, keyshareStore, frostResharingStorer, ed25519ResharingStorer, cmpKeyshareStorer, bridgeAddress, keyRefreshInterval))
+				retryEventHandler := evmEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan)
+				eventHandlers = append(eventHandlers, retryEventHandler)
 				if config.Retry != "" {
 					eventHandlers = append(eventHandlers, evmEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgChan))
 				}
 				evmListener := listener.NewEVMListener(client, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockConfirmations, config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan))
+				retryMessageHandler := executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan)
+				mh.RegisterMessageHandler(retry.RetryMessageType, retryMessageHandler)
 				mh.RegisterMessageHandler(transfer.TransferMessageType, &executor.TransferMessageHandler{})
-				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				executor := executor.NewExecutor(host, communication, scheduler, bridgeContract, signingFactory, config.GeneralChainConfig.DerivationPath(), keyMonitor, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewECDSAKeyChecker(bridgeContract, signingFactory, config.GeneralChainConfig.DerivationPath()))
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -252,6 +277,14 @@ func Run() error {
 				chain := coreEvm.NewEVMChain(evmListener, mh, executor, *config.GeneralChainConfig.Id, startBlock)
 
 				domains[*config.GeneralChainConfig.Id] = chain
+				reloader.registerEVMDomain(chainConfig, &evmDomain{
+					config:              config,
+					depositHandler:      depositHandler,
+					transactor:          t,
+					listener:            evmListener,
+					retryEventHandler:   retryEventHandler,
+					retryMessageHandler: retryMessageHandler,
+				})
 			}
 		case "substrate":
 			{
@@ -260,7 +293,7 @@ func Run() error {
 					panic(err)
 				}
 
-				conn, err := connection.NewSubstrateConnection(config.GeneralChainConfig.Endpoint)
+				conn, err := substrateConnection.NewSubstrateConnection(config.GeneralChainConfig.Endpoint)
 				if err != nil {
 					panic(err)
 				}
@@ -269,25 +302,41 @@ func Run() error {
 					panic(err)
 				}
 
-				substrateClient := substrateClient.NewSubstrateClient(conn, &keyPair, config.ChainID, config.Tip)
-				bridgePallet := substratePallet.NewPallet(substrateClient)
+				substrateClient := substrateClient.NewSubstrateClient(conn.Connection, &keyPair, config.ChainID, config.Tip)
+				substrateTransactor := substrateTransactor.NewMonitoredTransactor(*config.GeneralChainConfig.Id, conn, &keyPair, config.Tip, config.MaxTip, config.TipIncreasePercentage, config.ResubmitBlocks)
+				bridgePallet := substratePallet.NewPallet(substrateClient, substrateTransactor, substratePallet.EIP712Domain{
+					Version:           config.BridgeVersion,
+					VerifyingContract: config.VerifyingContract,
+				})
+				err = bridgePallet.LoadDomain()
+				if err != nil {
+					panic(err)
+				}
+				err = bridgePallet.VerifyDomain()
+				if err != nil {
+					panic(err)
+				}
 
-				log.Info().Str("domain", config.String()).Msgf("Registering substrate domain")
+				log.Info().Str("domain", config.String()).Str("address", keyPair.Address).Msgf("Registering substrate domain")
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 				depositHandler := substrateListener.NewSubstrateDepositHandler()
 				depositHandler.RegisterDepositHandler(transfer.FungibleTransfer, substrateListener.FungibleTransferHandler)
-				eventHandlers := make([]coreSubstrateListener.EventHandler, 0)
+				depositHandler.RegisterDepositHandler(transfer.NonFungibleTransfer, substrateListener.NonFungibleTransferHandler)
+				depositHandler.RegisterDepositHandler(transfer.PermissionlessGenericTransfer, substrateListener.PermissionlessGenericTransferHandler)
+				eventHandlers := make([]substrateListener.EventHandler, 0)
+				eventHandlers = append(eventHandlers, substrateListener.NewSystemUpdateEventHandler(conn, bridgePallet.LoadDomain))
 				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn)
 				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgChan))
 				eventHandlers = append(eventHandlers, depositEventHandler)
-				substrateListener := coreSubstrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
+				substrateListener := substrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, blockHashStore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &substrateExecutor.SubstrateMessageHandler{})
+				mh.RegisterMessageHandler(transfer.TransferMessageType, substrateExecutor.NewSubstrateMessageHandler(substrateExecutor.NewRecipientValidator(config.AllowedParachains), propStore))
 				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgChan))
 
-				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareStore, conn, exitLock)
+				sExecutor := substrateExecutor.NewExecutor(host, communication, scheduler, bridgePallet, signingFactory, config.GeneralChainConfig.DerivationPath(), keyMonitor, conn.Connection, exitLock)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewECDSAKeyChecker(bridgePallet, signingFactory, config.GeneralChainConfig.DerivationPath()))
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -307,6 +356,7 @@ func Run() error {
 				substrateChain := coreSubstrate.NewSubstrateChain(substrateListener, mh, sExecutor, *config.GeneralChainConfig.Id, startBlock)
 
 				domains[*config.GeneralChainConfig.Id] = substrateChain
+				reloader.registerSubstrateDomain(chainConfig, config)
 			}
 		case "btc":
 			{
@@ -326,10 +376,7 @@ func Run() error {
 				}
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
-				resources := make(map[[32]byte]btcConfig.Resource)
-				for _, resource := range config.Resources {
-					resources[resource.ResourceID] = resource
-				}
+				resources := btcResources(config.Resources)
 				depositHandler := &btcListener.BtcDepositHandler{}
 				depositEventHandler := btcListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn, resources, config.FeeAddress)
 				eventHandlers := make([]btcListener.EventHandler, 0)
@@ -339,36 +386,58 @@ func Run() error {
 				mempool := mempool.NewMempoolAPI(config.MempoolUrl)
 				mh := message.NewMessageHandler()
 				mh.RegisterMessageHandler(transfer.TransferMessageType, &btcExecutor.FungibleMessageHandler{})
-				mh.RegisterMessageHandler(retry.RetryMessageType, btcExecutor.NewRetryMessageHandler(depositEventHandler, conn, config.BlockConfirmations, propStore, msgChan))
+				retryMessageHandler := btcExecutor.NewRetryMessageHandler(depositEventHandler, conn, config.BlockConfirmations, propStore, msgChan)
+				mh.RegisterMessageHandler(retry.RetryMessageType, retryMessageHandler)
 				uploader := uploader.NewIPFSUploader(configuration.RelayerConfig.UploaderConfig)
 
 				executor := btcExecutor.NewExecutor(
 					propStore,
 					host,
 					communication,
-					coordinator,
+					scheduler,
 					frostKeyshareStore,
+					keyMonitor,
 					conn,
 					mempool,
 					resources,
 					config.Network,
 					exitLock,
 					uploader)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewFrostKeyChecker(frostKeyshareStore, executor, config.Network))
 
 				btcChain := btc.NewBtcChain(listener, executor, mh, *config.GeneralChainConfig.Id)
 				domains[*config.GeneralChainConfig.Id] = btcChain
-
+				reloader.registerBtcDomain(chainConfig, &btcDomain{
+					config:         config,
+					listener:       listener,
+					eventHandler:   depositEventHandler,
+					executor:       executor,
+					messageHandler: retryMessageHandler,
+				})
 			}
 		default:
 			panic(fmt.Errorf("type '%s' not recognized", chainConfig["type"]))
 		}
 	}
 
+	// keys are checked before relaying starts so signing is refused for mismatched domains
+	keyMonitor.Check()
+	health.RegisterKeyStatusEndpoint(keyMonitor)
+	go keyMonitor.Start(ctx, configuration.RelayerConfig.MpcConfig.KeyCheckInterval)
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
 
 	r := relayer.NewRelayer(domains, sygmaMetrics)
 	go r.Start(ctx, msgChan)
 
+	reloadInterval := viper.GetDuration(config.ReloadIntervalFlag)
+	if reloadInterval > 0 {
+		watcher, err := config.NewWatcher(func() (*config.Config, error) {
+			return config.LoadConfig(configFlag, configURL)
+		}, reloadInterval, configuration)
+		panicOnError(err)
+		go watcher.Watch(ctx, reloader.Reload)
+	}
+
 	sysErr := make(chan os.Signal, 1)
 	signal.Notify(sysErr,
 		syscall.SIGTERM,
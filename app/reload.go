@@ -0,0 +1,333 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	btcExecutor "github.com/ChainSafe/sygma-relayer/chains/btc/executor"
+	btcListener "github.com/ChainSafe/sygma-relayer/chains/btc/listener"
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	evmExecutor "github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	evmListener "github.com/ChainSafe/sygma-relayer/chains/evm/listener"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	evmEventHandlers "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate"
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/client"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/monitored"
+	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
+)
+
+const (
+	txResendInterval = time.Minute * 3
+	txTimeout        = time.Minute * 10
+	txTooNew         = time.Minute
+)
+
+// liveFields are domain configuration fields that can be applied
+// on a running relayer without restart
+var liveFields = map[string][]string{
+	"evm":       {"handlers", "maxGasPrice", "gasMultiplier", "gasIncreasePercentage", "blockConfirmations"},
+	"substrate": {},
+	"btc":       {"resources", "blockConfirmations"},
+}
+
+type evmDomain struct {
+	config              *evm.EVMConfig
+	depositHandler      *depositHandlers.ETHDepositHandler
+	transactor          *evmTransactor
+	listener            *evmListener.EVMListener
+	retryEventHandler   *evmEventHandlers.RetryV1EventHandler
+	retryMessageHandler *evmExecutor.RetryMessageHandler
+}
+
+type btcDomain struct {
+	config         *btcConfig.BtcConfig
+	listener       *btcListener.BtcListener
+	eventHandler   *btcListener.FungibleTransferEventHandler
+	executor       *btcExecutor.Executor
+	messageHandler *btcExecutor.RetryMessageHandler
+}
+
+type evmTransactorClient interface {
+	client.Client
+	gas.LondonGasClient
+}
+
+// evmTransactor sends transactions with the monitored transactor built from the latest
+// gas configuration. Gas configuration changes replace the monitored transactor instead
+// of updating values it reads without synchronization. Replaced transactors keep
+// resending their pending transactions until those time out.
+type evmTransactor struct {
+	ctx      context.Context
+	domainID uint8
+	client   evmTransactorClient
+	tracker  monitored.GasTracker
+
+	mu            sync.RWMutex
+	transactor    *monitored.MonitoredTransactor
+	cancelMonitor context.CancelFunc
+}
+
+func newEVMTransactor(
+	ctx context.Context,
+	domainID uint8,
+	client evmTransactorClient,
+	tracker monitored.GasTracker,
+	config *evm.EVMConfig,
+) *evmTransactor {
+	t := &evmTransactor{
+		ctx:      ctx,
+		domainID: domainID,
+		client:   client,
+		tracker:  tracker,
+	}
+	t.setGasConfig(config.MaxGasPrice, config.GasMultiplier, config.GasIncreasePercentage)
+	return t
+}
+
+func (t *evmTransactor) Transact(to *common.Address, data []byte, opts transactor.TransactOptions) (*common.Hash, error) {
+	return t.current().Transact(to, data, opts)
+}
+
+func (t *evmTransactor) current() *monitored.MonitoredTransactor {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.transactor
+}
+
+// setGasConfig starts a monitored transactor with copies of the new gas configuration
+// and stops monitoring transactions of the previous one once they time out.
+func (t *evmTransactor) setGasConfig(maxGasPrice *big.Int, gasMultiplier *big.Float, gasIncreasePercentage *big.Int) {
+	gasPricer := gas.NewLondonGasPriceClient(t.client, &gas.GasPricerOpts{
+		UpperLimitFeePerGas: new(big.Int).Set(maxGasPrice),
+		GasPriceFactor:      new(big.Float).Set(gasMultiplier),
+	})
+	monitoredTransactor := monitored.NewMonitoredTransactor(
+		t.domainID,
+		transaction.NewTransaction,
+		gasPricer,
+		t.tracker,
+		t.client,
+		new(big.Int).Set(maxGasPrice),
+		new(big.Int).Set(gasIncreasePercentage))
+	ctx, cancel := context.WithCancel(t.ctx)
+	go monitoredTransactor.Monitor(ctx, txResendInterval, txTimeout, txTooNew)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancelMonitor != nil {
+		time.AfterFunc(txTimeout+txResendInterval, t.cancelMonitor)
+	}
+	t.transactor = monitoredTransactor
+	t.cancelMonitor = cancel
+}
+
+// configReloader applies domain configuration changes to running domains.
+// Changes that require a restart are rejected and the domain keeps running
+// with the previous configuration.
+type configReloader struct {
+	mu           sync.Mutex
+	chainConfigs map[uint8]map[string]interface{}
+	evmDomains   map[uint8]*evmDomain
+	btcDomains   map[uint8]*btcDomain
+}
+
+func newConfigReloader() *configReloader {
+	return &configReloader{
+		chainConfigs: make(map[uint8]map[string]interface{}),
+		evmDomains:   make(map[uint8]*evmDomain),
+		btcDomains:   make(map[uint8]*btcDomain),
+	}
+}
+
+func (r *configReloader) registerEVMDomain(chainConfig map[string]interface{}, domain *evmDomain) {
+	r.chainConfigs[*domain.config.GeneralChainConfig.Id] = chainConfig
+	r.evmDomains[*domain.config.GeneralChainConfig.Id] = domain
+}
+
+func (r *configReloader) registerSubstrateDomain(chainConfig map[string]interface{}, config *substrate.SubstrateConfig) {
+	r.chainConfigs[*config.GeneralChainConfig.Id] = chainConfig
+}
+
+func (r *configReloader) registerBtcDomain(chainConfig map[string]interface{}, domain *btcDomain) {
+	r.chainConfigs[*domain.config.GeneralChainConfig.Id] = chainConfig
+	r.btcDomains[*domain.config.GeneralChainConfig.Id] = domain
+}
+
+// Reload applies live fields of the new configuration to registered domains
+func (r *configReloader) Reload(configuration *config.Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reloadedDomains := make(map[uint8]bool)
+	for _, chainConfig := range configuration.ChainConfigs {
+		domainID, err := r.reloadDomain(chainConfig)
+		if err != nil {
+			log.Error().Err(err).Msgf("Rejected configuration change for domain %v", chainConfig["id"])
+			continue
+		}
+		reloadedDomains[domainID] = true
+	}
+
+	for domainID := range r.chainConfigs {
+		if !reloadedDomains[domainID] {
+			log.Warn().Uint8("domainID", domainID).Msgf("Domain not reloaded, running with previous configuration")
+		}
+	}
+}
+
+func (r *configReloader) reloadDomain(chainConfig map[string]interface{}) (uint8, error) {
+	chainType, _ := chainConfig["type"].(string)
+	switch chainType {
+	case "evm":
+		{
+			c, err := evm.NewEVMConfig(chainConfig)
+			if err != nil {
+				return 0, err
+			}
+			domainID := *c.GeneralChainConfig.Id
+			if err := r.checkLiveChanges(domainID, chainType, chainConfig); err != nil {
+				return domainID, err
+			}
+
+			r.evmDomains[domainID].apply(c)
+			r.chainConfigs[domainID] = chainConfig
+			return domainID, nil
+		}
+	case "substrate":
+		{
+			c, err := substrate.NewSubstrateConfig(chainConfig)
+			if err != nil {
+				return 0, err
+			}
+			domainID := *c.GeneralChainConfig.Id
+			return domainID, r.checkLiveChanges(domainID, chainType, chainConfig)
+		}
+	case "btc":
+		{
+			c, err := btcConfig.NewBtcConfig(chainConfig)
+			if err != nil {
+				return 0, err
+			}
+			domainID := *c.GeneralChainConfig.Id
+			if err := r.checkLiveChanges(domainID, chainType, chainConfig); err != nil {
+				return domainID, err
+			}
+
+			r.btcDomains[domainID].apply(c)
+			r.chainConfigs[domainID] = chainConfig
+			return domainID, nil
+		}
+	default:
+		return 0, fmt.Errorf("type '%s' not recognized", chainType)
+	}
+}
+
+// checkLiveChanges returns an error if the new domain configuration differs from
+// the running one in fields that can't be applied without restart
+func (r *configReloader) checkLiveChanges(domainID uint8, chainType string, chainConfig map[string]interface{}) error {
+	current, ok := r.chainConfigs[domainID]
+	if !ok {
+		return fmt.Errorf("domain %d is not running, restart required to add it", domainID)
+	}
+
+	changedFields := changedFields(current, chainConfig, liveFields[chainType])
+	if len(changedFields) > 0 {
+		return fmt.Errorf("fields %v of domain %d changed, restart required to apply them", changedFields, domainID)
+	}
+	return nil
+}
+
+func changedFields(current map[string]interface{}, new map[string]interface{}, ignoredFields []string) []string {
+	ignored := make(map[string]bool)
+	for _, field := range ignoredFields {
+		ignored[field] = true
+	}
+
+	fields := make(map[string]bool)
+	for field := range current {
+		fields[field] = true
+	}
+	for field := range new {
+		fields[field] = true
+	}
+
+	changed := make([]string, 0)
+	for field := range fields {
+		if ignored[field] {
+			continue
+		}
+		if !reflect.DeepEqual(current[field], new[field]) {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// apply updates running evm domain with new configuration.
+// Values are published through components of the domain, configuration
+// instances shared with running components are never modified.
+func (d *evmDomain) apply(c *evm.EVMConfig) {
+	d.depositHandler.SetDepositHandlers(evmDepositHandlers(c.Handlers))
+	d.transactor.setGasConfig(c.MaxGasPrice, c.GasMultiplier, c.GasIncreasePercentage)
+	d.listener.SetBlockConfirmations(c.BlockConfirmations)
+	d.retryEventHandler.SetBlockConfirmations(c.BlockConfirmations)
+	d.retryMessageHandler.SetBlockConfirmations(c.BlockConfirmations)
+	d.config = c
+
+	log.Info().Uint8("domainID", *c.GeneralChainConfig.Id).Msgf("Applied configuration change: %s", d.config.String())
+}
+
+// apply updates running btc domain with new configuration
+func (d *btcDomain) apply(c *btcConfig.BtcConfig) {
+	resources := btcResources(c.Resources)
+	d.eventHandler.SetResources(resources)
+	d.executor.SetResources(resources)
+	d.listener.SetBlockConfirmations(c.BlockConfirmations)
+	d.messageHandler.SetBlockConfirmations(c.BlockConfirmations)
+	d.config = c
+
+	log.Info().Uint8("domainID", *c.GeneralChainConfig.Id).Msgf("Applied configuration change with %d resources", len(resources))
+}
+
+func evmDepositHandlers(handlers []evm.HandlerConfig) depositHandlers.DepositHandlers {
+	depositHandlerMap := make(depositHandlers.DepositHandlers)
+	for _, handler := range handlers {
+		if handler.Address == "" {
+			continue
+		}
+
+		depositHandler, err := depositHandlers.DepositHandlerForType(handler.Type)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Skipping handler %s", handler.Address)
+			continue
+		}
+		depositHandlerMap[common.HexToAddress(handler.Address)] = depositHandler
+	}
+	return depositHandlerMap
+}
+
+func btcResources(configResources []btcConfig.Resource) map[[32]byte]btcConfig.Resource {
+	resources := make(map[[32]byte]btcConfig.Resource)
+	for _, resource := range configResources {
+		resources[resource.ResourceID] = resource
+	}
+	return resources
+}
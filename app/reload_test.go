@@ -0,0 +1,159 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	evmExecutor "github.com/ChainSafe/sygma-relayer/chains/evm/executor"
+	evmListener "github.com/ChainSafe/sygma-relayer/chains/evm/listener"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	evmEventHandlers "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+func evmChainConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"id":                    1,
+		"type":                  "evm",
+		"endpoint":              "ws://domain.com",
+		"name":                  "evm1",
+		"from":                  "address",
+		"bridge":                "bridgeAddress",
+		"frostKeygen":           "frostKeygen",
+		"maxGasPrice":           100,
+		"gasIncreasePercentage": 10,
+		"blockConfirmations":    5,
+	}
+}
+
+type ChangedFieldsTestSuite struct {
+	suite.Suite
+}
+
+func TestRunChangedFieldsTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangedFieldsTestSuite))
+}
+
+func (s *ChangedFieldsTestSuite) Test_SameConfig_NoChanges() {
+	changed := changedFields(evmChainConfig(), evmChainConfig(), []string{})
+
+	s.Equal(changed, []string{})
+}
+
+func (s *ChangedFieldsTestSuite) Test_IgnoredFieldsChanged_NoChanges() {
+	new := evmChainConfig()
+	new["maxGasPrice"] = 200
+	new["handlers"] = []interface{}{}
+
+	changed := changedFields(evmChainConfig(), new, []string{"maxGasPrice", "handlers"})
+
+	s.Equal(changed, []string{})
+}
+
+func (s *ChangedFieldsTestSuite) Test_FieldsChangedAddedAndRemoved() {
+	new := evmChainConfig()
+	new["endpoint"] = "ws://other.com"
+	new["retry"] = "retryAddress"
+	delete(new, "bridge")
+
+	changed := changedFields(evmChainConfig(), new, []string{"maxGasPrice"})
+
+	s.Equal(changed, []string{"bridge", "endpoint", "retry"})
+}
+
+type ConfigReloaderTestSuite struct {
+	suite.Suite
+	cancel   context.CancelFunc
+	reloader *configReloader
+	domain   *evmDomain
+}
+
+func TestRunConfigReloaderTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigReloaderTestSuite))
+}
+
+func (s *ConfigReloaderTestSuite) SetupTest() {
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+
+	c, err := evm.NewEVMConfig(evmChainConfig())
+	s.Nil(err)
+	s.domain = &evmDomain{
+		config:              c,
+		depositHandler:      depositHandlers.NewETHDepositHandler(nil),
+		transactor:          newEVMTransactor(ctx, 1, nil, nil, c),
+		listener:            evmListener.NewEVMListener(nil, nil, nil, nil, 1, c.BlockRetryInterval, c.BlockConfirmations, c.BlockInterval),
+		retryEventHandler:   evmEventHandlers.NewRetryV1EventHandler(zerolog.Context{}, nil, nil, nil, common.Address{}, 1, c.BlockConfirmations, nil),
+		retryMessageHandler: evmExecutor.NewRetryMessageHandler(nil, nil, nil, c.BlockConfirmations, nil),
+	}
+	s.reloader = newConfigReloader()
+	s.reloader.registerEVMDomain(evmChainConfig(), s.domain)
+}
+
+func (s *ConfigReloaderTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *ConfigReloaderTestSuite) Test_NewDomain_Rejected() {
+	chainConfig := evmChainConfig()
+	chainConfig["id"] = 2
+
+	_, err := s.reloader.reloadDomain(chainConfig)
+
+	s.NotNil(err)
+	s.NotContains(s.reloader.chainConfigs, uint8(2))
+	s.NotContains(s.reloader.evmDomains, uint8(2))
+}
+
+func (s *ConfigReloaderTestSuite) Test_RestartFieldChanged_Rejected() {
+	chainConfig := evmChainConfig()
+	chainConfig["maxGasPrice"] = 200
+	chainConfig["endpoint"] = "ws://other.com"
+
+	_, err := s.reloader.reloadDomain(chainConfig)
+
+	s.NotNil(err)
+	s.Equal(s.reloader.chainConfigs[1], evmChainConfig())
+	s.Equal(s.domain.transactor.current().IncreaseGas([]*big.Int{big.NewInt(95)}), []*big.Int{big.NewInt(100)})
+}
+
+func (s *ConfigReloaderTestSuite) Test_LiveFieldsChanged_Applied() {
+	initialConfig := s.domain.config
+	chainConfig := evmChainConfig()
+	chainConfig["maxGasPrice"] = 200
+	chainConfig["gasIncreasePercentage"] = 50
+
+	s.reloader.Reload(&config.Config{
+		ChainConfigs: []map[string]interface{}{chainConfig},
+	})
+
+	s.Equal(s.reloader.chainConfigs[1], chainConfig)
+	s.Equal(s.domain.config.MaxGasPrice, big.NewInt(200))
+	s.Equal(s.domain.transactor.current().IncreaseGas([]*big.Int{big.NewInt(100)}), []*big.Int{big.NewInt(150)})
+	s.Equal(s.domain.transactor.current().IncreaseGas([]*big.Int{big.NewInt(190)}), []*big.Int{big.NewInt(200)})
+	// configuration shared with running components is not modified
+	s.Equal(initialConfig.MaxGasPrice, big.NewInt(100))
+	s.Equal(initialConfig.GasIncreasePercentage, big.NewInt(10))
+}
+
+func (s *ConfigReloaderTestSuite) Test_BlockConfirmationsChanged_Applied() {
+	initialConfig := s.domain.config
+	chainConfig := evmChainConfig()
+	chainConfig["blockConfirmations"] = 10
+
+	_, err := s.reloader.reloadDomain(chainConfig)
+
+	s.Nil(err)
+	s.Equal(s.reloader.chainConfigs[1], chainConfig)
+	s.Equal(s.domain.config.BlockConfirmations, big.NewInt(10))
+	// configuration shared with running components is not modified
+	s.Equal(initialConfig.BlockConfirmations, big.NewInt(5))
+}
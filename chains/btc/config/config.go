@@ -13,6 +13,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -124,12 +125,10 @@ func NewBtcConfig(chainConfig map[string]interface{}) (*BtcConfig, error) {
 		if err != nil {
 			return nil, err
 		}
-		resourceBytes, err := hex.DecodeString(r.ResourceID[2:])
+		resource32Bytes, err := parseResourceID(r.ResourceID)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		var resource32Bytes [32]byte
-		copy(resource32Bytes[:], resourceBytes)
 		resources[i] = Resource{
 			Address:    address,
 			ResourceID: resource32Bytes,
@@ -156,6 +155,20 @@ func NewBtcConfig(chainConfig map[string]interface{}) (*BtcConfig, error) {
 	return config, nil
 }
 
+func parseResourceID(resourceID string) ([32]byte, error) {
+	var resource32Bytes [32]byte
+	resourceBytes, err := hexutil.Decode(resourceID)
+	if err != nil {
+		return resource32Bytes, fmt.Errorf("invalid resource ID %s: %w", resourceID, err)
+	}
+	if len(resourceBytes) != 32 {
+		return resource32Bytes, fmt.Errorf("invalid resource ID %s: expected 32 bytes", resourceID)
+	}
+
+	copy(resource32Bytes[:], resourceBytes)
+	return resource32Bytes, nil
+}
+
 func networkParams(network string) (chaincfg.Params, error) {
 	switch network {
 	case "mainnet":
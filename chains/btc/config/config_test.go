@@ -90,6 +90,29 @@ func (s *NewBtcConfigTestSuite) Test_InvalidPassword() {
 	s.Equal(err.Error(), "required field chain.Password empty for chain 1")
 }
 
+func (s *NewBtcConfigTestSuite) Test_InvalidResourceID() {
+	_, err := config.NewBtcConfig(map[string]interface{}{
+		"id":         1,
+		"endpoint":   "ws://domain.com",
+		"name":       "btc1",
+		"username":   "username",
+		"password":   "pass123",
+		"network":    "testnet",
+		"feeAddress": "mkHS9ne12qx9pS9VojpwU5xtRd4T7X7ZUt",
+		"resources": []interface{}{
+			config.RawResource{
+				Address:    "tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm",
+				FeeAmount:  "10000000",
+				ResourceID: "0x0300",
+				Script:     "51206a698882348433b57d549d6344f74500fcd13ad8d2200cdf89f8e39e5cafa7d5",
+			},
+		},
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "invalid resource ID 0x0300: expected 32 bytes")
+}
+
 func (s *NewBtcConfigTestSuite) Test_ValidConfig() {
 	expectedResource := listener.SliceTo32Bytes(common.LeftPadBytes([]byte{3}, 31))
 	expectedAddress, _ := btcutil.DecodeAddress("tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm", &chaincfg.TestNet3Params)
@@ -41,16 +41,23 @@ type MempoolAPI interface {
 	Utxos(address string) ([]mempool.Utxo, error)
 }
 
-type Executor struct {
-	coordinator *tss.Coordinator
-	host        host.Host
-	comm        comm.Communication
+// KeyVerifier refuses signing for domains whose local key doesn't match the on-chain key
+type KeyVerifier interface {
+	VerifyKey(domainID uint8) error
+}
 
-	conn      *connection.Connection
-	resources map[[32]byte]config.Resource
-	chainCfg  chaincfg.Params
-	mempool   MempoolAPI
-	fetcher   signing.SaveDataFetcher
+type Executor struct {
+	scheduler *tss.Scheduler
+	host      host.Host
+	comm      comm.Communication
+
+	conn          *connection.Connection
+	resources     map[[32]byte]config.Resource
+	resourcesLock sync.RWMutex
+	chainCfg      chaincfg.Params
+	mempool       MempoolAPI
+	fetcher       signing.SaveDataFetcher
+	keyVerifier   KeyVerifier
 
 	propStorer PropStorer
 	propMutex  sync.Mutex
@@ -63,8 +70,9 @@ func NewExecutor(
 	propStorer PropStorer,
 	host host.Host,
 	comm comm.Communication,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	fetcher signing.SaveDataFetcher,
+	keyVerifier KeyVerifier,
 	conn *connection.Connection,
 	mempool MempoolAPI,
 	resources map[[32]byte]config.Resource,
@@ -76,9 +84,10 @@ func NewExecutor(
 		propStorer:  propStorer,
 		host:        host,
 		comm:        comm,
-		coordinator: coordinator,
+		scheduler:   scheduler,
 		exitLock:    exitLock,
 		fetcher:     fetcher,
+		keyVerifier: keyVerifier,
 		conn:        conn,
 		resources:   resources,
 		mempool:     mempool,
@@ -87,13 +96,38 @@ func NewExecutor(
 	}
 }
 
+// SetResources replaces resources for which proposals are executed
+func (e *Executor) SetResources(resources map[[32]byte]config.Resource) {
+	e.resourcesLock.Lock()
+	defer e.resourcesLock.Unlock()
+
+	e.resources = resources
+}
+
+// Resources returns resources for which proposals are executed
+func (e *Executor) Resources() map[[32]byte]config.Resource {
+	e.resourcesLock.RLock()
+	defer e.resourcesLock.RUnlock()
+
+	return e.resources
+}
+
 // Execute starts a signing process and executes proposals when signature is generated
 func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	e.exitLock.RLock()
 	defer e.exitLock.RUnlock()
 
+	if len(proposals) == 0 {
+		return fmt.Errorf("no proposals to execute")
+	}
+
+	err := e.keyVerifier.VerifyKey(proposals[0].Destination)
+	if err != nil {
+		return err
+	}
+
 	messageID := proposals[0].MessageID
-	props, err := e.proposalsForExecution(proposals, messageID)
+	props, priority, err := e.proposalsForExecution(proposals, messageID)
 	if err != nil {
 		return err
 	}
@@ -106,24 +140,28 @@ func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 		propsPerResource[prop.Data.ResourceId] = append(propsPerResource[prop.Data.ResourceId], prop)
 	}
 
+	e.resourcesLock.RLock()
+	resources := e.resources
+	e.resourcesLock.RUnlock()
+
 	p := pool.New().WithErrors()
 	for resourceID, props := range propsPerResource {
 		resourceID := resourceID
 		props := props
 
 		p.Go(func() error {
-			resource, ok := e.resources[resourceID]
+			resource, ok := resources[resourceID]
 			if !ok {
 				return fmt.Errorf("no resource for ID %s", hex.EncodeToString(resourceID[:]))
 			}
 
-			return e.executeResourceProps(props, resource, messageID)
+			return e.executeResourceProps(props, resource, priority, messageID)
 		})
 	}
 	return p.Wait()
 }
 
-func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource config.Resource, messageID string) error {
+func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource config.Resource, priority tss.Priority, messageID string) error {
 	log.Info().Str("messageID", messageID).Msgf("Executing proposals %+v for resource %s", props, hex.EncodeToString(resource.ResourceID[:]))
 
 	tx, utxos, err := e.rawTx(props, resource)
@@ -132,14 +170,7 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 	}
 
 	sigChn := make(chan interface{}, len(tx.TxIn))
-	p := pool.New().WithErrors()
-	executionContext, cancelExecution := context.WithCancel(context.Background())
-	watchContext, cancelWatch := context.WithCancel(context.Background())
 	sessionID := fmt.Sprintf("%s-%s", messageID, hex.EncodeToString(resource.ResourceID[:]))
-	defer cancelWatch()
-	p.Go(func() error {
-		return e.watchExecution(watchContext, cancelExecution, tx, props, sigChn, sessionID, messageID)
-	})
 	prevOuts := make(map[wire.OutPoint]*wire.TxOut)
 	for _, utxo := range utxos {
 		txOut := wire.NewTxOut(int64(utxo.Value), resource.Script)
@@ -159,12 +190,14 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 
 	// we need to sign each input individually
 	tssProcesses := make([]tss.TssProcess, len(tx.TxIn))
+	signingHashes := make([][]byte, len(tx.TxIn))
 	for i := range tx.TxIn {
 		signingHash, err := txscript.CalcTaprootSignatureHash(sigHashes, txscript.SigHashDefault, tx, i, prevOutputFetcher)
 		sessionID := hex.EncodeToString(signingHash)
 		if err != nil {
 			return err
 		}
+		signingHashes[i] = signingHash
 		signing, err := signing.NewSigning(
 			i,
 			signingHash,
@@ -179,8 +212,16 @@ func (e *Executor) executeResourceProps(props []*BtcTransferProposal, resource c
 		}
 		tssProcesses[i] = signing
 	}
+
+	p := pool.New().WithErrors()
+	executionContext, cancelExecution := context.WithCancel(context.Background())
+	watchContext, cancelWatch := context.WithCancel(context.Background())
+	defer cancelWatch()
 	p.Go(func() error {
-		return e.coordinator.Execute(executionContext, tssProcesses, sigChn)
+		return e.watchExecution(watchContext, cancelExecution, tx, signingHashes, resource.Tweak, props, sigChn, sessionID, messageID)
+	})
+	p.Go(func() error {
+		return e.scheduler.Execute(executionContext, priority, props[0].Destination, tssProcesses, sigChn)
 	})
 	return p.Wait()
 }
@@ -189,6 +230,8 @@ func (e *Executor) watchExecution(
 	ctx context.Context,
 	cancelExecution context.CancelFunc,
 	tx *wire.MsgTx,
+	signingHashes [][]byte,
+	tweak string,
 	proposals []*BtcTransferProposal,
 	sigChn chan interface{},
 	sessionID string,
@@ -212,6 +255,13 @@ func (e *Executor) watchExecution(
 				}
 				cancelExecution()
 
+				err := e.verifySignatures(signatures, signingHashes, tweak)
+				if err != nil {
+					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					e.storeProposalsStatus(proposals, store.FailedProp)
+					return err
+				}
+
 				hash, err := e.sendTx(tx, signatures, messageID)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
@@ -364,6 +414,32 @@ func (e *Executor) sendTx(tx *wire.MsgTx, signatures []taproot.Signature, messag
 	return e.conn.SendRawTransaction(tx, true)
 }
 
+// verifySignatures checks that input signatures verify against the tweaked taproot
+// key and input sighashes so invalid transactions are not broadcasted
+func (e *Executor) verifySignatures(signatures []taproot.Signature, signingHashes [][]byte, tweak string) error {
+	e.fetcher.LockKeyshare()
+	key, err := e.fetcher.GetKeyshare()
+	e.fetcher.UnlockKeyshare()
+	if err != nil {
+		return err
+	}
+	publicKey, err := signing.TweakedPublicKey(key, tweak)
+	if err != nil {
+		return err
+	}
+
+	for i, signature := range signatures {
+		if !publicKey.Verify(signature, signingHashes[i]) {
+			return &tss.SignatureError{
+				Message:   signingHashes[i],
+				Signature: signature,
+				PublicKey: hex.EncodeToString(publicKey),
+			}
+		}
+	}
+	return nil
+}
+
 func (e *Executor) signaturesFilled(signatures []taproot.Signature) bool {
 	for _, signature := range signatures {
 		if len([]byte(signature)) == 0 {
@@ -374,23 +450,29 @@ func (e *Executor) signaturesFilled(signatures []taproot.Signature) bool {
 	return true
 }
 
-func (e *Executor) proposalsForExecution(proposals []*proposal.Proposal, messageID string) ([]*BtcTransferProposal, error) {
+// proposalsForExecution filters out executed proposals and returns retry priority
+// if any of the proposals failed previously
+func (e *Executor) proposalsForExecution(proposals []*proposal.Proposal, messageID string) ([]*BtcTransferProposal, tss.Priority, error) {
 	e.propMutex.Lock()
 	props := make([]*BtcTransferProposal, 0)
+	priority := tss.PriorityNormal
 	for _, prop := range proposals {
-		executed, err := e.isExecuted(prop)
+		status, err := e.propStorer.PropStatus(prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce)
 		if err != nil {
-			return props, err
+			return props, priority, err
 		}
 
-		if executed {
+		if status != store.MissingProp && status != store.FailedProp {
 			log.Warn().Str("messageID", messageID).Msgf("Proposal %s already executed", fmt.Sprintf("%d-%d-%d", prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce))
 			continue
 		}
+		if status == store.FailedProp {
+			priority = tss.PriorityRetry
+		}
 
 		err = e.propStorer.StorePropStatus(prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce, store.PendingProp)
 		if err != nil {
-			return props, err
+			return props, priority, err
 		}
 		props = append(props, &BtcTransferProposal{
 			Source:      prop.Source,
@@ -399,19 +481,7 @@ func (e *Executor) proposalsForExecution(proposals []*proposal.Proposal, message
 		})
 	}
 	e.propMutex.Unlock()
-	return props, nil
-}
-
-func (e *Executor) isExecuted(prop *proposal.Proposal) (bool, error) {
-	status, err := e.propStorer.PropStatus(prop.Source, prop.Destination, prop.Data.(BtcTransferProposalData).DepositNonce)
-	if err != nil {
-		return true, err
-	}
-
-	if status == store.MissingProp || status == store.FailedProp {
-		return false, nil
-	}
-	return true, err
+	return props, priority, nil
 }
 
 func (e *Executor) storeProposalsStatus(props []*BtcTransferProposal, status store.PropStatus) {
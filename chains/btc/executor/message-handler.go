@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -91,11 +92,12 @@ type DepositProcessor interface {
 }
 
 type RetryMessageHandler struct {
-	depositProcessor   DepositProcessor
-	blockFetcher       BlockFetcher
-	blockConfirmations *big.Int
-	propStorer         PropStorer
-	msgChan            chan []*message.Message
+	depositProcessor       DepositProcessor
+	blockFetcher           BlockFetcher
+	blockConfirmations     *big.Int
+	blockConfirmationsLock sync.RWMutex
+	propStorer             PropStorer
+	msgChan                chan []*message.Message
 }
 
 func NewRetryMessageHandler(
@@ -113,6 +115,21 @@ func NewRetryMessageHandler(
 	}
 }
 
+// SetBlockConfirmations replaces the number of blocks a retried deposit has to be confirmed with
+func (h *RetryMessageHandler) SetBlockConfirmations(blockConfirmations *big.Int) {
+	h.blockConfirmationsLock.Lock()
+	defer h.blockConfirmationsLock.Unlock()
+
+	h.blockConfirmations = new(big.Int).Set(blockConfirmations)
+}
+
+func (h *RetryMessageHandler) confirmations() *big.Int {
+	h.blockConfirmationsLock.RLock()
+	defer h.blockConfirmationsLock.RUnlock()
+
+	return h.blockConfirmations
+}
+
 func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	retryData := msg.Data.(retry.RetryMessageData)
 	hash, err := h.blockFetcher.GetBestBlockHash()
@@ -124,11 +141,12 @@ func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Pro
 		return nil, err
 	}
 	latestBlock := big.NewInt(block.Height)
-	if latestBlock.Cmp(new(big.Int).Add(retryData.BlockHeight, h.blockConfirmations)) != 1 {
+	confirmedBlock := new(big.Int).Add(retryData.BlockHeight, h.confirmations())
+	if latestBlock.Cmp(confirmedBlock) != 1 {
 		return nil, fmt.Errorf(
 			"latest block %s higher than receipt block number + block confirmations %s",
 			latestBlock,
-			new(big.Int).Add(retryData.BlockHeight, h.blockConfirmations),
+			confirmedBlock,
 		)
 	}
 
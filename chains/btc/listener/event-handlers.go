@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
@@ -47,6 +48,7 @@ type FungibleTransferEventHandler struct {
 	conn           Connection
 	msgChan        chan []*message.Message
 	resources      map[[32]byte]config.Resource
+	resourcesLock  sync.RWMutex
 }
 
 func NewFungibleTransferEventHandler(
@@ -82,12 +84,24 @@ func (eh *FungibleTransferEventHandler) HandleEvents(blockNumber *big.Int) error
 	return nil
 }
 
+// SetResources replaces resources for which deposits are processed
+func (eh *FungibleTransferEventHandler) SetResources(resources map[[32]byte]config.Resource) {
+	eh.resourcesLock.Lock()
+	defer eh.resourcesLock.Unlock()
+
+	eh.resources = resources
+}
+
 func (eh *FungibleTransferEventHandler) ProcessDeposits(blockNumber *big.Int) (map[uint8][]*message.Message, error) {
 	domainDeposits := make(map[uint8][]*message.Message)
 	evts, err := eh.FetchEvents(blockNumber)
 	if err != nil {
 		return nil, err
 	}
+
+	eh.resourcesLock.RLock()
+	resources := eh.resources
+	eh.resourcesLock.RUnlock()
 	for _, evt := range evts {
 		err := func(evt btcjson.TxRawResult) error {
 			defer func() {
@@ -96,7 +110,7 @@ func (eh *FungibleTransferEventHandler) ProcessDeposits(blockNumber *big.Int) (m
 				}
 			}()
 
-			for _, resource := range eh.resources {
+			for _, resource := range resources {
 				d, isDeposit, err := DecodeDepositEvent(evt, resource, eh.feeAddress)
 				if err != nil {
 					return err
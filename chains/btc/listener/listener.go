@@ -6,6 +6,7 @@ package listener
 import (
 	"context"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/btc/config"
@@ -30,10 +31,11 @@ type Connection interface {
 type BtcListener struct {
 	conn Connection
 
-	eventHandlers      []EventHandler
-	blockRetryInterval time.Duration
-	blockConfirmations *big.Int
-	blockstore         BlockStorer
+	eventHandlers          []EventHandler
+	blockRetryInterval     time.Duration
+	blockConfirmations     *big.Int
+	blockConfirmationsLock sync.RWMutex
+	blockstore             BlockStorer
 
 	log      zerolog.Logger
 	domainID uint8
@@ -48,7 +50,7 @@ func NewBtcListener(connection Connection, eventHandlers []EventHandler, config
 		conn:               connection,
 		eventHandlers:      eventHandlers,
 		blockRetryInterval: config.BlockRetryInterval,
-		blockConfirmations: config.BlockConfirmations,
+		blockConfirmations: new(big.Int).Set(config.BlockConfirmations),
 		blockstore:         blockstore,
 		domainID:           *config.GeneralChainConfig.Id,
 	}
@@ -85,7 +87,7 @@ loop:
 			}
 
 			// Sleep if startBlock is higher then head
-			if new(big.Int).Sub(head, startBlock).Cmp(l.blockConfirmations) == -1 {
+			if new(big.Int).Sub(head, startBlock).Cmp(l.confirmations()) == -1 {
 				time.Sleep(l.blockRetryInterval)
 				continue
 			}
@@ -109,3 +111,18 @@ loop:
 		}
 	}
 }
+
+// SetBlockConfirmations replaces the number of blocks the listener waits before handling a block
+func (l *BtcListener) SetBlockConfirmations(blockConfirmations *big.Int) {
+	l.blockConfirmationsLock.Lock()
+	defer l.blockConfirmationsLock.Unlock()
+
+	l.blockConfirmations = new(big.Int).Set(blockConfirmations)
+}
+
+func (l *BtcListener) confirmations() *big.Int {
+	l.blockConfirmationsLock.RLock()
+	defer l.blockConfirmationsLock.RUnlock()
+
+	return l.blockConfirmations
+}
@@ -9,11 +9,9 @@ import (
 	"time"
 
 	"github.com/creasty/defaults"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/ChainSafe/sygma-relayer/config/chain"
-	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
 )
 
 type HandlerConfig struct {
@@ -39,16 +37,13 @@ type EVMConfig struct {
 }
 
 func (c *EVMConfig) String() string {
-	privateKey, _ := crypto.HexToECDSA(c.GeneralChainConfig.Key)
-	kp := secp256k1.NewKeypair(*privateKey)
-	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Key address: '%s', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', StartBlock: '%s', BlockConfirmations: '%s', BlockInterval: '%s', BlockRetryInterval: '%s'`,
+	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', LatestBlock: '%t', Bridge: '%s', Retry: '%s', Handlers: %+v, MaxGasPrice: '%s', GasMultiplier: '%s', GasLimit: '%s', TransferGas: '%d', StartBlock: '%s', BlockConfirmations: '%s', BlockInterval: '%s', BlockRetryInterval: '%s'`,
 		c.GeneralChainConfig.Name,
 		*c.GeneralChainConfig.Id,
 		c.GeneralChainConfig.Type,
 		c.GeneralChainConfig.BlockstorePath,
 		c.GeneralChainConfig.FreshStart,
 		c.GeneralChainConfig.LatestBlock,
-		kp.Address(),
 		c.Bridge,
 		c.Retry,
 		c.Handlers,
@@ -4,9 +4,11 @@
 package executor
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
@@ -14,12 +16,14 @@ import (
 	"github.com/sourcegraph/conc/pool"
 
 	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/rs/zerolog/log"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
@@ -41,11 +45,18 @@ type BridgeContract interface {
 	ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error)
 }
 
+// KeyVerifier refuses signing for domains whose local key doesn't match the on-chain key
+type KeyVerifier interface {
+	VerifyKey(domainID uint8) error
+}
+
 type Executor struct {
-	coordinator       *tss.Coordinator
+	scheduler         *tss.Scheduler
 	host              host.Host
 	comm              comm.Communication
-	fetcher           signing.SaveDataFetcher
+	signer            ecdsa.SigningFactory
+	derivationPath    []uint32
+	keyVerifier       KeyVerifier
 	bridge            BridgeContract
 	exitLock          *sync.RWMutex
 	transactionMaxGas uint64
@@ -55,9 +66,11 @@ type Executor struct {
 func NewExecutor(
 	host host.Host,
 	comm comm.Communication,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	bridgeContract BridgeContract,
-	fetcher signing.SaveDataFetcher,
+	signer ecdsa.SigningFactory,
+	derivationPath []uint32,
+	keyVerifier KeyVerifier,
 	exitLock *sync.RWMutex,
 	transactionMaxGas uint64,
 	transferGasCost uint64,
@@ -65,77 +78,145 @@ func NewExecutor(
 	return &Executor{
 		host:              host,
 		comm:              comm,
-		coordinator:       coordinator,
+		scheduler:         scheduler,
 		bridge:            bridgeContract,
-		fetcher:           fetcher,
+		signer:            signer,
+		derivationPath:    derivationPath,
+		keyVerifier:       keyVerifier,
 		exitLock:          exitLock,
 		transactionMaxGas: transactionMaxGas,
 		transferGasCost:   transferGasCost,
 	}
 }
 
-// Execute starts a signing process and executes proposals when signature is generated
+// Execute starts a signing process and executes proposals when signature is generated.
+// All batches of proposals are signed in a single signing session.
 func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	e.exitLock.RLock()
 	defer e.exitLock.RUnlock()
+	if len(proposals) == 0 {
+		return fmt.Errorf("no proposals to execute")
+	}
+
+	err := e.keyVerifier.VerifyKey(proposals[0].Destination)
+	if err != nil {
+		return err
+	}
+
 	batches, err := e.proposalBatches(proposals)
 	if err != nil {
 		return err
 	}
 
-	p := pool.New().WithErrors()
+	pendingBatches := make([]*Batch, 0)
+	for _, batch := range batches {
+		if len(batch.proposals) != 0 {
+			pendingBatches = append(pendingBatches, batch)
+		}
+	}
+	if len(pendingBatches) == 0 {
+		return nil
+	}
+
+	return e.executeBatches(pendingBatches)
+}
+
+// executeBatches signs batches in a single signing session and executes each batch
+// when its signature is generated
+func (e *Executor) executeBatches(batches []*Batch) error {
+	batches, msgs, sessionID, err := e.batchSession(batches)
+	if err != nil {
+		return err
+	}
+
+	messageID := batches[0].proposals[0].MessageID
+	log.Info().Str("messageID", messageID).Msgf("Starting session with ID: %s for %d batches", sessionID, len(batches))
+
+	var process tss.TssProcess
+	if len(batches) == 1 {
+		process, err = e.signer.NewSigning(msgs[0], e.derivationPath, messageID, sessionID)
+	} else {
+		process, err = e.signer.NewBatchSigning(msgs, e.derivationPath, messageID, sessionID)
+	}
+	if err != nil {
+		return err
+	}
+
+	sigChn := make(chan interface{})
+	executionContext, cancelExecution := context.WithCancel(context.Background())
+	watchContext, cancelWatch := context.WithCancel(context.Background())
+	ep := pool.New().WithErrors()
+	ep.Go(func() error {
+		err := e.scheduler.Execute(
+			executionContext,
+			batchPriority(batches),
+			batches[0].proposals[0].Destination,
+			[]tss.TssProcess{process},
+			sigChn)
+		if err != nil {
+			cancelWatch()
+		}
+
+		return err
+	})
+	ep.Go(func() error {
+		if len(batches) == 1 {
+			return e.watchExecution(watchContext, cancelExecution, batches[0], msgs[0], sigChn, sessionID, messageID)
+		}
+		return e.watchBatchExecution(watchContext, cancelExecution, batches, msgs, sigChn, sessionID, messageID)
+	})
+	return ep.Wait()
+}
+
+// batchSession orders batches by proposals hash, removes duplicated batches and derives
+// the signing session ID. The session ID is scoped to the message and to the signed proposal
+// hashes so that relayers executing the same message join the same session.
+func (e *Executor) batchSession(batches []*Batch) ([]*Batch, []*big.Int, string, error) {
+	hashes := make(map[*Batch][]byte)
+	for _, batch := range batches {
+		propHash, err := e.bridge.ProposalsHash(batch.proposals)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		hashes[batch] = propHash
+	}
+	sort.SliceStable(batches, func(i, j int) bool {
+		return bytes.Compare(hashes[batches[i]], hashes[batches[j]]) < 0
+	})
+
+	sortedBatches := make([]*Batch, 0)
+	msgs := make([]*big.Int, 0)
+	data := make([][]byte, 0)
 	for i, batch := range batches {
-		if len(batch.proposals) == 0 {
+		if i > 0 && bytes.Equal(hashes[batch], hashes[batches[i-1]]) {
 			continue
 		}
-		messageID := batch.proposals[0].MessageID
+		sortedBatches = append(sortedBatches, batch)
+		msgs = append(msgs, new(big.Int).SetBytes(hashes[batch]))
+		data = append(data, ethCommon.LeftPadBytes(hashes[batch], 32))
+	}
 
-		b := batch
-		p.Go(func() error {
-			propHash, err := e.bridge.ProposalsHash(b.proposals)
-			if err != nil {
-				return err
-			}
+	sessionID := fmt.Sprintf("%s-%s", sortedBatches[0].proposals[0].MessageID, ethCommon.Bytes2Hex(crypto.Keccak256(data...)))
+	return sortedBatches, msgs, sessionID, nil
+}
 
-			sessionID := fmt.Sprintf("%s-%d", messageID, i)
-			log.Info().Str("messageID", batch.proposals[0].MessageID).Msgf("Starting session with ID: %s", sessionID)
-
-			msg := big.NewInt(0)
-			msg.SetBytes(propHash)
-			signing, err := signing.NewSigning(
-				msg,
-				messageID,
-				sessionID,
-				e.host,
-				e.comm,
-				e.fetcher)
-			if err != nil {
-				return err
+// batchPriority returns retry priority if any of the batch proposals is retried
+func batchPriority(batches []*Batch) tss.Priority {
+	for _, batch := range batches {
+		for _, prop := range batch.proposals {
+			if transfer.IsRetry(prop.Data.Metadata) {
+				return tss.PriorityRetry
 			}
-
-			sigChn := make(chan interface{})
-			executionContext, cancelExecution := context.WithCancel(context.Background())
-			watchContext, cancelWatch := context.WithCancel(context.Background())
-			ep := pool.New().WithErrors()
-			ep.Go(func() error {
-				err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
-				if err != nil {
-					cancelWatch()
-				}
-
-				return err
-			})
-			ep.Go(func() error { return e.watchExecution(watchContext, cancelExecution, b, sigChn, sessionID, messageID) })
-			return ep.Wait()
-		})
+		}
 	}
-	return p.Wait()
+	return tss.PriorityNormal
 }
 
 func (e *Executor) watchExecution(
 	ctx context.Context,
 	cancelExecution context.CancelFunc,
 	batch *Batch,
+	msg *big.Int,
 	sigChn chan interface{},
 	sessionID string,
 	messageID string) error {
@@ -155,7 +236,7 @@ func (e *Executor) watchExecution(
 				}
 
 				signatureData := sigResult.(*common.SignatureData)
-				hash, err := e.executeBatch(batch, signatureData)
+				hash, err := e.executeBatch(batch, msg, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
 					return err
@@ -184,6 +265,70 @@ func (e *Executor) watchExecution(
 	}
 }
 
+// watchBatchExecution executes batches as their signatures are generated
+// and waits until all batches are executed
+func (e *Executor) watchBatchExecution(
+	ctx context.Context,
+	cancelExecution context.CancelFunc,
+	batches []*Batch,
+	msgs []*big.Int,
+	sigChn chan interface{},
+	sessionID string,
+	messageID string) error {
+	ticker := time.NewTicker(executionCheckPeriod)
+	timeout := time.NewTicker(signingTimeout)
+	defer ticker.Stop()
+	defer timeout.Stop()
+	defer cancelExecution()
+
+	signed := 0
+	for {
+		select {
+		case sigResult := <-sigChn:
+			{
+				if sigResult == nil {
+					cancelExecution()
+					continue
+				}
+
+				signature := sigResult.(*signing.BatchSignature)
+				signed++
+				if signed == len(batches) {
+					cancelExecution()
+				}
+
+				hash, err := e.executeBatch(batches[signature.Index], msgs[signature.Index], signature.Signature)
+				if err != nil {
+					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
+					return err
+				}
+
+				log.Info().Str("messageID", messageID).Msgf("Sent batch %d proposals execution with hash: %s", signature.Index, hash)
+			}
+		case <-ticker.C:
+			{
+				if !e.areBatchesExecuted(batches) {
+					continue
+				}
+
+				log.Info().Str("messageID", messageID).Msgf("Successfully executed proposals")
+				return nil
+			}
+		case <-timeout.C:
+			{
+				return fmt.Errorf("execution timed out in %s", signingTimeout)
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// proposalBatches splits proposals into batches by gas limit. Proposals are ordered by
+// source domain and deposit nonce so that batches don't depend on the order proposals
+// were received in.
 func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, error) {
 	batches := make([]*Batch, 1)
 	currentBatch := &Batch{
@@ -192,15 +337,24 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 	}
 	batches[0] = currentBatch
 
-	for _, prop := range proposals {
-		transferProposal := &transfer.TransferProposal{
+	transferProposals := make([]*transfer.TransferProposal, len(proposals))
+	for i, prop := range proposals {
+		transferProposals[i] = &transfer.TransferProposal{
 			Source:      prop.Source,
 			Destination: prop.Destination,
 			Data:        prop.Data.(transfer.TransferProposalData),
 			Type:        prop.Type,
 			MessageID:   prop.MessageID,
 		}
+	}
+	sort.SliceStable(transferProposals, func(i, j int) bool {
+		if transferProposals[i].Source != transferProposals[j].Source {
+			return transferProposals[i].Source < transferProposals[j].Source
+		}
+		return transferProposals[i].Data.DepositNonce < transferProposals[j].Data.DepositNonce
+	})
 
+	for _, transferProposal := range transferProposals {
 		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
 		if err != nil {
 			return nil, err
@@ -232,7 +386,18 @@ func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, er
 	return batches, nil
 }
 
-func (e *Executor) executeBatch(batch *Batch, signatureData *common.SignatureData) (*ethCommon.Hash, error) {
+// executeBatch submits batch proposals with the signature after checking that
+// the signature was generated with the expected key
+func (e *Executor) executeBatch(batch *Batch, msg *big.Int, signatureData *common.SignatureData) (*ethCommon.Hash, error) {
+	publicKey, err := e.signer.PublicKey(e.derivationPath)
+	if err != nil {
+		return nil, err
+	}
+	err = ecdsa.VerifySignature(msg, signatureData, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
 	sig := []byte{}
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.R, 32)...)
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.S, 32)...)
@@ -259,3 +424,13 @@ func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal)
 
 	return true
 }
+
+func (e *Executor) areBatchesExecuted(batches []*Batch) bool {
+	for _, batch := range batches {
+		if !e.areProposalsExecuted(batch.proposals) {
+			return false
+		}
+	}
+
+	return true
+}
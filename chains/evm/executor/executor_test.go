@@ -0,0 +1,150 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	mock_executor "github.com/ChainSafe/sygma-relayer/chains/evm/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type BatchSessionTestSuite struct {
+	suite.Suite
+	mockBridge *mock_executor.MockBridgeContract
+	executor   *Executor
+}
+
+func TestRunBatchSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchSessionTestSuite))
+}
+
+func (s *BatchSessionTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockBridge = mock_executor.NewMockBridgeContract(ctrl)
+	s.mockBridge.EXPECT().ProposalsHash(gomock.Any()).DoAndReturn(func(proposals []*transfer.TransferProposal) ([]byte, error) {
+		hash := []byte{}
+		for _, prop := range proposals {
+			hash = append(hash, byte(prop.Source)*16+byte(prop.Data.DepositNonce))
+		}
+		return hash, nil
+	}).AnyTimes()
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, nil).AnyTimes()
+	s.executor = &Executor{
+		bridge:            s.mockBridge,
+		transactionMaxGas: 250,
+		transferGasCost:   100,
+	}
+}
+
+func (s *BatchSessionTestSuite) batch(source uint8, nonce uint64) *Batch {
+	return &Batch{
+		proposals: []*transfer.TransferProposal{
+			{
+				Source:      source,
+				Destination: 3,
+				Data: transfer.TransferProposalData{
+					DepositNonce: nonce,
+				},
+				MessageID: "messageID",
+			},
+		},
+	}
+}
+
+func (s *BatchSessionTestSuite) proposal(source uint8, nonce uint64) *proposal.Proposal {
+	return &proposal.Proposal{
+		Source:      source,
+		Destination: 3,
+		Data: transfer.TransferProposalData{
+			DepositNonce: nonce,
+		},
+		Type:      transfer.TransferProposalType,
+		MessageID: "messageID",
+	}
+}
+
+func (s *BatchSessionTestSuite) Test_Execute_NoProposals() {
+	s.executor.exitLock = &sync.RWMutex{}
+
+	err := s.executor.Execute([]*proposal.Proposal{})
+
+	s.NotNil(err)
+}
+
+func (s *BatchSessionTestSuite) Test_HashFails() {
+	ctrl := gomock.NewController(s.T())
+	mockBridge := mock_executor.NewMockBridgeContract(ctrl)
+	mockBridge.EXPECT().ProposalsHash(gomock.Any()).Return(nil, errors.New("error"))
+	s.executor.bridge = mockBridge
+
+	_, _, _, err := s.executor.batchSession([]*Batch{s.batch(1, 1)})
+
+	s.NotNil(err)
+}
+
+func (s *BatchSessionTestSuite) Test_BatchesSortedByHash() {
+	first := s.batch(1, 2)
+	second := s.batch(1, 3)
+	third := s.batch(2, 1)
+
+	batches, msgs, _, err := s.executor.batchSession([]*Batch{third, first, second})
+
+	s.Nil(err)
+	s.Equal(batches, []*Batch{first, second, third})
+	s.Equal(msgs, []*big.Int{big.NewInt(18), big.NewInt(19), big.NewInt(33)})
+}
+
+func (s *BatchSessionTestSuite) Test_DuplicatedBatchesRemoved() {
+	first := s.batch(1, 1)
+	second := s.batch(1, 2)
+
+	batches, msgs, _, err := s.executor.batchSession([]*Batch{second, first, s.batch(1, 2)})
+
+	s.Nil(err)
+	s.Equal(batches, []*Batch{first, second})
+	s.Equal(msgs, []*big.Int{big.NewInt(17), big.NewInt(18)})
+}
+
+func (s *BatchSessionTestSuite) Test_SessionID_ScopedToSignedBatches() {
+	_, _, sessionID, err := s.executor.batchSession([]*Batch{s.batch(1, 1), s.batch(2, 2)})
+	s.Nil(err)
+	_, _, otherSessionID, err := s.executor.batchSession([]*Batch{s.batch(1, 1)})
+	s.Nil(err)
+
+	s.Contains(sessionID, "messageID-")
+	s.NotEqual(sessionID, otherSessionID)
+}
+
+func (s *BatchSessionTestSuite) Test_SessionID_IndependentOfProposalOrder() {
+	otherExecutor := &Executor{
+		bridge:            s.mockBridge,
+		transactionMaxGas: 250,
+		transferGasCost:   100,
+	}
+
+	batches, err := s.executor.proposalBatches([]*proposal.Proposal{
+		s.proposal(1, 1), s.proposal(2, 1), s.proposal(1, 2), s.proposal(2, 2),
+	})
+	s.Nil(err)
+	otherBatches, err := otherExecutor.proposalBatches([]*proposal.Proposal{
+		s.proposal(2, 2), s.proposal(1, 2), s.proposal(2, 1), s.proposal(1, 1),
+	})
+	s.Nil(err)
+
+	_, msgs, sessionID, err := s.executor.batchSession(batches)
+	s.Nil(err)
+	_, otherMsgs, otherSessionID, err := otherExecutor.batchSession(otherBatches)
+	s.Nil(err)
+
+	s.Equal(len(msgs), 2)
+	s.Equal(msgs, otherMsgs)
+	s.Equal(sessionID, otherSessionID)
+}
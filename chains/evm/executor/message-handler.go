@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -232,11 +233,12 @@ type DepositProcessor interface {
 }
 
 type RetryMessageHandler struct {
-	depositProcessor   DepositProcessor
-	blockConfirmations *big.Int
-	blockFetcher       BlockFetcher
-	propStorer         PropStorer
-	msgChan            chan []*message.Message
+	depositProcessor       DepositProcessor
+	blockConfirmations     *big.Int
+	blockConfirmationsLock sync.RWMutex
+	blockFetcher           BlockFetcher
+	propStorer             PropStorer
+	msgChan                chan []*message.Message
 }
 
 func NewRetryMessageHandler(
@@ -254,17 +256,33 @@ func NewRetryMessageHandler(
 	}
 }
 
+// SetBlockConfirmations replaces the number of blocks a retried deposit has to be confirmed with
+func (h *RetryMessageHandler) SetBlockConfirmations(blockConfirmations *big.Int) {
+	h.blockConfirmationsLock.Lock()
+	defer h.blockConfirmationsLock.Unlock()
+
+	h.blockConfirmations = new(big.Int).Set(blockConfirmations)
+}
+
+func (h *RetryMessageHandler) confirmations() *big.Int {
+	h.blockConfirmationsLock.RLock()
+	defer h.blockConfirmationsLock.RUnlock()
+
+	return h.blockConfirmations
+}
+
 func (h *RetryMessageHandler) HandleMessage(msg *message.Message) (*proposal.Proposal, error) {
 	retryData := msg.Data.(retry.RetryMessageData)
 	latestBlock, err := h.blockFetcher.LatestBlock()
 	if err != nil {
 		return nil, err
 	}
-	if latestBlock.Cmp(new(big.Int).Add(retryData.BlockHeight, h.blockConfirmations)) != 1 {
+	blockConfirmations := h.confirmations()
+	if latestBlock.Cmp(new(big.Int).Add(retryData.BlockHeight, blockConfirmations)) != 1 {
 		return nil, fmt.Errorf(
 			"latest block %s higher than receipt block number + block confirmations %s",
 			latestBlock,
-			new(big.Int).Add(retryData.BlockHeight, h.blockConfirmations),
+			new(big.Int).Add(retryData.BlockHeight, blockConfirmations),
 		)
 	}
 
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/executor/executor.go
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	reflect "reflect"
+
+	transfer "github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+	transactor "github.com/sygmaprotocol/sygma-core/chains/evm/transactor"
+)
+
+// MockBridgeContract is a mock of BridgeContract interface.
+type MockBridgeContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockBridgeContractMockRecorder
+}
+
+// MockBridgeContractMockRecorder is the mock recorder for MockBridgeContract.
+type MockBridgeContractMockRecorder struct {
+	mock *MockBridgeContract
+}
+
+// NewMockBridgeContract creates a new mock instance.
+func NewMockBridgeContract(ctrl *gomock.Controller) *MockBridgeContract {
+	mock := &MockBridgeContract{ctrl: ctrl}
+	mock.recorder = &MockBridgeContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBridgeContract) EXPECT() *MockBridgeContractMockRecorder {
+	return m.recorder
+}
+
+// ExecuteProposals mocks base method.
+func (m *MockBridgeContract) ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteProposals", proposals, signature, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteProposals indicates an expected call of ExecuteProposals.
+func (mr *MockBridgeContractMockRecorder) ExecuteProposals(proposals, signature, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteProposals", reflect.TypeOf((*MockBridgeContract)(nil).ExecuteProposals), proposals, signature, opts)
+}
+
+// IsProposalExecuted mocks base method.
+func (m *MockBridgeContract) IsProposalExecuted(p *transfer.TransferProposal) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProposalExecuted", p)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProposalExecuted indicates an expected call of IsProposalExecuted.
+func (mr *MockBridgeContractMockRecorder) IsProposalExecuted(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProposalExecuted", reflect.TypeOf((*MockBridgeContract)(nil).IsProposalExecuted), p)
+}
+
+// ProposalsHash mocks base method.
+func (m *MockBridgeContract) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposalsHash", proposals)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposalsHash indicates an expected call of ProposalsHash.
+func (mr *MockBridgeContractMockRecorder) ProposalsHash(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalsHash", reflect.TypeOf((*MockBridgeContract)(nil).ProposalsHash), proposals)
+}
+
+// MockKeyVerifier is a mock of KeyVerifier interface.
+type MockKeyVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyVerifierMockRecorder
+}
+
+// MockKeyVerifierMockRecorder is the mock recorder for MockKeyVerifier.
+type MockKeyVerifierMockRecorder struct {
+	mock *MockKeyVerifier
+}
+
+// NewMockKeyVerifier creates a new mock instance.
+func NewMockKeyVerifier(ctrl *gomock.Controller) *MockKeyVerifier {
+	mock := &MockKeyVerifier{ctrl: ctrl}
+	mock.recorder = &MockKeyVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyVerifier) EXPECT() *MockKeyVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyKey mocks base method.
+func (m *MockKeyVerifier) VerifyKey(domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKey", domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyKey indicates an expected call of VerifyKey.
+func (mr *MockKeyVerifierMockRecorder) VerifyKey(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKey", reflect.TypeOf((*MockKeyVerifier)(nil).VerifyKey), domainID)
+}
@@ -5,6 +5,8 @@ package depositHandlers
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
@@ -21,6 +23,7 @@ type HandlerMatcher interface {
 type ETHDepositHandler struct {
 	handlerMatcher  HandlerMatcher
 	depositHandlers DepositHandlers
+	handlersLock    sync.RWMutex
 }
 
 // NewETHDepositHandler creates an instance of ETHDepositHandler that contains
@@ -56,6 +59,9 @@ func (e *ETHDepositHandler) HandleDeposit(
 
 // matchAddressWithHandlerFunc matches a handler address with an associated handler function
 func (e *ETHDepositHandler) matchAddressWithHandlerFunc(handlerAddress common.Address) (eventHandlers.DepositHandler, error) {
+	e.handlersLock.RLock()
+	defer e.handlersLock.RUnlock()
+
 	hf, ok := e.depositHandlers[handlerAddress]
 	if !ok {
 		return nil, errors.New("no corresponding deposit handler for this address exists")
@@ -69,6 +75,34 @@ func (e *ETHDepositHandler) RegisterDepositHandler(handlerAddress string, handle
 		return
 	}
 
+	e.handlersLock.Lock()
+	defer e.handlersLock.Unlock()
+
 	log.Debug().Msgf("Registered deposit handler for address %s", handlerAddress)
 	e.depositHandlers[common.HexToAddress(handlerAddress)] = handler
 }
+
+// SetDepositHandlers replaces all registered deposit handlers with the provided ones
+func (e *ETHDepositHandler) SetDepositHandlers(handlers DepositHandlers) {
+	e.handlersLock.Lock()
+	defer e.handlersLock.Unlock()
+
+	e.depositHandlers = handlers
+}
+
+// DepositHandlerForType returns deposit handler that processes deposits
+// of the configured handler type
+func DepositHandlerForType(handlerType string) (eventHandlers.DepositHandler, error) {
+	switch handlerType {
+	case "erc20", "native":
+		return &Erc20DepositHandler{}, nil
+	case "permissionlessGeneric":
+		return &PermissionlessGenericDepositHandler{}, nil
+	case "erc721":
+		return &Erc721DepositHandler{}, nil
+	case "erc1155":
+		return &Erc1155DepositHandler{}, nil
+	default:
+		return nil, fmt.Errorf("unknown handler type %s", handlerType)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/chains/evm/calls/consts"
@@ -81,15 +82,16 @@ type PropStorer interface {
 }
 
 type RetryV1EventHandler struct {
-	log                zerolog.Logger
-	eventListener      EventListener
-	depositHandler     DepositHandler
-	propStorer         PropStorer
-	bridgeAddress      common.Address
-	bridgeABI          abi.ABI
-	domainID           uint8
-	blockConfirmations *big.Int
-	msgChan            chan []*message.Message
+	log                    zerolog.Logger
+	eventListener          EventListener
+	depositHandler         DepositHandler
+	propStorer             PropStorer
+	bridgeAddress          common.Address
+	bridgeABI              abi.ABI
+	domainID               uint8
+	blockConfirmations     *big.Int
+	blockConfirmationsLock sync.RWMutex
+	msgChan                chan []*message.Message
 }
 
 func NewRetryV1EventHandler(
@@ -116,6 +118,21 @@ func NewRetryV1EventHandler(
 	}
 }
 
+// SetBlockConfirmations replaces the number of blocks a retried deposit has to be confirmed with
+func (eh *RetryV1EventHandler) SetBlockConfirmations(blockConfirmations *big.Int) {
+	eh.blockConfirmationsLock.Lock()
+	defer eh.blockConfirmationsLock.Unlock()
+
+	eh.blockConfirmations = new(big.Int).Set(blockConfirmations)
+}
+
+func (eh *RetryV1EventHandler) confirmations() *big.Int {
+	eh.blockConfirmationsLock.RLock()
+	defer eh.blockConfirmationsLock.RUnlock()
+
+	return eh.blockConfirmations
+}
+
 func (eh *RetryV1EventHandler) HandleEvents(
 	startBlock *big.Int,
 	endBlock *big.Int,
@@ -134,7 +151,7 @@ func (eh *RetryV1EventHandler) HandleEvents(
 				}
 			}()
 
-			deposits, err := eh.eventListener.FetchRetryDepositEvents(event, eh.bridgeAddress, eh.blockConfirmations)
+			deposits, err := eh.eventListener.FetchRetryDepositEvents(event, eh.bridgeAddress, eh.confirmations())
 			if err != nil {
 				eh.log.Error().Err(err).Msgf("Unable to fetch deposit events from event %+v", event)
 				return
@@ -163,6 +180,7 @@ func (eh *RetryV1EventHandler) HandleEvents(
 				eh.log.Info().Str("messageID", msg.ID).Msgf(
 					"Resolved retry message %+v in block range: %s-%s", msg, startBlock.String(), endBlock.String(),
 				)
+				transfer.MarkRetry(msg)
 				retriesByDomain[msg.Destination] = append(retriesByDomain[msg.Destination], msg)
 			}
 		}(event)
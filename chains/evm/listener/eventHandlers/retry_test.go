@@ -160,6 +160,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_FetchDepositFails_ExecutionContinues
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 	}}})
 }
 
@@ -214,7 +215,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_HandleDepositFails_ExecutionContinue
 	msgs := <-s.msgChan
 
 	s.Nil(err)
-	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2}}})
+	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{DepositNonce: 2, Metadata: map[string]interface{}{transfer.RetryMetadataKey: true}}}})
 }
 
 func (s *RetryV1EventHandlerTestSuite) Test_HandlingRetryPanics_ExecutionContinue() {
@@ -270,6 +271,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_HandlingRetryPanics_ExecutionContinu
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 	}}})
 }
 
@@ -325,8 +327,10 @@ func (s *RetryV1EventHandlerTestSuite) Test_MultipleDeposits() {
 	s.Nil(err)
 	s.Equal(msgs, []*message.Message{{Data: transfer.TransferMessageData{
 		DepositNonce: 1,
+		Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 	}}, {Data: transfer.TransferMessageData{
 		DepositNonce: 2,
+		Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 	}}})
 }
 
@@ -386,6 +390,7 @@ func (s *RetryV1EventHandlerTestSuite) Test_MultipleDeposits_ExecutedIgnored() {
 		{
 			Data: transfer.TransferMessageData{
 				DepositNonce: 2,
+				Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 			},
 		},
 	})
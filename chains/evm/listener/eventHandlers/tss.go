@@ -7,26 +7,36 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/comm/p2p"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/topology"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	cmpKeygen "github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/keygen"
+	cmpResharing "github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/resharing"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/keygen"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/resharing"
+	ed25519Keygen "github.com/ChainSafe/sygma-relayer/tss/frost/ed25519/keygen"
+	ed25519Resharing "github.com/ChainSafe/sygma-relayer/tss/frost/ed25519/resharing"
 	frostKeygen "github.com/ChainSafe/sygma-relayer/tss/frost/keygen"
 	frostResharing "github.com/ChainSafe/sygma-relayer/tss/frost/resharing"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/host"
 )
 
+// keyManagementDomainID is the scheduler queue domain of key management
+// sessions as they are not related to a destination domain
+const keyManagementDomainID uint8 = 0
+
 type KeygenEventHandler struct {
 	log           zerolog.Logger
 	eventListener EventListener
-	coordinator   *tss.Coordinator
+	scheduler     *tss.Scheduler
 	host          host.Host
 	communication comm.Communication
 	storer        keygen.ECDSAKeyshareStorer
@@ -37,7 +47,7 @@ type KeygenEventHandler struct {
 func NewKeygenEventHandler(
 	logC zerolog.Context,
 	eventListener EventListener,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	host host.Host,
 	communication comm.Communication,
 	storer keygen.ECDSAKeyshareStorer,
@@ -47,7 +57,7 @@ func NewKeygenEventHandler(
 	return &KeygenEventHandler{
 		log:           logC.Logger(),
 		eventListener: eventListener,
-		coordinator:   coordinator,
+		scheduler:     scheduler,
 		host:          host,
 		communication: communication,
 		storer:        storer,
@@ -81,7 +91,7 @@ func (eh *KeygenEventHandler) HandleEvents(
 
 	keygenBlockNumber := big.NewInt(0).SetUint64(keygenEvents[0].BlockNumber)
 	keygen := keygen.NewKeygen(eh.sessionID(keygenBlockNumber), eh.threshold, eh.host, eh.communication, eh.storer)
-	err = eh.coordinator.Execute(context.Background(), []tss.TssProcess{keygen}, make(chan interface{}, 1))
+	err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{keygen}, make(chan interface{}, 1))
 	if err != nil {
 		log.Err(err).Msgf("Failed executing keygen")
 	}
@@ -92,10 +102,80 @@ func (eh *KeygenEventHandler) sessionID(block *big.Int) string {
 	return fmt.Sprintf("keygen-%s", block.String())
 }
 
+// CMPKeygenEventHandler generates CMP ECDSA keyshare on keygen events
+// for networks that start with the CMP protocol
+type CMPKeygenEventHandler struct {
+	log           zerolog.Logger
+	eventListener EventListener
+	scheduler     *tss.Scheduler
+	host          host.Host
+	communication comm.Communication
+	storer        cmpKeygen.CMPKeyshareStorer
+	bridgeAddress common.Address
+	threshold     int
+}
+
+func NewCMPKeygenEventHandler(
+	logC zerolog.Context,
+	eventListener EventListener,
+	scheduler *tss.Scheduler,
+	host host.Host,
+	communication comm.Communication,
+	storer cmpKeygen.CMPKeyshareStorer,
+	bridgeAddress common.Address,
+	threshold int,
+) *CMPKeygenEventHandler {
+	return &CMPKeygenEventHandler{
+		log:           logC.Logger(),
+		eventListener: eventListener,
+		scheduler:     scheduler,
+		host:          host,
+		communication: communication,
+		storer:        storer,
+		bridgeAddress: bridgeAddress,
+		threshold:     threshold,
+	}
+}
+
+func (eh *CMPKeygenEventHandler) HandleEvents(
+	startBlock *big.Int,
+	endBlock *big.Int,
+) error {
+	if eh.storer.KeyshareExists() {
+		return nil
+	}
+
+	keygenEvents, err := eh.eventListener.FetchKeygenEvents(
+		context.Background(), eh.bridgeAddress, startBlock, endBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to fetch keygen events because of: %+v", err)
+	}
+	if len(keygenEvents) == 0 {
+		return nil
+	}
+
+	eh.log.Info().Msgf(
+		"Resolved CMP keygen message in block range: %s-%s", startBlock.String(), endBlock.String(),
+	)
+
+	keygenBlockNumber := big.NewInt(0).SetUint64(keygenEvents[0].BlockNumber)
+	keygen := cmpKeygen.NewKeygen(eh.sessionID(keygenBlockNumber), eh.threshold, eh.host, eh.communication, eh.storer)
+	err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{keygen}, make(chan interface{}, 1))
+	if err != nil {
+		log.Err(err).Msgf("Failed executing keygen")
+	}
+	return nil
+}
+
+func (eh *CMPKeygenEventHandler) sessionID(block *big.Int) string {
+	return fmt.Sprintf("cmp-keygen-%s", block.String())
+}
+
 type FrostKeygenEventHandler struct {
 	log             zerolog.Logger
 	eventListener   EventListener
-	coordinator     *tss.Coordinator
+	scheduler       *tss.Scheduler
 	host            host.Host
 	communication   comm.Communication
 	storer          frostKeygen.FrostKeyshareStorer
@@ -106,7 +186,7 @@ type FrostKeygenEventHandler struct {
 func NewFrostKeygenEventHandler(
 	logC zerolog.Context,
 	eventListener EventListener,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	host host.Host,
 	communication comm.Communication,
 	storer frostKeygen.FrostKeyshareStorer,
@@ -116,7 +196,7 @@ func NewFrostKeygenEventHandler(
 	return &FrostKeygenEventHandler{
 		log:             logC.Logger(),
 		eventListener:   eventListener,
-		coordinator:     coordinator,
+		scheduler:       scheduler,
 		host:            host,
 		communication:   communication,
 		storer:          storer,
@@ -129,6 +209,10 @@ func (eh *FrostKeygenEventHandler) HandleEvents(
 	startBlock *big.Int,
 	endBlock *big.Int,
 ) error {
+	if eh.storer.KeyshareExists() {
+		return nil
+	}
+
 	keygenEvents, err := eh.eventListener.FetchFrostKeygenEvents(
 		context.Background(), eh.contractAddress, startBlock, endBlock,
 	)
@@ -146,7 +230,7 @@ func (eh *FrostKeygenEventHandler) HandleEvents(
 
 	keygenBlockNumber := big.NewInt(0).SetUint64(keygenEvents[0].BlockNumber)
 	keygen := frostKeygen.NewKeygen(eh.sessionID(keygenBlockNumber), eh.threshold, eh.host, eh.communication, eh.storer)
-	err = eh.coordinator.Execute(context.Background(), []tss.TssProcess{keygen}, make(chan interface{}, 1))
+	err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{keygen}, make(chan interface{}, 1))
 	if err != nil {
 		log.Err(err).Msgf("Failed executing keygen")
 	}
@@ -157,18 +241,115 @@ func (eh *FrostKeygenEventHandler) sessionID(block *big.Int) string {
 	return fmt.Sprintf("frost-keygen-%s", block.String())
 }
 
+// Ed25519KeygenEventHandler generates Ed25519 FROST keyshare on FROST keygen events
+type Ed25519KeygenEventHandler struct {
+	log             zerolog.Logger
+	eventListener   EventListener
+	scheduler       *tss.Scheduler
+	host            host.Host
+	communication   comm.Communication
+	storer          ed25519Keygen.Ed25519KeyshareStorer
+	contractAddress common.Address
+	threshold       int
+}
+
+func NewEd25519KeygenEventHandler(
+	logC zerolog.Context,
+	eventListener EventListener,
+	scheduler *tss.Scheduler,
+	host host.Host,
+	communication comm.Communication,
+	storer ed25519Keygen.Ed25519KeyshareStorer,
+	contractAddress common.Address,
+	threshold int,
+) *Ed25519KeygenEventHandler {
+	return &Ed25519KeygenEventHandler{
+		log:             logC.Logger(),
+		eventListener:   eventListener,
+		scheduler:       scheduler,
+		host:            host,
+		communication:   communication,
+		storer:          storer,
+		contractAddress: contractAddress,
+		threshold:       threshold,
+	}
+}
+
+func (eh *Ed25519KeygenEventHandler) HandleEvents(
+	startBlock *big.Int,
+	endBlock *big.Int,
+) error {
+	if eh.storer.KeyshareExists() {
+		return nil
+	}
+
+	keygenEvents, err := eh.eventListener.FetchFrostKeygenEvents(
+		context.Background(), eh.contractAddress, startBlock, endBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to fetch keygen events because of: %+v", err)
+	}
+
+	if len(keygenEvents) == 0 {
+		return nil
+	}
+
+	eh.log.Info().Msgf(
+		"Resolved Ed25519 keygen message in block range: %s-%s", startBlock.String(), endBlock.String(),
+	)
+
+	keygenBlockNumber := big.NewInt(0).SetUint64(keygenEvents[0].BlockNumber)
+	keygen := ed25519Keygen.NewKeygen(eh.sessionID(keygenBlockNumber), eh.threshold, eh.host, eh.communication, eh.storer)
+	err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{keygen}, make(chan interface{}, 1))
+	if err != nil {
+		log.Err(err).Msgf("Failed executing keygen")
+	}
+	return nil
+}
+
+func (eh *Ed25519KeygenEventHandler) sessionID(block *big.Int) string {
+	return fmt.Sprintf("ed25519-keygen-%s", block.String())
+}
+
+// ECDSAKeyshareStorer stores reshared ECDSA keyshares and
+// allows rolling back to the previously active version
+type ECDSAKeyshareStorer interface {
+	resharing.SaveDataStorer
+	ActiveVersion() (int, error)
+	ActiveMetadata() (keyshare.Metadata, error)
+}
+
+// FrostKeyshareStorer stores reshared FROST keyshares and
+// allows rolling back to the previously active version
+type FrostKeyshareStorer interface {
+	frostResharing.FrostKeyshareStorer
+	ActiveVersion() (int, error)
+}
+
+// Ed25519KeyshareStorer stores reshared Ed25519 keyshares and
+// allows rolling back to the previously active version
+type Ed25519KeyshareStorer interface {
+	ed25519Resharing.Ed25519KeyshareStorer
+	ActiveVersion() (int, error)
+}
+
 type RefreshEventHandler struct {
 	log              zerolog.Logger
 	topologyProvider topology.NetworkTopologyProvider
 	topologyStore    *topology.TopologyStore
 	eventListener    EventListener
 	bridgeAddress    common.Address
-	coordinator      *tss.Coordinator
+	scheduler        *tss.Scheduler
 	host             host.Host
 	communication    comm.Communication
 	connectionGate   *p2p.ConnectionGate
-	ecdsaStorer      resharing.SaveDataStorer
-	frostStorer      frostResharing.FrostKeyshareStorer
+	ecdsaStorer      ECDSAKeyshareStorer
+	frostStorer      FrostKeyshareStorer
+	ed25519Storer    Ed25519KeyshareStorer
+	cmpStorer        cmpResharing.CMPKeyshareStorer
+	// refreshInterval is the number of blocks between proactive refreshes
+	// of keyshares for the current topology, disabled if zero
+	refreshInterval *big.Int
 }
 
 func NewRefreshEventHandler(
@@ -176,31 +357,38 @@ func NewRefreshEventHandler(
 	topologyProvider topology.NetworkTopologyProvider,
 	topologyStore *topology.TopologyStore,
 	eventListener EventListener,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	host host.Host,
 	communication comm.Communication,
 	connectionGate *p2p.ConnectionGate,
-	ecdsaStorer resharing.SaveDataStorer,
-	frostStorer frostResharing.FrostKeyshareStorer,
+	ecdsaStorer ECDSAKeyshareStorer,
+	frostStorer FrostKeyshareStorer,
+	ed25519Storer Ed25519KeyshareStorer,
+	cmpStorer cmpResharing.CMPKeyshareStorer,
 	bridgeAddress common.Address,
+	refreshInterval *big.Int,
 ) *RefreshEventHandler {
 	return &RefreshEventHandler{
 		log:              logC.Logger(),
 		topologyProvider: topologyProvider,
 		topologyStore:    topologyStore,
 		eventListener:    eventListener,
-		coordinator:      coordinator,
+		scheduler:        scheduler,
 		host:             host,
 		communication:    communication,
 		ecdsaStorer:      ecdsaStorer,
 		frostStorer:      frostStorer,
+		ed25519Storer:    ed25519Storer,
+		cmpStorer:        cmpStorer,
 		connectionGate:   connectionGate,
 		bridgeAddress:    bridgeAddress,
+		refreshInterval:  refreshInterval,
 	}
 }
 
 // HandleEvent fetches refresh events and in case of an event retrieves and stores the latest topology
-// and starts a resharing tss process
+// and starts a resharing tss process. If there are no refresh events and the block range contains
+// a multiple of the refresh interval, keyshares are proactively refreshed for the current topology.
 func (eh *RefreshEventHandler) HandleEvents(
 	startBlock *big.Int,
 	endBlock *big.Int,
@@ -212,7 +400,7 @@ func (eh *RefreshEventHandler) HandleEvents(
 		return fmt.Errorf("unable to fetch keygen events because of: %+v", err)
 	}
 	if len(refreshEvents) == 0 {
-		return nil
+		return eh.proactiveRefresh(startBlock, endBlock)
 	}
 
 	hash := refreshEvents[len(refreshEvents)-1].Hash
@@ -238,17 +426,208 @@ func (eh *RefreshEventHandler) HandleEvents(
 		"Resolved refresh message in block range: %s-%s", startBlock.String(), endBlock.String(),
 	)
 
+	eh.reshare(startBlock.String(), topology.Threshold, hash)
+	return nil
+}
+
+// proactiveRefresh refreshes keyshares between relayers from the current topology when the block
+// range contains a multiple of the refresh interval. Refresh keeps the public keys and threshold
+// but replaces all shares so shares leaked before the refresh can't be combined with the new ones.
+func (eh *RefreshEventHandler) proactiveRefresh(startBlock *big.Int, endBlock *big.Int) error {
+	if eh.refreshInterval == nil || eh.refreshInterval.Sign() <= 0 {
+		return nil
+	}
+	refreshBlock := new(big.Int).Sub(endBlock, new(big.Int).Mod(endBlock, eh.refreshInterval))
+	if refreshBlock.Cmp(startBlock) < 0 || refreshBlock.Sign() == 0 {
+		return nil
+	}
+
+	topology, err := eh.topologyStore.Topology()
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed reading network topology for proactive key refresh")
+		return nil
+	}
+	// refresh keeps the topology the active keyshare was reshared with
+	metadata, err := eh.ecdsaStorer.ActiveMetadata()
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed reading topology hash for proactive key refresh")
+		return nil
+	}
+
+	eh.log.Info().Msgf("Starting proactive key refresh at block %s", refreshBlock.String())
+	eh.reshare(fmt.Sprintf("proactive-%s", refreshBlock.String()), topology.Threshold, metadata.TopologyHash)
+	return nil
+}
+
+// reshare reshares ECDSA, FROST, Ed25519 and CMP keys between relayers from the peerstore
+func (eh *RefreshEventHandler) reshare(session string, threshold int, hash string) {
 	resharing := resharing.NewResharing(
-		eh.sessionID(startBlock), topology.Threshold, eh.host, eh.communication, eh.ecdsaStorer,
+		eh.sessionID(session), threshold, eh.host, eh.communication, eh.ecdsaStorer, hash,
 	)
-	err = eh.coordinator.Execute(context.Background(), []tss.TssProcess{resharing}, make(chan interface{}, 1))
+	var err error
+	if eh.frostStorer == nil && eh.ed25519Storer == nil {
+		err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{resharing}, make(chan interface{}, 1))
+		if err != nil {
+			log.Err(err).Msgf("Failed executing ecdsa key refresh")
+		}
+	} else {
+		err = eh.reshareWithDeferredActivation(resharing, session, threshold, hash)
+		if err != nil {
+			log.Err(err).Msgf("Failed executing key refresh")
+		}
+	}
+
+	// CMP keyshare is refreshed after GG18 resharing so a changed committee
+	// can migrate the reshared GG18 keyshare. It is not refreshed if GG18
+	// resharing failed so both keys are always shared with the same peers.
+	if eh.cmpStorer == nil || err != nil {
+		return
+	}
+	cmpResharing := cmpResharing.NewResharing(
+		eh.cmpSessionID(session), threshold, eh.host, eh.communication, eh.cmpStorer, eh.ecdsaStorer, hash,
+	)
+	err = eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{cmpResharing}, make(chan interface{}, 1))
 	if err != nil {
-		log.Err(err).Msgf("Failed executing ecdsa key refresh")
-		return nil
+		log.Err(err).Msgf("Failed executing cmp key refresh")
+	}
+}
+
+// versionedKeyshareStorer activates keyshare versions stored by resharing with deferred activation
+type versionedKeyshareStorer interface {
+	LockKeyshare()
+	UnlockKeyshare()
+	ActivateKeyshare(version int) error
+	ActiveVersion() (int, error)
+}
+
+// resharedKeyshare is a keyshare version stored by resharing with deferred activation
+type resharedKeyshare struct {
+	name    string
+	storer  versionedKeyshareStorer
+	version int
+}
+
+// reshareWithDeferredActivation reshares ECDSA, FROST and Ed25519 keys and activates reshared
+// keyshares only if all resharings succeed so all keys are always shared with the same peers
+func (eh *RefreshEventHandler) reshareWithDeferredActivation(
+	ecdsaResharing *resharing.Resharing,
+	session string,
+	threshold int,
+	hash string,
+) error {
+	ecdsaResharing.DeferActivation()
+	ecdsaVersion, err := eh.executeDeferred(ecdsaResharing)
+	if err != nil {
+		return fmt.Errorf("ecdsa key refresh failed: %w", err)
+	}
+	reshared := []resharedKeyshare{{name: "ecdsa", storer: eh.ecdsaStorer, version: ecdsaVersion}}
+
+	if eh.frostStorer != nil {
+		frostProcess := frostResharing.NewResharing(
+			eh.frostSessionID(session), threshold, eh.host, eh.communication, eh.frostStorer, hash,
+		)
+		frostProcess.DeferActivation()
+		frostVersion, err := eh.executeDeferred(frostProcess)
+		if err != nil {
+			return fmt.Errorf("frost key refresh failed, reshared %s not activated: %w", describeReshared(reshared), err)
+		}
+		reshared = append(reshared, resharedKeyshare{name: "frost", storer: eh.frostStorer, version: frostVersion})
+	}
+
+	if eh.ed25519Storer != nil {
+		ed25519Process := ed25519Resharing.NewResharing(
+			eh.ed25519SessionID(session), threshold, eh.host, eh.communication, eh.ed25519Storer, hash,
+		)
+		ed25519Process.DeferActivation()
+		ed25519Version, err := eh.executeDeferred(ed25519Process)
+		if err != nil {
+			return fmt.Errorf("ed25519 key refresh failed, reshared %s not activated: %w", describeReshared(reshared), err)
+		}
+		reshared = append(reshared, resharedKeyshare{name: "ed25519", storer: eh.ed25519Storer, version: ed25519Version})
+	}
+
+	return eh.activateReshared(reshared)
+}
+
+// executeDeferred executes resharing with deferred activation and returns the stored keyshare version
+func (eh *RefreshEventHandler) executeDeferred(process tss.TssProcess) (int, error) {
+	resultChn := make(chan interface{}, 1)
+	err := eh.scheduler.Execute(context.Background(), tss.PriorityKeyManagement, keyManagementDomainID, []tss.TssProcess{process}, resultChn)
+	if err != nil {
+		return 0, err
+	}
+	return storedVersion(resultChn)
+}
+
+// activateReshared activates all reshared keyshares. If any activation fails, keyshares
+// activated before it are rolled back to their previously active versions.
+func (eh *RefreshEventHandler) activateReshared(reshared []resharedKeyshare) error {
+	previousVersions := make([]int, len(reshared))
+	for i, key := range reshared {
+		key.storer.LockKeyshare()
+		defer key.storer.UnlockKeyshare()
+
+		previousVersion, err := key.storer.ActiveVersion()
+		if err != nil {
+			return err
+		}
+		previousVersions[i] = previousVersion
 	}
+
+	for i, key := range reshared {
+		err := key.storer.ActivateKeyshare(key.version)
+		if err == nil {
+			continue
+		}
+
+		for j := 0; j < i; j++ {
+			rollbackErr := reshared[j].storer.ActivateKeyshare(previousVersions[j])
+			if rollbackErr != nil {
+				return fmt.Errorf("%s keyshare activation failed: %w, rollback to %s keyshare version %d failed: %s", key.name, err, reshared[j].name, previousVersions[j], rollbackErr)
+			}
+			eh.log.Warn().Msgf("Rolled back %s keyshare to version %d", reshared[j].name, previousVersions[j])
+		}
+		return fmt.Errorf("%s keyshare activation failed: %w", key.name, err)
+	}
+
+	eh.log.Info().Msgf("Activated reshared %s", describeReshared(reshared))
 	return nil
 }
 
-func (eh *RefreshEventHandler) sessionID(block *big.Int) string {
-	return fmt.Sprintf("resharing-%s", block.String())
+func describeReshared(reshared []resharedKeyshare) string {
+	keyshares := make([]string, len(reshared))
+	for i, key := range reshared {
+		keyshares[i] = fmt.Sprintf("%s keyshare version %d", key.name, key.version)
+	}
+	return strings.Join(keyshares, ", ")
+}
+
+// storedVersion returns keyshare version stored by the resharing with deferred activation
+func storedVersion(resultChn chan interface{}) (int, error) {
+	select {
+	case result := <-resultChn:
+		version, ok := result.(int)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resharing result %v", result)
+		}
+		return version, nil
+	default:
+		return 0, fmt.Errorf("reshared keyshare not stored")
+	}
+}
+
+func (eh *RefreshEventHandler) sessionID(session string) string {
+	return fmt.Sprintf("resharing-%s", session)
+}
+
+func (eh *RefreshEventHandler) frostSessionID(session string) string {
+	return fmt.Sprintf("frost-resharing-%s", session)
+}
+
+func (eh *RefreshEventHandler) ed25519SessionID(session string) string {
+	return fmt.Sprintf("ed25519-resharing-%s", session)
+}
+
+func (eh *RefreshEventHandler) cmpSessionID(session string) string {
+	return fmt.Sprintf("cmp-resharing-%s", session)
 }
@@ -0,0 +1,186 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package eventHandlers_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers"
+	mock_listener "github.com/ChainSafe/sygma-relayer/chains/evm/listener/eventHandlers/mock"
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/topology"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519/signing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+)
+
+type noopQueueMeter struct{}
+
+func (m noopQueueMeter) TrackTssQueueDepth(priority string, domainID uint8, depth int) {}
+func (m noopQueueMeter) TrackTssRunningSessions(running int)                           {}
+
+type RefreshEventHandlerTestSuite struct {
+	tsstest.CoordinatorTestSuite
+	ecdsaStores   []*keyshare.ECDSAKeyshareStore
+	frostStores   []*keyshare.FrostKeyshareStore
+	ed25519Stores []*keyshare.Ed25519KeyshareStore
+	handlers      []*eventHandlers.RefreshEventHandler
+}
+
+func TestRunRefreshEventHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshEventHandlerTestSuite))
+}
+
+func (s *RefreshEventHandlerTestSuite) SetupTest() {
+	s.CoordinatorTestSuite.SetupTest()
+	dir := s.T().TempDir()
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	mockEventListener := mock_listener.NewMockEventListener(s.GomockController)
+	mockEventListener.EXPECT().FetchRefreshEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	s.ecdsaStores = []*keyshare.ECDSAKeyshareStore{}
+	s.frostStores = []*keyshare.FrostKeyshareStore{}
+	s.ed25519Stores = []*keyshare.Ed25519KeyshareStore{}
+	s.handlers = []*eventHandlers.RefreshEventHandler{}
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+
+		ecdsaKey, err := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../../../tss/test/keyshares/%d.keyshare", i)).GetKeyshare()
+		s.Nil(err)
+		ecdsaStore := keyshare.NewECDSAKeyshareStore(filepath.Join(dir, fmt.Sprintf("%d.keyshare", i)))
+		s.Nil(ecdsaStore.StoreKeyshare(ecdsaKey))
+		frostKey, err := keyshare.NewFrostKeyshareStore(fmt.Sprintf("../../../../tss/test/keyshares/%d-frost.keyshare", i)).GetKeyshare()
+		s.Nil(err)
+		frostStore := keyshare.NewFrostKeyshareStore(filepath.Join(dir, fmt.Sprintf("%d-frost.keyshare", i)))
+		s.Nil(frostStore.StoreKeyshare(frostKey))
+		ed25519Key, err := keyshare.NewEd25519KeyshareStore(fmt.Sprintf("../../../../tss/test/keyshares/%d-ed25519.keyshare", i)).GetKeyshare()
+		s.Nil(err)
+		ed25519Store := keyshare.NewEd25519KeyshareStore(filepath.Join(dir, fmt.Sprintf("%d-ed25519.keyshare", i)))
+		s.Nil(ed25519Store.StoreKeyshare(ed25519Key))
+		topologyStore := topology.NewTopologyStore(filepath.Join(dir, fmt.Sprintf("%d-topology", i)))
+		s.Nil(topologyStore.StoreTopology(&topology.NetworkTopology{Threshold: s.Threshold}))
+
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
+		s.handlers = append(s.handlers, eventHandlers.NewRefreshEventHandler(
+			log.With(),
+			nil,
+			topologyStore,
+			mockEventListener,
+			tss.NewScheduler(coordinator, 1, noopQueueMeter{}),
+			host,
+			&communication,
+			nil,
+			ecdsaStore,
+			frostStore,
+			ed25519Store,
+			nil,
+			common.Address{},
+			big.NewInt(10),
+		))
+		s.ecdsaStores = append(s.ecdsaStores, ecdsaStore)
+		s.frostStores = append(s.frostStores, frostStore)
+		s.ed25519Stores = append(s.ed25519Stores, ed25519Store)
+	}
+	tsstest.SetupCommunication(communicationMap)
+}
+
+func (s *RefreshEventHandlerTestSuite) Test_ProactiveRefresh_ReshareEd25519Keyshare() {
+	oldKeys := []keyshare.Ed25519Keyshare{}
+	for _, store := range s.ed25519Stores {
+		key, err := store.GetKeyshare()
+		s.Nil(err)
+		oldKeys = append(oldKeys, key)
+	}
+
+	pool := pool.New().WithErrors()
+	for _, handler := range s.handlers {
+		handler := handler
+		pool.Go(func() error {
+			return handler.HandleEvents(big.NewInt(10), big.NewInt(10))
+		})
+	}
+	err := pool.Wait()
+	s.Nil(err)
+
+	for i := range s.Hosts {
+		ecdsaVersion, err := s.ecdsaStores[i].ActiveVersion()
+		s.Nil(err)
+		s.Equal(ecdsaVersion, 2)
+		frostVersion, err := s.frostStores[i].ActiveVersion()
+		s.Nil(err)
+		s.Equal(frostVersion, 2)
+		ed25519Version, err := s.ed25519Stores[i].ActiveVersion()
+		s.Nil(err)
+		s.Equal(ed25519Version, 2)
+
+		key, err := s.ed25519Stores[i].GetKeyshare()
+		s.Nil(err)
+		s.True(key.Key.PublicKey.Equal(oldKeys[i].Key.PublicKey))
+		s.False(key.Key.PrivateShare.Equal(oldKeys[i].Key.PrivateShare))
+	}
+
+	// reshared keyshares produce signatures valid for the unchanged public key
+	publicKey, err := oldKeys[0].PublicKey()
+	s.Nil(err)
+	msg := []byte("Message")
+	signature := s.sign(msg)
+	s.True(ed25519.Verify(publicKey, msg, signature.Signature))
+}
+
+func (s *RefreshEventHandlerTestSuite) sign(msg []byte) signing.Signature {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		signing, err := signing.NewSigning(1, msg, "signing", "signing", host, &communication, s.ed25519Stores[i])
+		s.Nil(err)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(s.Hosts))
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	signature := (<-resultChn).(signing.Signature)
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+	return signature
+}
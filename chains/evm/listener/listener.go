@@ -0,0 +1,135 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type EventHandler interface {
+	HandleEvents(startBlock *big.Int, endBlock *big.Int) error
+}
+
+type ChainClient interface {
+	LatestBlock() (*big.Int, error)
+}
+
+type BlockDeltaMeter interface {
+	TrackBlockDelta(domainID uint8, head *big.Int, current *big.Int)
+}
+
+type BlockStorer interface {
+	StoreBlock(block *big.Int, domainID uint8) error
+}
+
+// EVMListener polls evm block ranges and executes event handlers for them.
+// Block confirmations can be replaced while the listener is running.
+type EVMListener struct {
+	client        ChainClient
+	eventHandlers []EventHandler
+	metrics       BlockDeltaMeter
+	blockstore    BlockStorer
+
+	domainID               uint8
+	blockRetryInterval     time.Duration
+	blockConfirmations     *big.Int
+	blockConfirmationsLock sync.RWMutex
+	blockInterval          *big.Int
+
+	log zerolog.Logger
+}
+
+// NewEVMListener creates an EVMListener that listens to deposit events on chain
+// and calls event handler when one occurs
+func NewEVMListener(
+	client ChainClient,
+	eventHandlers []EventHandler,
+	blockstore BlockStorer,
+	metrics BlockDeltaMeter,
+	domainID uint8,
+	blockRetryInterval time.Duration,
+	blockConfirmations *big.Int,
+	blockInterval *big.Int) *EVMListener {
+	return &EVMListener{
+		log:                log.With().Uint8("domainID", domainID).Logger(),
+		client:             client,
+		metrics:            metrics,
+		eventHandlers:      eventHandlers,
+		blockstore:         blockstore,
+		domainID:           domainID,
+		blockRetryInterval: blockRetryInterval,
+		blockConfirmations: new(big.Int).Set(blockConfirmations),
+		blockInterval:      blockInterval,
+	}
+}
+
+// ListenToEvents goes block by block of a network and executes event handlers that are
+// configured for the listener.
+func (l *EVMListener) ListenToEvents(ctx context.Context, startBlock *big.Int) {
+	endBlock := big.NewInt(0)
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			head, err := l.client.LatestBlock()
+			if err != nil {
+				l.log.Warn().Err(err).Msg("Unable to get latest block")
+				time.Sleep(l.blockRetryInterval)
+				continue
+			}
+			if startBlock == nil {
+				startBlock = big.NewInt(head.Int64())
+			}
+			endBlock.Add(startBlock, l.blockInterval)
+
+			// Sleep if the difference is less than needed block confirmations; (latest - current) < BlockDelay
+			if new(big.Int).Sub(head, endBlock).Cmp(l.confirmations()) == -1 {
+				time.Sleep(l.blockRetryInterval)
+				continue
+			}
+
+			l.metrics.TrackBlockDelta(l.domainID, head, endBlock)
+			l.log.Debug().Msgf("Fetching evm events for block range %s-%s", startBlock, endBlock)
+
+			for _, handler := range l.eventHandlers {
+				err := handler.HandleEvents(startBlock, new(big.Int).Sub(endBlock, big.NewInt(1)))
+				if err != nil {
+					l.log.Warn().Err(err).Msgf("Unable to handle events")
+					continue loop
+				}
+			}
+
+			//Write to block store. Not a critical operation, no need to retry
+			err = l.blockstore.StoreBlock(endBlock, l.domainID)
+			if err != nil {
+				l.log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+			}
+
+			startBlock.Add(startBlock, l.blockInterval)
+		}
+	}
+}
+
+// SetBlockConfirmations replaces the number of blocks the listener waits before handling a block range
+func (l *EVMListener) SetBlockConfirmations(blockConfirmations *big.Int) {
+	l.blockConfirmationsLock.Lock()
+	defer l.blockConfirmationsLock.Unlock()
+
+	l.blockConfirmations = new(big.Int).Set(blockConfirmations)
+}
+
+func (l *EVMListener) confirmations() *big.Int {
+	l.blockConfirmationsLock.RLock()
+	defer l.blockConfirmationsLock.RUnlock()
+
+	return l.blockConfirmations
+}
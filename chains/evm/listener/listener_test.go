@@ -0,0 +1,79 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener"
+	"github.com/stretchr/testify/suite"
+)
+
+type testClient struct{}
+
+func (c *testClient) LatestBlock() (*big.Int, error) {
+	return big.NewInt(10), nil
+}
+
+type testMetrics struct{}
+
+func (m *testMetrics) TrackBlockDelta(domainID uint8, head *big.Int, current *big.Int) {}
+
+type testBlockStorer struct{}
+
+func (b *testBlockStorer) StoreBlock(block *big.Int, domainID uint8) error {
+	return nil
+}
+
+type testEventHandler struct {
+	handledChn chan *big.Int
+}
+
+func (h *testEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int) error {
+	h.handledChn <- new(big.Int).Set(startBlock)
+	return nil
+}
+
+type EVMListenerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunEVMListenerTestSuite(t *testing.T) {
+	suite.Run(t, new(EVMListenerTestSuite))
+}
+
+func (s *EVMListenerTestSuite) Test_BlockConfirmationsChanged() {
+	handler := &testEventHandler{handledChn: make(chan *big.Int, 10)}
+	l := listener.NewEVMListener(
+		&testClient{},
+		[]listener.EventHandler{handler},
+		&testBlockStorer{},
+		&testMetrics{},
+		1,
+		time.Millisecond,
+		big.NewInt(20),
+		big.NewInt(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go l.ListenToEvents(ctx, big.NewInt(5))
+
+	select {
+	case <-handler.handledChn:
+		s.Fail("block handled before confirmations")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	l.SetBlockConfirmations(big.NewInt(4))
+
+	select {
+	case block := <-handler.handledChn:
+		s.Equal(block, big.NewInt(5))
+	case <-time.After(time.Second):
+		s.Fail("block not handled after confirmations changed")
+	}
+}
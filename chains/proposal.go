@@ -29,12 +29,7 @@ func ProposalsHash(proposals []*transfer.TransferProposal, chainID int64, verifC
 	}
 	typedData := apitypes.TypedData{
 		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{Name: "name", Type: "string"},
-				{Name: "version", Type: "string"},
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
-			},
+			"EIP712Domain": eip712DomainType,
 			"Proposal": []apitypes.Type{
 				{Name: "originDomainID", Type: "uint8"},
 				{Name: "depositNonce", Type: "uint64"},
@@ -46,13 +41,8 @@ func ProposalsHash(proposals []*transfer.TransferProposal, chainID int64, verifC
 			},
 		},
 		PrimaryType: "Proposals",
-		Domain: apitypes.TypedDataDomain{
-			Name:              "Bridge",
-			ChainId:           math.NewHexOrDecimal256(chainID),
-			Version:           bridgeVersion,
-			VerifyingContract: verifContract,
-		},
-		Message: message,
+		Domain:      bridgeDomain(chainID, verifContract, bridgeVersion),
+		Message:     message,
 	}
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
@@ -66,3 +56,30 @@ func ProposalsHash(proposals []*transfer.TransferProposal, chainID int64, verifC
 	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
 	return crypto.Keccak256(rawData), nil
 }
+
+// DomainSeparator returns hash of the EIP712 domain proposals are signed with
+func DomainSeparator(chainID int64, verifContract string, bridgeVersion string) ([]byte, error) {
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": eip712DomainType,
+		},
+		Domain: bridgeDomain(chainID, verifContract, bridgeVersion),
+	}
+	return typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+}
+
+var eip712DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+func bridgeDomain(chainID int64, verifContract string, bridgeVersion string) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              "Bridge",
+		ChainId:           math.NewHexOrDecimal256(chainID),
+		Version:           bridgeVersion,
+		VerifyingContract: verifContract,
+	}
+}
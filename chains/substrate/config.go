@@ -6,9 +6,9 @@ package substrate
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/creasty/defaults"
 	"github.com/mitchellh/mapstructure"
 
@@ -17,41 +17,58 @@ import (
 
 type RawSubstrateConfig struct {
 	chain.GeneralChainConfig `mapstructure:",squash"`
-	ChainID                  int64  `mapstructure:"chainID"`
-	StartBlock               int64  `mapstructure:"startBlock"`
-	BlockInterval            int64  `mapstructure:"blockInterval" default:"5"`
-	BlockRetryInterval       uint64 `mapstructure:"blockRetryInterval" default:"5"`
-	SubstrateNetwork         int64  `mapstructure:"substrateNetwork"`
-	Tip                      uint64 `mapstructure:"tip"`
+	ChainID                  int64    `mapstructure:"chainID"`
+	StartBlock               int64    `mapstructure:"startBlock"`
+	BlockInterval            int64    `mapstructure:"blockInterval" default:"5"`
+	BlockRetryInterval       uint64   `mapstructure:"blockRetryInterval" default:"5"`
+	SubstrateNetwork         int64    `mapstructure:"substrateNetwork"`
+	Tip                      uint64   `mapstructure:"tip"`
+	MaxTip                   uint64   `mapstructure:"maxTip"`
+	TipIncreasePercentage    uint64   `mapstructure:"tipIncreasePercentage" default:"15"`
+	ResubmitBlocks           uint64   `mapstructure:"resubmitBlocks" default:"10"`
+	BridgeVersion            string   `mapstructure:"bridgeVersion"`
+	VerifyingContract        string   `mapstructure:"verifyingContract"`
+	AllowedParachains        []uint32 `mapstructure:"allowedParachains"`
 }
 
 type SubstrateConfig struct {
-	GeneralChainConfig chain.GeneralChainConfig
-	ChainID            *big.Int
-	StartBlock         *big.Int
-	BlockInterval      *big.Int
-	BlockRetryInterval time.Duration
-	SubstrateNetwork   uint16
-	Tip                uint64
+	GeneralChainConfig    chain.GeneralChainConfig
+	ChainID               *big.Int
+	StartBlock            *big.Int
+	BlockInterval         *big.Int
+	BlockRetryInterval    time.Duration
+	SubstrateNetwork      uint16
+	Tip                   uint64
+	MaxTip                uint64
+	TipIncreasePercentage uint64
+	ResubmitBlocks        uint64
+	BridgeVersion         string
+	VerifyingContract     string
+	AllowedParachains     []uint32
 }
 
 func (c *SubstrateConfig) String() string {
-	kp, _ := signature.KeyringPairFromSecret(c.GeneralChainConfig.Key, c.SubstrateNetwork)
 	return fmt.Sprintf(`Name: '%s', Id: '%d', Type: '%s', BlockstorePath: '%s', FreshStart: '%t', 
-							  LatestBlock: '%t', Key address: '%s', StartBlock: '%s', BlockInterval: '%s', 
-                              BlockRetryInterval: '%s', ChainID: '%d', Tip: '%d', SubstrateNetworkPrefix: "%d"`,
+							  LatestBlock: '%t', StartBlock: '%s', BlockInterval: '%s', 
+                              BlockRetryInterval: '%s', ChainID: '%d', Tip: '%d', MaxTip: '%d', TipIncreasePercentage: '%d',
+                              ResubmitBlocks: '%d', BridgeVersion: '%s', VerifyingContract: '%s', AllowedParachains: '%v', SubstrateNetworkPrefix: "%d"`,
 		c.GeneralChainConfig.Name,
 		*c.GeneralChainConfig.Id,
 		c.GeneralChainConfig.Type,
 		c.GeneralChainConfig.BlockstorePath,
 		c.GeneralChainConfig.FreshStart,
 		c.GeneralChainConfig.LatestBlock,
-		kp.Address,
 		c.StartBlock,
 		c.BlockInterval,
 		c.BlockRetryInterval,
 		c.ChainID,
 		c.Tip,
+		c.MaxTip,
+		c.TipIncreasePercentage,
+		c.ResubmitBlocks,
+		c.BridgeVersion,
+		c.VerifyingContract,
+		c.AllowedParachains,
 		c.SubstrateNetwork,
 	)
 }
@@ -85,13 +102,19 @@ func NewSubstrateConfig(chainConfig map[string]interface{}) (*SubstrateConfig, e
 
 	c.GeneralChainConfig.ParseFlags()
 	config := &SubstrateConfig{
-		GeneralChainConfig: c.GeneralChainConfig,
-		ChainID:            big.NewInt(c.ChainID),
-		BlockRetryInterval: time.Duration(c.BlockRetryInterval) * time.Second,
-		StartBlock:         big.NewInt(c.StartBlock),
-		BlockInterval:      big.NewInt(c.BlockInterval),
-		SubstrateNetwork:   uint16(c.SubstrateNetwork),
-		Tip:                uint64(c.Tip),
+		GeneralChainConfig:    c.GeneralChainConfig,
+		ChainID:               big.NewInt(c.ChainID),
+		BlockRetryInterval:    time.Duration(c.BlockRetryInterval) * time.Second,
+		StartBlock:            big.NewInt(c.StartBlock),
+		BlockInterval:         big.NewInt(c.BlockInterval),
+		SubstrateNetwork:      uint16(c.SubstrateNetwork),
+		Tip:                   uint64(c.Tip),
+		MaxTip:                c.MaxTip,
+		TipIncreasePercentage: c.TipIncreasePercentage,
+		ResubmitBlocks:        c.ResubmitBlocks,
+		BridgeVersion:         c.BridgeVersion,
+		VerifyingContract:     strings.TrimPrefix(c.VerifyingContract, "0x"),
+		AllowedParachains:     c.AllowedParachains,
 	}
 
 	return config, nil
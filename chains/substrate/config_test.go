@@ -57,24 +57,31 @@ func (s *NewSubstrateConfigTestSuite) Test_ValidConfig() {
 			Endpoint: "ws://domain.com",
 			Id:       id,
 		},
-		StartBlock:         big.NewInt(0),
-		ChainID:            big.NewInt(5),
-		SubstrateNetwork:   uint16(0),
-		BlockInterval:      big.NewInt(5),
-		BlockRetryInterval: time.Duration(5) * time.Second,
+		StartBlock:            big.NewInt(0),
+		ChainID:               big.NewInt(5),
+		SubstrateNetwork:      uint16(0),
+		BlockInterval:         big.NewInt(5),
+		BlockRetryInterval:    time.Duration(5) * time.Second,
+		TipIncreasePercentage: 15,
+		ResubmitBlocks:        10,
 	})
 }
 
 func (s *NewSubstrateConfigTestSuite) Test_ValidConfigWithCustomParams() {
 	rawConfig := map[string]interface{}{
-		"id":                 1,
-		"endpoint":           "ws://domain.com",
-		"name":               "substrate1",
-		"chainID":            5,
-		"substrateNetwork":   0,
-		"startBlock":         1000,
-		"blockRetryInterval": 10,
-		"blockInterval":      2,
+		"id":                    1,
+		"endpoint":              "ws://domain.com",
+		"name":                  "substrate1",
+		"chainID":               5,
+		"substrateNetwork":      0,
+		"startBlock":            1000,
+		"blockRetryInterval":    10,
+		"blockInterval":         2,
+		"tip":                   100,
+		"maxTip":                1000,
+		"tipIncreasePercentage": 20,
+		"resubmitBlocks":        5,
+		"allowedParachains":     []interface{}{1000, 2000},
 	}
 
 	actualConfig, err := NewSubstrateConfig(rawConfig)
@@ -88,10 +95,15 @@ func (s *NewSubstrateConfigTestSuite) Test_ValidConfigWithCustomParams() {
 			Endpoint: "ws://domain.com",
 			Id:       id,
 		},
-		ChainID:            big.NewInt(5),
-		SubstrateNetwork:   uint16(0),
-		StartBlock:         big.NewInt(1000),
-		BlockInterval:      big.NewInt(2),
-		BlockRetryInterval: time.Duration(10) * time.Second,
+		ChainID:               big.NewInt(5),
+		SubstrateNetwork:      uint16(0),
+		StartBlock:            big.NewInt(1000),
+		BlockInterval:         big.NewInt(2),
+		BlockRetryInterval:    time.Duration(10) * time.Second,
+		Tip:                   100,
+		MaxTip:                1000,
+		TipIncreasePercentage: 20,
+		ResubmitBlocks:        5,
+		AllowedParachains:     []uint32{1000, 2000},
 	})
 }
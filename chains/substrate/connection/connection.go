@@ -0,0 +1,188 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package connection
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/rs/zerolog/log"
+	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
+)
+
+// maxCachedRuntimeVersions limits the number of block runtime versions kept in memory
+const maxCachedRuntimeVersions = 1000
+
+type runtimeRegistry struct {
+	meta          *types.Metadata
+	eventRegistry registry.EventRegistry
+}
+
+// Connection decodes block events with the metadata of the runtime that
+// produced the block, so events stay decodable across runtime upgrades
+// and when catching up on blocks produced before an upgrade.
+type Connection struct {
+	*connection.Connection
+
+	eventProvider   state.EventProvider
+	eventParser     parser.EventParser
+	registryFactory registry.Factory
+
+	lock            sync.Mutex
+	registries      map[types.U32]*runtimeRegistry
+	runtimeVersions map[types.Hash]types.RuntimeVersion
+}
+
+func NewSubstrateConnection(url string) (*Connection, error) {
+	conn, err := connection.NewSubstrateConnection(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{
+		Connection:      conn,
+		eventProvider:   state.NewEventProvider(conn.State),
+		eventParser:     parser.NewEventParser(),
+		registryFactory: registry.NewFactory(),
+		registries:      make(map[types.U32]*runtimeRegistry),
+		runtimeVersions: make(map[types.Hash]types.RuntimeVersion),
+	}, nil
+}
+
+// GetRuntimeVersion returns runtime version active at the block
+func (c *Connection) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
+	c.lock.Lock()
+	runtimeVersion, ok := c.runtimeVersions[blockHash]
+	c.lock.Unlock()
+	if ok {
+		return &runtimeVersion, nil
+	}
+
+	fetchedVersion, err := c.State.GetRuntimeVersion(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.runtimeVersions) >= maxCachedRuntimeVersions {
+		c.runtimeVersions = make(map[types.Hash]types.RuntimeVersion)
+	}
+	c.runtimeVersions[blockHash] = *fetchedVersion
+	return fetchedVersion, nil
+}
+
+// GetBlockEvents decodes block events with the metadata of the runtime that
+// executed the block. Blocks are executed with the runtime of their parent block,
+// so events of the block that upgrades the runtime are decoded with the metadata
+// of the previous runtime.
+func (c *Connection) GetBlockEvents(hash types.Hash) ([]*parser.Event, error) {
+	parentHash, err := c.parentHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	runtimeVersion, err := c.GetRuntimeVersion(parentHash)
+	if err != nil {
+		return nil, err
+	}
+	runtime, err := c.runtimeRegistryAt(parentHash, runtimeVersion.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	storageEvents, err := c.eventProvider.GetStorageEvents(runtime.meta, hash)
+	if err != nil {
+		return nil, err
+	}
+	return c.eventParser.ParseEvents(runtime.eventRegistry, storageEvents)
+}
+
+func (c *Connection) FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error) {
+	evts := make([]*parser.Event, 0)
+	for i := new(big.Int).Set(startBlock); i.Cmp(endBlock) <= 0; i.Add(i, big.NewInt(1)) {
+		hash, err := c.GetBlockHash(i.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		evt, err := c.GetBlockEvents(hash)
+		if err != nil {
+			return nil, err
+		}
+		evts = append(evts, evt...)
+	}
+	return evts, nil
+}
+
+// GetRuntimeVersionLatest returns runtime version of the latest block
+func (c *Connection) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	return c.State.GetRuntimeVersionLatest()
+}
+
+func (c *Connection) GetGenesisHash() types.Hash {
+	return c.GenesisHash
+}
+
+// AccountNextIndex returns next account nonce including extrinsics pending in the
+// transaction pool
+func (c *Connection) AccountNextIndex(address string) (uint64, error) {
+	var nonce uint64
+	err := c.Call(&nonce, "system_accountNextIndex", address)
+	if err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+func (c *Connection) SubmitAndWatchExtrinsic(ext extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	return c.Author.SubmitAndWatchExtrinsic(ext)
+}
+
+// parentHash returns hash of the parent block. Genesis block has no parent
+// so its own hash is returned.
+func (c *Connection) parentHash(hash types.Hash) (types.Hash, error) {
+	header, err := c.GetHeader(hash)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	if header.Number == 0 {
+		return hash, nil
+	}
+	return header.ParentHash, nil
+}
+
+// runtimeRegistryAt returns event registry for the spec version. Metadata is fetched
+// at the block the spec version was first seen at as any block with the same
+// spec version shares the same metadata.
+func (c *Connection) runtimeRegistryAt(hash types.Hash, specVersion types.U32) (*runtimeRegistry, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if runtime, ok := c.registries[specVersion]; ok {
+		return runtime, nil
+	}
+
+	meta, err := c.State.GetMetadata(hash)
+	if err != nil {
+		return nil, err
+	}
+	eventRegistry, err := c.registryFactory.CreateEventRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("Loaded substrate metadata for spec version %d", specVersion)
+	runtime := &runtimeRegistry{
+		meta:          meta,
+		eventRegistry: eventRegistry,
+	}
+	c.registries[specVersion] = runtime
+	return runtime, nil
+}
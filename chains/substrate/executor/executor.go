@@ -10,13 +10,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/transactor"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/binance-chain/tss-lib/common"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 
@@ -25,9 +26,14 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/tss"
-	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa"
 )
 
+type Batch struct {
+	proposals []*transfer.TransferProposal
+	weight    pallet.Weight
+}
+
 var (
 	executionCheckPeriod = time.Minute
 	signingTimeout       = 30 * time.Minute
@@ -35,110 +41,143 @@ var (
 
 type BridgePallet interface {
 	IsProposalExecuted(p *transfer.TransferProposal) (bool, error)
-	ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte) (types.Hash, *author.ExtrinsicStatusSubscription, error)
+	ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte) (types.Hash, error)
 	ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error)
-	TrackExtrinsic(extHash types.Hash, sub *author.ExtrinsicStatusSubscription) error
+	TrackExtrinsic(extHash types.Hash) (*transactor.Receipt, error)
+	EstimateWeight(proposals []*transfer.TransferProposal) (pallet.Weight, error)
+	MaxExtrinsicWeight() (pallet.Weight, error)
+	BaseExtrinsicWeight() (pallet.Weight, error)
+}
+
+// KeyVerifier refuses signing for domains whose local key doesn't match the on-chain key
+type KeyVerifier interface {
+	VerifyKey(domainID uint8) error
 }
 
 type Executor struct {
-	coordinator *tss.Coordinator
-	host        host.Host
-	comm        comm.Communication
-	fetcher     signing.SaveDataFetcher
-	bridge      BridgePallet
-	conn        *connection.Connection
-	exitLock    *sync.RWMutex
+	scheduler      *tss.Scheduler
+	host           host.Host
+	comm           comm.Communication
+	signer         ecdsa.SigningFactory
+	derivationPath []uint32
+	keyVerifier    KeyVerifier
+	bridge         BridgePallet
+	conn           *connection.Connection
+	exitLock       *sync.RWMutex
 }
 
 func NewExecutor(
 	host host.Host,
 	comm comm.Communication,
-	coordinator *tss.Coordinator,
+	scheduler *tss.Scheduler,
 	bridgePallet BridgePallet,
-	fetcher signing.SaveDataFetcher,
+	signer ecdsa.SigningFactory,
+	derivationPath []uint32,
+	keyVerifier KeyVerifier,
 	conn *connection.Connection,
 	exitLock *sync.RWMutex,
 ) *Executor {
 	return &Executor{
-		host:        host,
-		comm:        comm,
-		coordinator: coordinator,
-		bridge:      bridgePallet,
-		fetcher:     fetcher,
-		conn:        conn,
-		exitLock:    exitLock,
+		host:           host,
+		comm:           comm,
+		scheduler:      scheduler,
+		bridge:         bridgePallet,
+		signer:         signer,
+		derivationPath: derivationPath,
+		keyVerifier:    keyVerifier,
+		conn:           conn,
+		exitLock:       exitLock,
 	}
 }
 
-// Execute starts a signing process and executes proposals when signature is generated
+// Execute starts a signing process for each proposal batch and executes
+// proposals when signature is generated
 func (e *Executor) Execute(proposals []*proposal.Proposal) error {
 	e.exitLock.RLock()
 	defer e.exitLock.RUnlock()
-
-	transferProposals := make([]*transfer.TransferProposal, 0)
-	for _, prop := range proposals {
-		transferProposal := &transfer.TransferProposal{
-			Source:      prop.Source,
-			Destination: prop.Destination,
-			Data:        prop.Data.(transfer.TransferProposalData),
-			Type:        prop.Type,
-			MessageID:   prop.MessageID,
-		}
-		transferProposals = append(transferProposals, transferProposal)
-
-		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
-		if err != nil {
-			return err
-		}
-		if isExecuted {
-			continue
-		}
-
-		proposals = append(proposals, prop)
-	}
 	if len(proposals) == 0 {
-		return nil
+		return fmt.Errorf("no proposals to execute")
 	}
 
-	propHash, err := e.bridge.ProposalsHash(transferProposals)
+	err := e.keyVerifier.VerifyKey(proposals[0].Destination)
 	if err != nil {
 		return err
 	}
 
-	messageID := transferProposals[0].MessageID
-	msg := big.NewInt(0)
-	msg.SetBytes(propHash)
-	signing, err := signing.NewSigning(
-		msg,
-		messageID,
-		messageID,
-		e.host,
-		e.comm,
-		e.fetcher)
+	batches, err := e.proposalBatches(proposals)
 	if err != nil {
 		return err
 	}
 
-	sigChn := make(chan interface{})
-	executionContext, cancelExecution := context.WithCancel(context.Background())
-	watchContext, cancelWatch := context.WithCancel(context.Background())
-
-	pool := pool.New().WithErrors()
-	pool.Go(func() error {
-		err := e.coordinator.Execute(executionContext, []tss.TssProcess{signing}, sigChn)
-		if err != nil {
-			cancelWatch()
+	p := pool.New().WithErrors()
+	for i, batch := range batches {
+		if len(batch.proposals) == 0 {
+			continue
 		}
+		messageID := batch.proposals[0].MessageID
 
-		return err
-	})
-	pool.Go(func() error {
-		return e.watchExecution(watchContext, cancelExecution, transferProposals, sigChn, messageID)
-	})
-	return pool.Wait()
+		i := i
+		b := batch
+		p.Go(func() error {
+			propHash, err := e.bridge.ProposalsHash(b.proposals)
+			if err != nil {
+				return err
+			}
+
+			sessionID := fmt.Sprintf("%s-%d", messageID, i)
+			log.Info().Str("messageID", messageID).Msgf("Starting session with ID: %s", sessionID)
+
+			msg := big.NewInt(0)
+			msg.SetBytes(propHash)
+			signing, err := e.signer.NewSigning(msg, e.derivationPath, messageID, sessionID)
+			if err != nil {
+				return err
+			}
+
+			sigChn := make(chan interface{})
+			executionContext, cancelExecution := context.WithCancel(context.Background())
+			watchContext, cancelWatch := context.WithCancel(context.Background())
+			ep := pool.New().WithErrors()
+			ep.Go(func() error {
+				err := e.scheduler.Execute(
+					executionContext,
+					batchPriority(b.proposals),
+					b.proposals[0].Destination,
+					[]tss.TssProcess{signing},
+					sigChn)
+				if err != nil {
+					cancelWatch()
+				}
+
+				return err
+			})
+			ep.Go(func() error {
+				return e.watchExecution(watchContext, cancelExecution, b, msg, sigChn, sessionID, messageID)
+			})
+			return ep.Wait()
+		})
+	}
+	return p.Wait()
+}
+
+// batchPriority returns retry priority if any of the batch proposals is retried
+func batchPriority(proposals []*transfer.TransferProposal) tss.Priority {
+	for _, prop := range proposals {
+		if transfer.IsRetry(prop.Data.Metadata) {
+			return tss.PriorityRetry
+		}
+	}
+	return tss.PriorityNormal
 }
 
-func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.CancelFunc, proposals []*transfer.TransferProposal, sigChn chan interface{}, sessionID string) error {
+func (e *Executor) watchExecution(
+	ctx context.Context,
+	cancelExecution context.CancelFunc,
+	batch *Batch,
+	msg *big.Int,
+	sigChn chan interface{},
+	sessionID string,
+	messageID string) error {
 	ticker := time.NewTicker(executionCheckPeriod)
 	timeout := time.NewTicker(signingTimeout)
 	defer ticker.Stop()
@@ -155,21 +194,28 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 				}
 
 				signatureData := sigResult.(*common.SignatureData)
-				hash, sub, err := e.executeProposal(proposals, signatureData)
+				hash, err := e.executeProposal(batch.proposals, msg, signatureData)
 				if err != nil {
 					_ = e.comm.Broadcast(e.host.Peerstore().Peers(), []byte{}, comm.TssFailMsg, sessionID)
 					return err
 				}
 
-				return e.bridge.TrackExtrinsic(hash, sub)
+				log.Info().Str("messageID", messageID).Msgf("Sent proposals execution with hash: %s", hash.Hex())
+				receipt, err := e.bridge.TrackExtrinsic(hash)
+				if err != nil {
+					return err
+				}
+
+				log.Info().Str("messageID", messageID).Msgf("Executed proposals in block %d", receipt.BlockNumber)
+				return nil
 			}
 		case <-ticker.C:
 			{
-				if !e.areProposalsExecuted(proposals) {
+				if !e.areProposalsExecuted(batch.proposals) {
 					continue
 				}
 
-				log.Info().Str("messageID", sessionID).Msgf("Successfully executed proposals")
+				log.Info().Str("messageID", messageID).Msgf("Successfully executed proposals")
 				return nil
 			}
 		case <-timeout.C:
@@ -184,19 +230,90 @@ func (e *Executor) watchExecution(ctx context.Context, cancelExecution context.C
 	}
 }
 
-func (e *Executor) executeProposal(proposals []*transfer.TransferProposal, signatureData *common.SignatureData) (types.Hash, *author.ExtrinsicStatusSubscription, error) {
+// proposalBatches filters out executed proposals and splits the rest into batches
+// so that the estimated weight of each batch extrinsic fits into the max extrinsic weight.
+// Estimated weight of a single proposal extrinsic includes the base extrinsic weight
+// which is counted only once per batch.
+func (e *Executor) proposalBatches(proposals []*proposal.Proposal) ([]*Batch, error) {
+	maxWeight, err := e.bridge.MaxExtrinsicWeight()
+	if err != nil {
+		return nil, err
+	}
+	baseWeight, err := e.bridge.BaseExtrinsicWeight()
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*Batch, 1)
+	currentBatch := &Batch{
+		proposals: make([]*transfer.TransferProposal, 0),
+		weight:    baseWeight,
+	}
+	batches[0] = currentBatch
+
+	for _, prop := range proposals {
+		transferProposal := &transfer.TransferProposal{
+			Source:      prop.Source,
+			Destination: prop.Destination,
+			Data:        prop.Data.(transfer.TransferProposalData),
+			Type:        prop.Type,
+			MessageID:   prop.MessageID,
+		}
+
+		isExecuted, err := e.bridge.IsProposalExecuted(transferProposal)
+		if err != nil {
+			return nil, err
+		}
+		if isExecuted {
+			log.Info().Str("messageID", transferProposal.MessageID).Msgf(
+				"Proposal with deposit nonce %d from domain %d already executed", transferProposal.Data.DepositNonce, transferProposal.Source)
+			continue
+		}
+
+		extrinsicWeight, err := e.bridge.EstimateWeight([]*transfer.TransferProposal{transferProposal})
+		if err != nil {
+			return nil, err
+		}
+		propWeight := extrinsicWeight.Sub(baseWeight)
+		if len(currentBatch.proposals) > 0 && currentBatch.weight.Add(propWeight).Exceeds(maxWeight) {
+			currentBatch = &Batch{
+				proposals: make([]*transfer.TransferProposal, 0),
+				weight:    baseWeight,
+			}
+			batches = append(batches, currentBatch)
+		}
+
+		currentBatch.weight = currentBatch.weight.Add(propWeight)
+		currentBatch.proposals = append(currentBatch.proposals, transferProposal)
+	}
+
+	return batches, nil
+}
+
+// executeProposal submits proposals with the signature after checking that
+// the signature was generated with the expected key
+func (e *Executor) executeProposal(proposals []*transfer.TransferProposal, msg *big.Int, signatureData *common.SignatureData) (types.Hash, error) {
+	publicKey, err := e.signer.PublicKey(e.derivationPath)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	err = ecdsa.VerifySignature(msg, signatureData, publicKey)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
 	sig := []byte{}
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.R, 32)...)
 	sig = append(sig[:], ethCommon.LeftPadBytes(signatureData.S, 32)...)
 	sig = append(sig[:], signatureData.SignatureRecovery...)
 	sig[len(sig)-1] += 27 // Transform V from 0/1 to 27/28
 
-	hash, sub, err := e.bridge.ExecuteProposals(proposals, sig)
+	hash, err := e.bridge.ExecuteProposals(proposals, sig)
 	if err != nil {
-		return types.Hash{}, nil, err
+		return types.Hash{}, err
 	}
 
-	return hash, sub, err
+	return hash, err
 }
 
 func (e *Executor) areProposalsExecuted(proposals []*transfer.TransferProposal) bool {
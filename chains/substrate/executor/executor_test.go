@@ -0,0 +1,169 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	mock_executor "github.com/ChainSafe/sygma-relayer/chains/substrate/executor/mock"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
+)
+
+type ProposalBatchesTestSuite struct {
+	suite.Suite
+	mockBridge *mock_executor.MockBridgePallet
+	executor   *Executor
+}
+
+func TestRunProposalBatchesTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalBatchesTestSuite))
+}
+
+func (s *ProposalBatchesTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockBridge = mock_executor.NewMockBridgePallet(ctrl)
+	s.executor = &Executor{
+		bridge: s.mockBridge,
+	}
+}
+
+func (s *ProposalBatchesTestSuite) proposals(count int) []*proposal.Proposal {
+	proposals := make([]*proposal.Proposal, count)
+	for i := range proposals {
+		proposals[i] = &proposal.Proposal{
+			Source:      1,
+			Destination: 2,
+			Data: transfer.TransferProposalData{
+				DepositNonce: uint64(i),
+			},
+			Type:      transfer.TransferProposalType,
+			MessageID: "messageID",
+		}
+	}
+	return proposals
+}
+
+func (s *ProposalBatchesTestSuite) batchNonces(batches []*Batch) [][]uint64 {
+	nonces := make([][]uint64, len(batches))
+	for i, batch := range batches {
+		nonces[i] = make([]uint64, len(batch.proposals))
+		for j, prop := range batch.proposals {
+			nonces[i][j] = prop.Data.DepositNonce
+		}
+	}
+	return nonces
+}
+
+func (s *ProposalBatchesTestSuite) Test_MaxWeightFails() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{}, errors.New("error"))
+
+	_, err := s.executor.proposalBatches(s.proposals(2))
+
+	s.NotNil(err)
+}
+
+func (s *ProposalBatchesTestSuite) Test_ExecutionCheckFails() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, errors.New("error"))
+
+	_, err := s.executor.proposalBatches(s.proposals(2))
+
+	s.NotNil(err)
+}
+
+func (s *ProposalBatchesTestSuite) Test_ExecutedProposalsFiltered() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).DoAndReturn(func(p *transfer.TransferProposal) (bool, error) {
+		return p.Data.DepositNonce == 1, nil
+	}).Times(3)
+	s.mockBridge.EXPECT().EstimateWeight(gomock.Any()).Return(pallet.Weight{RefTime: 10, ProofSize: 10}, nil).Times(2)
+
+	batches, err := s.executor.proposalBatches(s.proposals(3))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{0, 2}})
+	s.Equal(batches[0].weight, pallet.Weight{RefTime: 20, ProofSize: 20})
+}
+
+func (s *ProposalBatchesTestSuite) Test_AllProposalsExecuted_EmptyBatch() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(true, nil).Times(2)
+
+	batches, err := s.executor.proposalBatches(s.proposals(2))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{}})
+}
+
+func (s *ProposalBatchesTestSuite) Test_ProposalsSplitByRefTime() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, nil).Times(5)
+	s.mockBridge.EXPECT().EstimateWeight(gomock.Any()).Return(pallet.Weight{RefTime: 40, ProofSize: 10}, nil).Times(5)
+
+	batches, err := s.executor.proposalBatches(s.proposals(5))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{0, 1}, {2, 3}, {4}})
+}
+
+func (s *ProposalBatchesTestSuite) Test_ProposalsSplitByProofSize() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, nil).Times(3)
+	s.mockBridge.EXPECT().EstimateWeight(gomock.Any()).Return(pallet.Weight{RefTime: 10, ProofSize: 60}, nil).Times(3)
+
+	batches, err := s.executor.proposalBatches(s.proposals(3))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{0}, {1}, {2}})
+}
+
+func (s *ProposalBatchesTestSuite) Test_ProposalOverMaxWeight_OwnBatch() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, nil).Times(3)
+	s.mockBridge.EXPECT().EstimateWeight(gomock.Any()).DoAndReturn(func(proposals []*transfer.TransferProposal) (pallet.Weight, error) {
+		if proposals[0].Data.DepositNonce == 1 {
+			return pallet.Weight{RefTime: 150, ProofSize: 10}, nil
+		}
+		return pallet.Weight{RefTime: 10, ProofSize: 10}, nil
+	}).Times(3)
+
+	batches, err := s.executor.proposalBatches(s.proposals(3))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{0}, {1}, {2}})
+}
+
+func (s *ProposalBatchesTestSuite) Test_BaseWeightCountedOncePerBatch() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{RefTime: 30, ProofSize: 30}, nil)
+	s.mockBridge.EXPECT().IsProposalExecuted(gomock.Any()).Return(false, nil).Times(5)
+	s.mockBridge.EXPECT().EstimateWeight(gomock.Any()).Return(pallet.Weight{RefTime: 50, ProofSize: 40}, nil).Times(5)
+
+	batches, err := s.executor.proposalBatches(s.proposals(5))
+
+	s.Nil(err)
+	s.Equal(s.batchNonces(batches), [][]uint64{{0, 1, 2}, {3, 4}})
+	s.Equal(batches[0].weight, pallet.Weight{RefTime: 90, ProofSize: 60})
+	s.Equal(batches[1].weight, pallet.Weight{RefTime: 70, ProofSize: 50})
+}
+
+func (s *ProposalBatchesTestSuite) Test_BaseWeightFails() {
+	s.mockBridge.EXPECT().MaxExtrinsicWeight().Return(pallet.Weight{RefTime: 100, ProofSize: 100}, nil)
+	s.mockBridge.EXPECT().BaseExtrinsicWeight().Return(pallet.Weight{}, errors.New("error"))
+
+	_, err := s.executor.proposalBatches(s.proposals(2))
+
+	s.NotNil(err)
+}
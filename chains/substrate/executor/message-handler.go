@@ -14,11 +14,23 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rs/zerolog/log"
 	"github.com/sygmaprotocol/sygma-core/relayer/message"
 	"github.com/sygmaprotocol/sygma-core/relayer/proposal"
 )
 
-type SubstrateMessageHandler struct{}
+type SubstrateMessageHandler struct {
+	recipientValidator *RecipientValidator
+	propStorer         PropStorer
+}
+
+func NewSubstrateMessageHandler(recipientValidator *RecipientValidator, propStorer PropStorer) *SubstrateMessageHandler {
+	return &SubstrateMessageHandler{
+		recipientValidator: recipientValidator,
+		propStorer:         propStorer,
+	}
+}
 
 func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
 	transferMessage := &transfer.TransferMessage{
@@ -30,11 +42,39 @@ func (mh *SubstrateMessageHandler) HandleMessage(m *message.Message) (*proposal.
 	}
 	switch transferMessage.Data.Type {
 	case transfer.FungibleTransfer:
-		return fungibleTransferMessageHandler(transferMessage)
+		prop, err := fungibleTransferMessageHandler(transferMessage)
+		if err != nil {
+			return nil, err
+		}
+		err = mh.validateRecipient(transferMessage)
+		if err != nil {
+			return nil, err
+		}
+		return prop, nil
+	case transfer.NonFungibleTransfer:
+		return nonFungibleTransferMessageHandler(transferMessage)
+	case transfer.PermissionlessGenericTransfer:
+		return permissionlessGenericMessageHandler(transferMessage)
 	}
 	return nil, errors.New("wrong message type passed while handling message")
 }
 
+// validateRecipient checks the fungible transfer recipient is a supported MultiLocation
+// and marks the transfer as failed if it is not, so it is never signed and submitted
+func (mh *SubstrateMessageHandler) validateRecipient(m *transfer.TransferMessage) error {
+	recipient := m.Data.Payload[1].([]byte)
+	err := mh.recipientValidator.Validate(recipient)
+	if err == nil {
+		return nil
+	}
+
+	storeErr := mh.propStorer.StorePropStatus(m.Source, m.Destination, m.Data.DepositNonce, store.FailedProp)
+	if storeErr != nil {
+		log.Err(storeErr).Str("messageID", m.ID).Msgf("Failed storing proposal status")
+	}
+	return fmt.Errorf("invalid recipient MultiLocation %s: %w", hexutil.Encode(recipient), err)
+}
+
 func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
 	if len(m.Data.Payload) != 2 {
 		return nil, errors.New("malformed payload. Len  of payload should be 2")
@@ -61,6 +101,86 @@ func fungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Prop
 	}, m.ID, transfer.TransferProposalType), nil
 }
 
+func nonFungibleTransferMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
+	if len(m.Data.Payload) != 3 {
+		return nil, errors.New("malformed payload. Len  of payload should be 3")
+	}
+	tokenID, ok := m.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload tokenID format")
+	}
+	recipient, ok := m.Data.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload recipient format")
+	}
+	metadata, ok := m.Data.Payload[2].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload metadata format")
+	}
+	var data []byte
+	data = append(data, common.LeftPadBytes(tokenID, 32)...) // tokenID (uint256)
+
+	recipientLen := big.NewInt(int64(len(recipient))).Bytes()
+	data = append(data, common.LeftPadBytes(recipientLen, 32)...)
+	data = append(data, recipient...)
+
+	metadataLen := big.NewInt(int64(len(metadata))).Bytes()
+	data = append(data, common.LeftPadBytes(metadataLen, 32)...)
+	data = append(data, metadata...)
+	return proposal.NewProposal(m.Source, m.Destination, transfer.TransferProposalData{
+		DepositNonce: m.Data.DepositNonce,
+		ResourceId:   m.Data.ResourceId,
+		Metadata:     m.Data.Metadata,
+		Data:         data,
+	}, m.ID, transfer.TransferProposalType), nil
+}
+
+func permissionlessGenericMessageHandler(m *transfer.TransferMessage) (*proposal.Proposal, error) {
+	if len(m.Data.Payload) != 5 {
+		return nil, errors.New("malformed payload. Len  of payload should be 5")
+	}
+	functionSig, ok := m.Data.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong function signature format")
+	}
+	contractAddress, ok := m.Data.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong contract address format")
+	}
+	maxFee, ok := m.Data.Payload[2].([]byte)
+	if !ok {
+		return nil, errors.New("wrong max fee format")
+	}
+	depositor, ok := m.Data.Payload[3].([]byte)
+	if !ok {
+		return nil, errors.New("wrong depositor data format")
+	}
+	executionData, ok := m.Data.Payload[4].([]byte)
+	if !ok {
+		return nil, errors.New("wrong execution data format")
+	}
+	var data []byte
+	data = append(data, common.LeftPadBytes(maxFee, 32)...) // max fee (uint256)
+
+	functionSigLen := big.NewInt(int64(len(functionSig))).Bytes()
+	data = append(data, common.LeftPadBytes(functionSigLen, 2)...)
+	data = append(data, functionSig...)
+
+	data = append(data, byte(len(contractAddress)))
+	data = append(data, contractAddress...)
+
+	data = append(data, byte(len(depositor)))
+	data = append(data, depositor...)
+
+	data = append(data, executionData...)
+	return proposal.NewProposal(m.Source, m.Destination, transfer.TransferProposalData{
+		DepositNonce: m.Data.DepositNonce,
+		ResourceId:   m.Data.ResourceId,
+		Metadata:     m.Data.Metadata,
+		Data:         data,
+	}, m.ID, transfer.TransferProposalType), nil
+}
+
 type PropStorer interface {
 	StorePropStatus(source, destination uint8, depositNonce uint64, status store.PropStatus) error
 	PropStatus(source, destination uint8, depositNonce uint64) (store.PropStatus, error)
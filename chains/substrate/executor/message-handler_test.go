@@ -70,7 +70,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessage() {
 		Type: transfer.TransferProposalType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(err)
@@ -93,7 +93,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -118,7 +118,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -143,7 +143,7 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 		Type: transfer.TransferMessageType,
 	}
 
-	mh := executor.SubstrateMessageHandler{}
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
 	prop, err := mh.HandleMessage(message)
 
 	s.Nil(prop)
@@ -151,6 +151,61 @@ func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInco
 	s.EqualError(err, errIncorrectRecipient.Error())
 }
 
+func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageInvalidMultiLocation() {
+	message := &message.Message{
+		Source:      1,
+		Destination: 0,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{0},
+			Payload: []interface{}{
+				[]byte{2},                     // amount
+				[]byte{0x8e, 0xaf, 0x4, 0x15}, // recipientAddress
+			},
+			Type: transfer.FungibleTransfer,
+		},
+
+		Type: transfer.TransferMessageType,
+	}
+	ctrl := gomock.NewController(s.T())
+	mockPropStorer := mock_executor.NewMockPropStorer(ctrl)
+	mockPropStorer.EXPECT().StorePropStatus(uint8(1), uint8(0), uint64(1), store.FailedProp).Return(nil)
+
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), mockPropStorer)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
+func (s *FungibleTransferHandlerTestSuite) TestFungibleTransferHandleMessageParachainNotAllowed() {
+	recipient := append([]byte{1, 2, 0, 0xa1, 0x0f, 1, 0}, make([]byte, 32)...) // (1, X2(Parachain(1000), AccountId32))
+	message := &message.Message{
+		Source:      1,
+		Destination: 0,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{0},
+			Payload: []interface{}{
+				[]byte{2}, // amount
+				recipient,
+			},
+			Type: transfer.FungibleTransfer,
+		},
+
+		Type: transfer.TransferMessageType,
+	}
+	ctrl := gomock.NewController(s.T())
+	mockPropStorer := mock_executor.NewMockPropStorer(ctrl)
+	mockPropStorer.EXPECT().StorePropStatus(uint8(1), uint8(0), uint64(1), store.FailedProp).Return(nil)
+
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator([]uint32{2000}), mockPropStorer)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(prop)
+	s.NotNil(err)
+}
+
 func (s *FungibleTransferHandlerTestSuite) TestSuccesfullyRegisterFungibleTransferMessageHandler() {
 	recipientAddr := *(*[]types.U8)(unsafe.Pointer(&substrate.SubstratePK.PublicKey))
 	recipient := substrate.ConstructRecipientData(recipientAddr)
@@ -188,7 +243,7 @@ func (s *FungibleTransferHandlerTestSuite) TestSuccesfullyRegisterFungibleTransf
 
 	depositMessageHandler := message.NewMessageHandler()
 	// Register FungibleTransferMessageHandler function
-	depositMessageHandler.RegisterMessageHandler(transfer.TransferMessageType, &executor.SubstrateMessageHandler{})
+	depositMessageHandler.RegisterMessageHandler(transfer.TransferMessageType, executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil))
 	prop1, err1 := depositMessageHandler.HandleMessage(messageData)
 	s.Nil(err1)
 	s.NotNil(prop1)
@@ -199,6 +254,96 @@ func (s *FungibleTransferHandlerTestSuite) TestSuccesfullyRegisterFungibleTransf
 	s.NotNil(err2)
 }
 
+type NonFungibleTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunNonFungibleTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NonFungibleTransferHandlerTestSuite))
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleMessage() {
+	message := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{7},       // tokenID
+				[]byte{1, 2, 3}, // recipient
+				[]byte{4, 5},    // metadata
+			},
+			Type: transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+	data, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000007000000000000000000000000000000000000000000000000000000000000000301020300000000000000000000000000000000000000000000000000000000000000020405")
+
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(err)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, data)
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleMessageIncorrectDataLen() {
+	message := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{7},
+			},
+			Type: transfer.NonFungibleTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(prop)
+	s.EqualError(err, "malformed payload. Len  of payload should be 3")
+}
+
+type PermissionlessGenericHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunPermissionlessGenericHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PermissionlessGenericHandlerTestSuite))
+}
+
+func (s *PermissionlessGenericHandlerTestSuite) TestHandleMessage() {
+	message := &message.Message{
+		Source:      1,
+		Destination: 2,
+		Data: transfer.TransferMessageData{
+			DepositNonce: 1,
+			ResourceId:   [32]byte{1},
+			Payload: []interface{}{
+				[]byte{0x65, 0x4c, 0xf8, 0x8c}, // function signature
+				[]byte{1, 2},                   // contract address
+				[]byte{0x03, 0x0d, 0x40},       // max fee
+				[]byte{3},                      // depositor
+				[]byte{4, 5},                   // execution data
+			},
+			Type: transfer.PermissionlessGenericTransfer,
+		},
+		Type: transfer.TransferMessageType,
+	}
+	data, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000030d400004654cf88c02010201030405")
+
+	mh := executor.NewSubstrateMessageHandler(executor.NewRecipientValidator(nil), nil)
+	prop, err := mh.HandleMessage(message)
+
+	s.Nil(err)
+	s.Equal(prop.Data.(transfer.TransferProposalData).Data, data)
+}
+
 type RetryMessageHandlerTestSuite struct {
 	suite.Suite
 
@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/executor/executor.go
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	reflect "reflect"
+
+	pallet "github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	transactor "github.com/ChainSafe/sygma-relayer/chains/substrate/transactor"
+	transfer "github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBridgePallet is a mock of BridgePallet interface.
+type MockBridgePallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockBridgePalletMockRecorder
+}
+
+// MockBridgePalletMockRecorder is the mock recorder for MockBridgePallet.
+type MockBridgePalletMockRecorder struct {
+	mock *MockBridgePallet
+}
+
+// NewMockBridgePallet creates a new mock instance.
+func NewMockBridgePallet(ctrl *gomock.Controller) *MockBridgePallet {
+	mock := &MockBridgePallet{ctrl: ctrl}
+	mock.recorder = &MockBridgePalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBridgePallet) EXPECT() *MockBridgePalletMockRecorder {
+	return m.recorder
+}
+
+// BaseExtrinsicWeight mocks base method.
+func (m *MockBridgePallet) BaseExtrinsicWeight() (pallet.Weight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseExtrinsicWeight")
+	ret0, _ := ret[0].(pallet.Weight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BaseExtrinsicWeight indicates an expected call of BaseExtrinsicWeight.
+func (mr *MockBridgePalletMockRecorder) BaseExtrinsicWeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseExtrinsicWeight", reflect.TypeOf((*MockBridgePallet)(nil).BaseExtrinsicWeight))
+}
+
+// EstimateWeight mocks base method.
+func (m *MockBridgePallet) EstimateWeight(proposals []*transfer.TransferProposal) (pallet.Weight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateWeight", proposals)
+	ret0, _ := ret[0].(pallet.Weight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateWeight indicates an expected call of EstimateWeight.
+func (mr *MockBridgePalletMockRecorder) EstimateWeight(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateWeight", reflect.TypeOf((*MockBridgePallet)(nil).EstimateWeight), proposals)
+}
+
+// ExecuteProposals mocks base method.
+func (m *MockBridgePallet) ExecuteProposals(proposals []*transfer.TransferProposal, signature []byte) (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteProposals", proposals, signature)
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteProposals indicates an expected call of ExecuteProposals.
+func (mr *MockBridgePalletMockRecorder) ExecuteProposals(proposals, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteProposals", reflect.TypeOf((*MockBridgePallet)(nil).ExecuteProposals), proposals, signature)
+}
+
+// IsProposalExecuted mocks base method.
+func (m *MockBridgePallet) IsProposalExecuted(p *transfer.TransferProposal) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProposalExecuted", p)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProposalExecuted indicates an expected call of IsProposalExecuted.
+func (mr *MockBridgePalletMockRecorder) IsProposalExecuted(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProposalExecuted", reflect.TypeOf((*MockBridgePallet)(nil).IsProposalExecuted), p)
+}
+
+// MaxExtrinsicWeight mocks base method.
+func (m *MockBridgePallet) MaxExtrinsicWeight() (pallet.Weight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxExtrinsicWeight")
+	ret0, _ := ret[0].(pallet.Weight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaxExtrinsicWeight indicates an expected call of MaxExtrinsicWeight.
+func (mr *MockBridgePalletMockRecorder) MaxExtrinsicWeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxExtrinsicWeight", reflect.TypeOf((*MockBridgePallet)(nil).MaxExtrinsicWeight))
+}
+
+// ProposalsHash mocks base method.
+func (m *MockBridgePallet) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposalsHash", proposals)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposalsHash indicates an expected call of ProposalsHash.
+func (mr *MockBridgePalletMockRecorder) ProposalsHash(proposals interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalsHash", reflect.TypeOf((*MockBridgePallet)(nil).ProposalsHash), proposals)
+}
+
+// TrackExtrinsic mocks base method.
+func (m *MockBridgePallet) TrackExtrinsic(extHash types.Hash) (*transactor.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackExtrinsic", extHash)
+	ret0, _ := ret[0].(*transactor.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackExtrinsic indicates an expected call of TrackExtrinsic.
+func (mr *MockBridgePalletMockRecorder) TrackExtrinsic(extHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackExtrinsic", reflect.TypeOf((*MockBridgePallet)(nil).TrackExtrinsic), extHash)
+}
+
+// MockKeyVerifier is a mock of KeyVerifier interface.
+type MockKeyVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyVerifierMockRecorder
+}
+
+// MockKeyVerifierMockRecorder is the mock recorder for MockKeyVerifier.
+type MockKeyVerifierMockRecorder struct {
+	mock *MockKeyVerifier
+}
+
+// NewMockKeyVerifier creates a new mock instance.
+func NewMockKeyVerifier(ctrl *gomock.Controller) *MockKeyVerifier {
+	mock := &MockKeyVerifier{ctrl: ctrl}
+	mock.recorder = &MockKeyVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyVerifier) EXPECT() *MockKeyVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyKey mocks base method.
+func (m *MockKeyVerifier) VerifyKey(domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyKey", domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyKey indicates an expected call of VerifyKey.
+func (mr *MockKeyVerifierMockRecorder) VerifyKey(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyKey", reflect.TypeOf((*MockKeyVerifier)(nil).VerifyKey), domainID)
+}
@@ -0,0 +1,213 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+)
+
+type JunctionType uint8
+
+const (
+	Parachain JunctionType = iota
+	AccountID32
+	AccountIndex64
+	AccountKey20
+	PalletInstance
+	GeneralIndex
+	GeneralKey
+	OnlyChild
+	Plurality
+	GlobalConsensus
+)
+
+const maxJunctions = 8
+
+// Junction is a decoded XCM v3 junction. Only parachain ID is decoded
+// from junction data as the rest is not needed to validate recipients.
+type Junction struct {
+	Type        JunctionType
+	ParachainID uint32
+}
+
+// MultiLocation is a decoded XCM v3 MultiLocation
+type MultiLocation struct {
+	Parents  uint8
+	Interior []Junction
+}
+
+// DecodeMultiLocation SCALE decodes XCM v3 MultiLocation and fails
+// if the location contains unsupported junctions or trailing data
+func DecodeMultiLocation(data []byte) (*MultiLocation, error) {
+	reader := bytes.NewReader(data)
+	decoder := scale.NewDecoder(reader)
+
+	parents, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding parents: %w", err)
+	}
+	junctionsLen, err := decoder.ReadOneByte()
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding junctions: %w", err)
+	}
+	if junctionsLen > maxJunctions {
+		return nil, fmt.Errorf("invalid junctions variant %d", junctionsLen)
+	}
+
+	location := &MultiLocation{
+		Parents:  parents,
+		Interior: make([]Junction, junctionsLen),
+	}
+	for i := range location.Interior {
+		junction, err := decodeJunction(decoder)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding junction %d: %w", i, err)
+		}
+		location.Interior[i] = junction
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after location", reader.Len())
+	}
+	return location, nil
+}
+
+func decodeJunction(decoder *scale.Decoder) (Junction, error) {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return Junction{}, err
+	}
+
+	junction := Junction{Type: JunctionType(b)}
+	switch junction.Type {
+	case Parachain:
+		id, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return Junction{}, err
+		}
+		if !id.IsUint64() || id.Uint64() > uint64(^uint32(0)) {
+			return Junction{}, fmt.Errorf("parachain ID %s out of range", id)
+		}
+		junction.ParachainID = uint32(id.Uint64())
+	case AccountID32:
+		err = decodeNetworkID(decoder)
+		if err != nil {
+			return Junction{}, err
+		}
+		err = decoder.Read(make([]byte, 32))
+		if err != nil {
+			return Junction{}, err
+		}
+	case AccountKey20:
+		err = decodeNetworkID(decoder)
+		if err != nil {
+			return Junction{}, err
+		}
+		err = decoder.Read(make([]byte, 20))
+		if err != nil {
+			return Junction{}, err
+		}
+	default:
+		return Junction{}, fmt.Errorf("unsupported junction type %d", b)
+	}
+
+	return junction, nil
+}
+
+// decodeNetworkID skips optional XCM v3 network ID
+func decodeNetworkID(decoder *scale.Decoder) error {
+	hasNetwork, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch hasNetwork {
+	case 0:
+		return nil
+	case 1:
+	default:
+		return fmt.Errorf("invalid network option %d", hasNetwork)
+	}
+
+	network, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+	switch network {
+	case 0: // ByGenesis
+		return decoder.Read(make([]byte, 32))
+	case 1: // ByFork
+		return decoder.Read(make([]byte, 8+32))
+	case 2, 3, 4, 5, 6, 8, 9: // Polkadot, Kusama, Westend, Rococo, Wococo, BitcoinCore, BitcoinCash
+		return nil
+	case 7: // Ethereum
+		_, err := decoder.DecodeUintCompact()
+		return err
+	default:
+		return fmt.Errorf("invalid network ID %d", network)
+	}
+}
+
+// RecipientValidator validates transfer recipients are accounts on the destination
+// chain, its relay chain or on one of the allowed parachains
+type RecipientValidator struct {
+	allowedParachains map[uint32]bool
+}
+
+// NewRecipientValidator creates a recipient validator. Recipients
+// on any parachain are allowed if allowedParachains is empty.
+func NewRecipientValidator(allowedParachains []uint32) *RecipientValidator {
+	var allowed map[uint32]bool
+	if len(allowedParachains) > 0 {
+		allowed = make(map[uint32]bool)
+		for _, id := range allowedParachains {
+			allowed[id] = true
+		}
+	}
+
+	return &RecipientValidator{
+		allowedParachains: allowed,
+	}
+}
+
+// Validate decodes the recipient MultiLocation and checks it matches one of the
+// supported patterns:
+// (0, X1(AccountId32)) - local account
+// (1, X1(AccountId32)) - relay chain account
+// (1, X2(Parachain, AccountId32 | AccountKey20)) - account on an allowed parachain
+func (v *RecipientValidator) Validate(recipient []byte) error {
+	location, err := DecodeMultiLocation(recipient)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case location.Parents == 0 && len(location.Interior) == 1:
+		if location.Interior[0].Type != AccountID32 {
+			return fmt.Errorf("local recipient is not an AccountId32 junction")
+		}
+	case location.Parents == 1 && len(location.Interior) == 1:
+		if location.Interior[0].Type != AccountID32 {
+			return fmt.Errorf("relay chain recipient is not an AccountId32 junction")
+		}
+	case location.Parents == 1 && len(location.Interior) == 2:
+		parachain := location.Interior[0]
+		account := location.Interior[1]
+		if parachain.Type != Parachain {
+			return fmt.Errorf("recipient first junction is not a parachain")
+		}
+		if account.Type != AccountID32 && account.Type != AccountKey20 {
+			return fmt.Errorf("parachain recipient is not an AccountId32 or AccountKey20 junction")
+		}
+		if v.allowedParachains != nil && !v.allowedParachains[parachain.ParachainID] {
+			return fmt.Errorf("parachain %d is not allowed", parachain.ParachainID)
+		}
+	default:
+		return fmt.Errorf("unsupported recipient location with %d parents and %d junctions", location.Parents, len(location.Interior))
+	}
+
+	return nil
+}
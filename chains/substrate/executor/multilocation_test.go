@@ -0,0 +1,112 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/executor"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecipientValidatorTestSuite struct {
+	suite.Suite
+	validator *executor.RecipientValidator
+}
+
+func TestRunRecipientValidatorTestSuite(t *testing.T) {
+	suite.Run(t, new(RecipientValidatorTestSuite))
+}
+
+func (s *RecipientValidatorTestSuite) SetupTest() {
+	s.validator = executor.NewRecipientValidator([]uint32{1000})
+}
+
+func (s *RecipientValidatorTestSuite) Test_LocalAccount() {
+	recipient := append([]byte{0, 1, 1, 0}, make([]byte, 32)...)
+
+	err := s.validator.Validate(recipient)
+
+	s.Nil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_LocalAccountWithNetwork() {
+	recipient := append([]byte{0, 1, 1, 1, 2}, make([]byte, 32)...) // network: Some(Polkadot)
+
+	err := s.validator.Validate(recipient)
+
+	s.Nil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_RelayChainAccount() {
+	recipient := append([]byte{1, 1, 1, 0}, make([]byte, 32)...)
+
+	err := s.validator.Validate(recipient)
+
+	s.Nil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_AllowedParachainAccountKey20() {
+	recipient := append([]byte{1, 2, 0, 0xa1, 0x0f, 3, 0}, make([]byte, 20)...) // Parachain(1000)
+
+	err := s.validator.Validate(recipient)
+
+	s.Nil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_ParachainNotAllowed() {
+	recipient := append([]byte{1, 2, 0, 0x41, 0x1f, 1, 0}, make([]byte, 32)...) // Parachain(2000)
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_AnyParachainAllowed() {
+	recipient := append([]byte{1, 2, 0, 0x41, 0x1f, 1, 0}, make([]byte, 32)...) // Parachain(2000)
+
+	err := executor.NewRecipientValidator(nil).Validate(recipient)
+
+	s.Nil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_TrailingBytes() {
+	recipient := append([]byte{0, 1, 1, 0}, make([]byte, 33)...)
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_TruncatedAccount() {
+	recipient := append([]byte{0, 1, 1, 0}, make([]byte, 31)...)
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_UnsupportedJunction() {
+	recipient := []byte{0, 1, 4, 5} // PalletInstance(5)
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_UnsupportedPattern() {
+	recipient := append([]byte{2, 1, 1, 0}, make([]byte, 32)...)
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
+
+func (s *RecipientValidatorTestSuite) Test_ParachainWithoutAccount() {
+	recipient := []byte{1, 1, 0, 0xa1, 0x0f}
+
+	err := s.validator.Validate(recipient)
+
+	s.NotNil(err)
+}
@@ -5,6 +5,7 @@ package listener
 
 import (
 	"errors"
+	"math/big"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
@@ -20,10 +21,19 @@ type SubstrateDepositHandler struct {
 	depositHandlers DepositHandlers
 }
 
+// Transfer types as encoded by the sygma pallet TransferType enum
 const (
 	FungibleTransfer = iota
+	NonFungibleTransfer
+	GenericTransfer
 )
 
+var transferTypes = map[types.U8]transfer.TransferType{
+	FungibleTransfer:    transfer.FungibleTransfer,
+	NonFungibleTransfer: transfer.NonFungibleTransfer,
+	GenericTransfer:     transfer.PermissionlessGenericTransfer,
+}
+
 // NewSubstrateDepositHandler creates an instance of SubstrateDepositHandler that contains
 // handler functions for processing deposit events
 func NewSubstrateDepositHandler() *SubstrateDepositHandler {
@@ -41,10 +51,8 @@ func (e *SubstrateDepositHandler) HandleDeposit(
 	transferType types.U8,
 	messageID string,
 	timestamp time.Time) (*message.Message, error) {
-	var depositType transfer.TransferType
-	if transferType == FungibleTransfer {
-		depositType = transfer.FungibleTransfer
-	} else {
+	depositType, ok := transferTypes[transferType]
+	if !ok {
 		return nil, errors.New("no corresponding deposit handler for this transfer type exists")
 	}
 
@@ -118,3 +126,127 @@ func FungibleTransferHandler(
 		transfer.TransferMessageType,
 		timestamp), nil
 }
+
+// NonFungibleTransferHandler converts data pulled from non-fungible deposit event into message
+func NonFungibleTransferHandler(
+	sourceID uint8,
+	destID types.U8,
+	nonce types.U64,
+	resourceID types.Bytes32,
+	calldata []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(calldata) < 96 {
+		err := errors.New("invalid calldata length: less than 96 bytes")
+		return nil, err
+	}
+
+	// first 32 bytes are tokenId
+	tokenID := calldata[:32]
+
+	// 32 - 64 is recipient address length
+	recipientAddressLength := big.NewInt(0).SetBytes(calldata[32:64])
+	if recipientAddressLength.Cmp(big.NewInt(int64(len(calldata)-96))) == 1 {
+		return nil, errors.New("invalid calldata length: recipient address out of bounds")
+	}
+	recipientAddressEnd := 64 + recipientAddressLength.Int64()
+
+	// 64 - (64 + recipient address length) is recipient address
+	recipientAddress := calldata[64:recipientAddressEnd]
+
+	// (64 + recipient address length) - ((64 + recipient address length) + 32) is metadata length
+	metadataLength := big.NewInt(0).SetBytes(calldata[recipientAddressEnd : recipientAddressEnd+32])
+	if metadataLength.Cmp(big.NewInt(int64(len(calldata))-recipientAddressEnd-32)) == 1 {
+		return nil, errors.New("invalid calldata length: metadata out of bounds")
+	}
+	metadataStart := recipientAddressEnd + 32
+
+	// ((64 + recipient address length) + 32) - ((64 + recipient address length) + 32 + metadata length) is metadata
+	metadata := calldata[metadataStart : metadataStart+metadataLength.Int64()]
+
+	payload := []interface{}{
+		tokenID,
+		recipientAddress,
+		metadata,
+	}
+
+	return message.NewMessage(
+		sourceID,
+		uint8(destID),
+		transfer.TransferMessageData{
+			DepositNonce: uint64(nonce),
+			ResourceId:   resourceID,
+			Payload:      payload,
+			Type:         transfer.NonFungibleTransfer,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp), nil
+}
+
+// PermissionlessGenericTransferHandler converts data pulled from generic deposit event into message
+func PermissionlessGenericTransferHandler(
+	sourceID uint8,
+	destID types.U8,
+	nonce types.U64,
+	resourceID types.Bytes32,
+	calldata []byte,
+	messageID string,
+	timestamp time.Time) (*message.Message, error) {
+	if len(calldata) < 76 {
+		err := errors.New("invalid calldata length: less than 76 bytes")
+		return nil, err
+	}
+
+	// first 32 bytes are max fee
+	maxFee := calldata[:32]
+
+	// 32 - 34 is function signature length
+	functionSigEnd := 34 + int(big.NewInt(0).SetBytes(calldata[32:34]).Int64())
+	if len(calldata) < functionSigEnd+1 {
+		return nil, errors.New("invalid calldata length: function signature out of bounds")
+	}
+	functionSig := calldata[34:functionSigEnd]
+
+	// next byte is contract address length
+	contractAddressEnd := functionSigEnd + 1 + int(calldata[functionSigEnd])
+	if len(calldata) < contractAddressEnd+1 {
+		return nil, errors.New("invalid calldata length: contract address out of bounds")
+	}
+	contractAddress := calldata[functionSigEnd+1 : contractAddressEnd]
+
+	// next byte is depositor address length
+	depositorEnd := contractAddressEnd + 1 + int(calldata[contractAddressEnd])
+	if len(calldata) < depositorEnd {
+		return nil, errors.New("invalid calldata length: depositor address out of bounds")
+	}
+	depositorAddress := calldata[contractAddressEnd+1 : depositorEnd]
+
+	// rest of the calldata is execution data
+	executionData := calldata[depositorEnd:]
+
+	payload := []interface{}{
+		functionSig,
+		contractAddress,
+		maxFee,
+		depositorAddress,
+		executionData,
+	}
+
+	metadata := make(map[string]interface{})
+	metadata["gasLimit"] = big.NewInt(0).SetBytes(maxFee).Uint64()
+
+	return message.NewMessage(
+		sourceID,
+		uint8(destID),
+		transfer.TransferMessageData{
+			DepositNonce: uint64(nonce),
+			ResourceId:   resourceID,
+			Metadata:     metadata,
+			Payload:      payload,
+			Type:         transfer.PermissionlessGenericTransfer,
+		},
+		messageID,
+		transfer.TransferMessageType,
+		timestamp), nil
+}
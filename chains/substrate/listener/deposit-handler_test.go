@@ -19,6 +19,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	"github.com/ChainSafe/sygma-relayer/e2e/substrate"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -151,3 +152,112 @@ func (s *Erc20HandlerTestSuite) TestSuccesfullyRegisterFungibleTransferHandler()
 	s.NotNil(err2)
 	s.EqualError(err2, errNoCorrespondingDepositHandler.Error())
 }
+
+type NonFungibleTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunNonFungibleTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NonFungibleTransferHandlerTestSuite))
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleEvent() {
+	recipientAddr := *(*[]types.U8)(unsafe.Pointer(&substrate.SubstratePK.PublicKey))
+	recipient := substrate.ConstructRecipientData(recipientAddr)
+	metadata := []byte("metadata.url")
+
+	var calldata []byte
+	tokenID, _ := types.BigIntToIntBytes(big.NewInt(7), 32)
+	calldata = append(calldata, tokenID...)
+	recipientLen, _ := types.BigIntToIntBytes(big.NewInt(int64(len(recipient))), 32)
+	calldata = append(calldata, recipientLen...)
+	calldata = append(calldata, types.Bytes(recipient)...)
+	metadataLen, _ := types.BigIntToIntBytes(big.NewInt(int64(len(metadata))), 32)
+	calldata = append(calldata, metadataLen...)
+	calldata = append(calldata, metadata...)
+
+	depositHandler := listener.NewSubstrateDepositHandler()
+	depositHandler.RegisterDepositHandler(transfer.NonFungibleTransfer, listener.NonFungibleTransferHandler)
+	timestamp := time.Now()
+	message, err := depositHandler.HandleDeposit(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, types.NewU8(listener.NonFungibleTransfer), "messageID", timestamp)
+
+	s.Nil(err)
+	s.Equal(message.Data.(transfer.TransferMessageData), transfer.TransferMessageData{
+		DepositNonce: 1,
+		ResourceId:   types.Bytes32{1},
+		Payload: []interface{}{
+			tokenID,
+			[]byte(recipient),
+			metadata,
+		},
+		Type: transfer.NonFungibleTransfer,
+	})
+}
+
+func (s *NonFungibleTransferHandlerTestSuite) TestHandleEventRecipientOutOfBounds() {
+	var calldata []byte
+	tokenID, _ := types.BigIntToIntBytes(big.NewInt(7), 32)
+	calldata = append(calldata, tokenID...)
+	recipientLen, _ := types.BigIntToIntBytes(big.NewInt(100), 32)
+	calldata = append(calldata, recipientLen...)
+	calldata = append(calldata, make([]byte, 40)...)
+
+	message, err := listener.NonFungibleTransferHandler(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, "messageID", time.Now())
+
+	s.Nil(message)
+	s.NotNil(err)
+}
+
+type PermissionlessGenericTransferHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunPermissionlessGenericTransferHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PermissionlessGenericTransferHandlerTestSuite))
+}
+
+func (s *PermissionlessGenericTransferHandlerTestSuite) TestHandleEvent() {
+	maxFee, _ := types.BigIntToIntBytes(big.NewInt(200000), 32)
+	functionSig := []byte{0x65, 0x4c, 0xf8, 0x8c}
+	contractAddress := common.HexToAddress("0x02091EefF969b33A5CE8A729DaE325879bf76f90").Bytes()
+	depositor := common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b").Bytes()
+	executionData := []byte("execution data")
+
+	var calldata []byte
+	calldata = append(calldata, maxFee...)
+	calldata = append(calldata, 0, byte(len(functionSig)))
+	calldata = append(calldata, functionSig...)
+	calldata = append(calldata, byte(len(contractAddress)))
+	calldata = append(calldata, contractAddress...)
+	calldata = append(calldata, byte(len(depositor)))
+	calldata = append(calldata, depositor...)
+	calldata = append(calldata, executionData...)
+
+	depositHandler := listener.NewSubstrateDepositHandler()
+	depositHandler.RegisterDepositHandler(transfer.PermissionlessGenericTransfer, listener.PermissionlessGenericTransferHandler)
+	message, err := depositHandler.HandleDeposit(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, calldata, types.NewU8(listener.GenericTransfer), "messageID", time.Now())
+
+	s.Nil(err)
+	s.Equal(message.Data.(transfer.TransferMessageData), transfer.TransferMessageData{
+		DepositNonce: 1,
+		ResourceId:   types.Bytes32{1},
+		Metadata: map[string]interface{}{
+			"gasLimit": uint64(200000),
+		},
+		Payload: []interface{}{
+			functionSig,
+			contractAddress,
+			maxFee,
+			depositor,
+			executionData,
+		},
+		Type: transfer.PermissionlessGenericTransfer,
+	})
+}
+
+func (s *PermissionlessGenericTransferHandlerTestSuite) TestHandleEventIncorrectDataLen() {
+	message, err := listener.PermissionlessGenericTransferHandler(1, types.NewU8(2), types.NewU64(1), types.Bytes32{1}, make([]byte, 40), "messageID", time.Now())
+
+	s.Nil(message)
+	s.EqualError(err, "invalid calldata length: less than 76 bytes")
+}
@@ -22,36 +22,58 @@ type Connection interface {
 	GetBlock(blockHash types.Hash) (*block.SignedBlock, error)
 	GetBlockHash(blockNumber uint64) (types.Hash, error)
 	GetBlockEvents(hash types.Hash) ([]*parser.Event, error)
+	GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error)
 	UpdateMetatdata() error
 	FetchEvents(startBlock, endBlock *big.Int) ([]*parser.Event, error)
 }
 
+// SystemUpdateEventHandler tracks runtime spec version of each processed block
+// and reloads the latest metadata, used to build extrinsics, once the runtime
+// is upgraded. Upgrade hooks are called after the metadata is reloaded.
 type SystemUpdateEventHandler struct {
-	conn Connection
+	conn         Connection
+	upgradeHooks []func() error
+	specVersion  types.U32
 }
 
-func NewSystemUpdateEventHandler(conn Connection) *SystemUpdateEventHandler {
+func NewSystemUpdateEventHandler(conn Connection, upgradeHooks ...func() error) *SystemUpdateEventHandler {
 	return &SystemUpdateEventHandler{
-		conn: conn,
+		conn:         conn,
+		upgradeHooks: upgradeHooks,
 	}
 }
 
 func (eh *SystemUpdateEventHandler) HandleEvents(startBlock *big.Int, endBlock *big.Int) error {
-	evts, err := eh.conn.FetchEvents(startBlock, endBlock)
-	if err != nil {
-		log.Error().Err(err).Msg("Error fetching events")
-		return err
-	}
-	for _, e := range evts {
-		if e.Name == events.ParachainUpdatedEvent {
-			log.Info().Msgf("Updating substrate metadata")
+	for block := new(big.Int).Set(startBlock); block.Cmp(endBlock) <= 0; block.Add(block, big.NewInt(1)) {
+		hash, err := eh.conn.GetBlockHash(block.Uint64())
+		if err != nil {
+			return err
+		}
+		runtimeVersion, err := eh.conn.GetRuntimeVersion(hash)
+		if err != nil {
+			return err
+		}
+
+		if eh.specVersion != 0 && eh.specVersion != runtimeVersion.SpecVersion {
+			log.Info().Msgf(
+				"Runtime upgraded from spec version %d to %d at block %s, updating substrate metadata",
+				eh.specVersion, runtimeVersion.SpecVersion, block,
+			)
 
 			err := eh.conn.UpdateMetatdata()
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to update Metadata")
 				return err
 			}
+			for _, hook := range eh.upgradeHooks {
+				err := hook()
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to handle runtime upgrade")
+					return err
+				}
+			}
 		}
+		eh.specVersion = runtimeVersion.SpecVersion
 	}
 
 	return nil
@@ -39,14 +39,26 @@ func (s *SystemUpdateHandlerTestSuite) SetupTest() {
 	s.systemUpdateHandler = listener.NewSystemUpdateEventHandler(s.mockConn)
 }
 
+func (s *SystemUpdateHandlerTestSuite) expectSpecVersions(specVersions ...uint32) {
+	for i, specVersion := range specVersions {
+		hash := types.Hash{byte(i)}
+		s.mockConn.EXPECT().GetBlockHash(uint64(i)).Return(hash, nil)
+		s.mockConn.EXPECT().GetRuntimeVersion(hash).Return(&types.RuntimeVersion{SpecVersion: types.U32(specVersion)}, nil)
+	}
+}
+
 func (s *SystemUpdateHandlerTestSuite) Test_UpdateMetadataFails() {
+	s.expectSpecVersions(1, 2)
 	s.mockConn.EXPECT().UpdateMetatdata().Return(fmt.Errorf("error"))
-	evts := []*parser.Event{
-		{
-			Name: "ParachainSystem.ValidationFunctionApplied",
-		},
-	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
+
+	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+
+	s.NotNil(err)
+}
+
+func (s *SystemUpdateHandlerTestSuite) Test_FetchingRuntimeVersionFails() {
+	s.mockConn.EXPECT().GetBlockHash(uint64(0)).Return(types.Hash{}, nil)
+	s.mockConn.EXPECT().GetRuntimeVersion(gomock.Any()).Return(nil, fmt.Errorf("error"))
 
 	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 
@@ -54,26 +66,59 @@ func (s *SystemUpdateHandlerTestSuite) Test_UpdateMetadataFails() {
 }
 
 func (s *SystemUpdateHandlerTestSuite) Test_NoMetadataUpdate() {
+	s.expectSpecVersions(1, 1)
 
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return([]*parser.Event{}, nil)
 	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+
 	s.Nil(err)
 }
 
 func (s *SystemUpdateHandlerTestSuite) Test_SuccesfullMetadataUpdate() {
+	s.expectSpecVersions(1, 2)
 	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
-	evts := []*parser.Event{
-		{
-			Name: "ParachainSystem.ValidationFunctionApplied",
-		},
-	}
-	s.mockConn.EXPECT().FetchEvents(gomock.Any(), gomock.Any()).Return(evts, nil)
 
 	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
 
 	s.Nil(err)
 }
 
+func (s *SystemUpdateHandlerTestSuite) Test_UpgradeBetweenRanges() {
+	s.expectSpecVersions(1, 1, 2, 2)
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil).Times(1)
+
+	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+	s.Nil(err)
+	err = s.systemUpdateHandler.HandleEvents(big.NewInt(2), big.NewInt(3))
+	s.Nil(err)
+}
+
+func (s *SystemUpdateHandlerTestSuite) Test_UpgradeHooksCalled() {
+	hookCalls := 0
+	s.systemUpdateHandler = listener.NewSystemUpdateEventHandler(s.mockConn, func() error {
+		hookCalls++
+		return nil
+	})
+	s.expectSpecVersions(1, 2)
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+
+	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+
+	s.Nil(err)
+	s.Equal(hookCalls, 1)
+}
+
+func (s *SystemUpdateHandlerTestSuite) Test_UpgradeHookFails() {
+	s.systemUpdateHandler = listener.NewSystemUpdateEventHandler(s.mockConn, func() error {
+		return fmt.Errorf("error")
+	})
+	s.expectSpecVersions(1, 2)
+	s.mockConn.EXPECT().UpdateMetatdata().Return(nil)
+
+	err := s.systemUpdateHandler.HandleEvents(big.NewInt(0), big.NewInt(1))
+
+	s.NotNil(err)
+}
+
 type DepositHandlerTestSuite struct {
 	suite.Suite
 	depositEventHandler *listener.FungibleTransferEventHandler
@@ -0,0 +1,238 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type EventHandler interface {
+	HandleEvents(startBlock *big.Int, endBlock *big.Int) error
+}
+
+type ChainConnection interface {
+	GetFinalizedHead() (types.Hash, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
+	GetBlockHash(blockNumber uint64) (types.Hash, error)
+	SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, error)
+}
+
+type BlockStorer interface {
+	StoreBlock(block *big.Int, domainID uint8) error
+}
+
+type BlockHashStorer interface {
+	StoreBlockHash(domainID uint8, block *big.Int, hash [32]byte) error
+	LastBlockHash(domainID uint8) (*big.Int, [32]byte, error)
+}
+
+type BlockDeltaMeter interface {
+	TrackBlockDelta(domainID uint8, head *big.Int, current *big.Int)
+}
+
+// SubstrateListener processes only blocks finalized by GRANDPA. New finalized heads
+// are received through the finalized heads subscription and the finalized head is
+// polled if the subscription is not available.
+type SubstrateListener struct {
+	conn           ChainConnection
+	blockstore     BlockStorer
+	blockHashStore BlockHashStorer
+	eventHandlers  []EventHandler
+	metrics        BlockDeltaMeter
+
+	blockRetryInterval time.Duration
+	blockInterval      *big.Int
+	domainID           uint8
+
+	log zerolog.Logger
+}
+
+func NewSubstrateListener(
+	connection ChainConnection,
+	eventHandlers []EventHandler,
+	blockstore BlockStorer,
+	blockHashStore BlockHashStorer,
+	metrics BlockDeltaMeter,
+	domainID uint8,
+	blockRetryInterval time.Duration,
+	blockInterval *big.Int,
+) *SubstrateListener {
+	return &SubstrateListener{
+		log:                log.With().Uint8("domainID", domainID).Logger(),
+		domainID:           domainID,
+		conn:               connection,
+		blockstore:         blockstore,
+		blockHashStore:     blockHashStore,
+		eventHandlers:      eventHandlers,
+		blockRetryInterval: blockRetryInterval,
+		blockInterval:      blockInterval,
+		metrics:            metrics,
+	}
+}
+
+// ListenToEvents processes finalized blocks in block interval ranges and executes
+// event handlers that are configured for the listener.
+func (l *SubstrateListener) ListenToEvents(ctx context.Context, startBlock *big.Int) {
+	err := l.checkDivergence()
+	if err != nil {
+		l.log.Error().Err(err).Msg("Stored block diverges from the finalized chain")
+	}
+
+	var sub *chain.FinalizedHeadsSubscription
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+
+	for {
+		if sub == nil {
+			sub, err = l.conn.SubscribeFinalizedHeads()
+			if err != nil {
+				l.log.Debug().Err(err).Msg("Finalized heads subscription unavailable, polling finalized head")
+				sub = nil
+			}
+		}
+
+		var head *big.Int
+		if sub != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				l.log.Warn().Err(err).Msg("Finalized heads subscription failed, polling finalized head")
+				sub.Unsubscribe()
+				sub = nil
+				continue
+			case header := <-sub.Chan():
+				head = big.NewInt(int64(header.Number))
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			head, err = l.finalizedHead()
+			if err != nil {
+				l.log.Warn().Err(err).Msg("Failed to fetch finalized header")
+				time.Sleep(l.blockRetryInterval)
+				continue
+			}
+		}
+
+		if startBlock == nil {
+			startBlock = new(big.Int).Set(head)
+		}
+
+		// Wait for new finalized blocks
+		if head.Cmp(startBlock) == -1 {
+			if sub == nil {
+				time.Sleep(l.blockRetryInterval)
+			}
+			continue
+		}
+
+		err = l.processBlocks(ctx, startBlock, head)
+		if err != nil {
+			l.log.Warn().Err(err).Msg("Error handling substrate events")
+			time.Sleep(l.blockRetryInterval)
+		}
+	}
+}
+
+// processBlocks handles blocks from startBlock up to finalized head in block interval
+// ranges and moves startBlock after the last processed block
+func (l *SubstrateListener) processBlocks(ctx context.Context, startBlock *big.Int, head *big.Int) error {
+	for startBlock.Cmp(head) <= 0 {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		endBlock := new(big.Int).Add(startBlock, l.blockInterval)
+		endBlock.Sub(endBlock, big.NewInt(1))
+		if endBlock.Cmp(head) == 1 {
+			endBlock.Set(head)
+		}
+
+		l.metrics.TrackBlockDelta(l.domainID, head, endBlock)
+		l.log.Debug().Msgf("Fetching substrate events for block range %s-%s", startBlock, endBlock)
+
+		for _, handler := range l.eventHandlers {
+			err := handler.HandleEvents(startBlock, endBlock)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Writes to block stores are not critical operations, no need to retry
+		hash, err := l.conn.GetBlockHash(endBlock.Uint64())
+		if err != nil {
+			l.log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to fetch block hash")
+		} else {
+			err = l.blockHashStore.StoreBlockHash(l.domainID, endBlock, hash)
+			if err != nil {
+				l.log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to write latest block hash to blockstore")
+			}
+		}
+
+		nextBlock := new(big.Int).Add(endBlock, big.NewInt(1))
+		err = l.blockstore.StoreBlock(nextBlock, l.domainID)
+		if err != nil {
+			l.log.Error().Str("block", nextBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+		}
+		startBlock.Set(nextBlock)
+	}
+
+	return nil
+}
+
+func (l *SubstrateListener) finalizedHead() (*big.Int, error) {
+	hash, err := l.conn.GetFinalizedHead()
+	if err != nil {
+		return nil, err
+	}
+	header, err := l.conn.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(int64(header.Number)), nil
+}
+
+// checkDivergence compares hash of the last processed block with the hash
+// of the block with the same number on the connected chain
+func (l *SubstrateListener) checkDivergence() error {
+	block, storedHash, err := l.blockHashStore.LastBlockHash(l.domainID)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return nil
+	}
+
+	hash, err := l.conn.GetBlockHash(block.Uint64())
+	if err != nil {
+		return err
+	}
+	if hash != storedHash {
+		return fmt.Errorf(
+			"block %s hash %s does not match stored hash %s",
+			block, hash.Hex(), types.NewHash(storedHash[:]).Hex(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
+	mock_listener "github.com/ChainSafe/sygma-relayer/chains/substrate/listener/mock"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubstrateListenerTestSuite struct {
+	suite.Suite
+	listener           *listener.SubstrateListener
+	mockConn           *mock_listener.MockChainConnection
+	mockEventHandler   *mock_listener.MockEventHandler
+	mockBlockStorer    *mock_listener.MockBlockStorer
+	mockBlockHashStore *mock_listener.MockBlockHashStorer
+	mockMetrics        *mock_listener.MockBlockDeltaMeter
+	domainID           uint8
+}
+
+func TestRunSubstrateListenerTestSuite(t *testing.T) {
+	suite.Run(t, new(SubstrateListenerTestSuite))
+}
+
+func (s *SubstrateListenerTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.domainID = 1
+	s.mockConn = mock_listener.NewMockChainConnection(ctrl)
+	s.mockEventHandler = mock_listener.NewMockEventHandler(ctrl)
+	s.mockBlockStorer = mock_listener.NewMockBlockStorer(ctrl)
+	s.mockBlockHashStore = mock_listener.NewMockBlockHashStorer(ctrl)
+	s.mockMetrics = mock_listener.NewMockBlockDeltaMeter(ctrl)
+	s.listener = listener.NewSubstrateListener(
+		s.mockConn,
+		[]listener.EventHandler{s.mockEventHandler},
+		s.mockBlockStorer,
+		s.mockBlockHashStore,
+		s.mockMetrics,
+		s.domainID,
+		time.Millisecond,
+		big.NewInt(5),
+	)
+}
+
+func (s *SubstrateListenerTestSuite) Test_PollsFinalizedHead_SubscriptionUnavailable() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.mockBlockHashStore.EXPECT().LastBlockHash(s.domainID).Return(nil, [32]byte{}, nil)
+	s.mockConn.EXPECT().SubscribeFinalizedHeads().Return(nil, fmt.Errorf("error")).AnyTimes()
+	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{1}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetHeader(types.Hash{1}).Return(&types.Header{Number: 11}, nil).AnyTimes()
+	s.mockMetrics.EXPECT().TrackBlockDelta(s.domainID, big.NewInt(11), gomock.Any()).AnyTimes()
+
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(5), big.NewInt(9)).Return(nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(9)).Return(types.Hash{9}, nil)
+	s.mockBlockHashStore.EXPECT().StoreBlockHash(s.domainID, big.NewInt(9), [32]byte(types.Hash{9})).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(10), s.domainID).Return(nil)
+
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(10), big.NewInt(11)).Return(nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(11)).Return(types.Hash{11}, nil)
+	s.mockBlockHashStore.EXPECT().StoreBlockHash(s.domainID, big.NewInt(11), [32]byte(types.Hash{11})).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(12), s.domainID).DoAndReturn(func(block *big.Int, domainID uint8) error {
+		cancel()
+		return nil
+	})
+
+	s.listener.ListenToEvents(ctx, big.NewInt(5))
+}
+
+func (s *SubstrateListenerTestSuite) Test_HandlerFails_BlockRangeRetried() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.mockBlockHashStore.EXPECT().LastBlockHash(s.domainID).Return(nil, [32]byte{}, nil)
+	s.mockConn.EXPECT().SubscribeFinalizedHeads().Return(nil, fmt.Errorf("error")).AnyTimes()
+	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{1}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetHeader(types.Hash{1}).Return(&types.Header{Number: 7}, nil).AnyTimes()
+	s.mockMetrics.EXPECT().TrackBlockDelta(s.domainID, big.NewInt(7), big.NewInt(7)).AnyTimes()
+
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(5), big.NewInt(7)).Return(fmt.Errorf("error"))
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(5), big.NewInt(7)).Return(nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(7)).Return(types.Hash{7}, nil)
+	s.mockBlockHashStore.EXPECT().StoreBlockHash(s.domainID, big.NewInt(7), [32]byte(types.Hash{7})).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(8), s.domainID).DoAndReturn(func(block *big.Int, domainID uint8) error {
+		cancel()
+		return nil
+	})
+
+	s.listener.ListenToEvents(ctx, big.NewInt(5))
+}
+
+func (s *SubstrateListenerTestSuite) Test_StoredHashDiverges_ProcessingContinues() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.mockBlockHashStore.EXPECT().LastBlockHash(s.domainID).Return(big.NewInt(4), [32]byte{4}, nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(4)).Return(types.Hash{5}, nil)
+	s.mockConn.EXPECT().SubscribeFinalizedHeads().Return(nil, fmt.Errorf("error")).AnyTimes()
+	s.mockConn.EXPECT().GetFinalizedHead().Return(types.Hash{1}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetHeader(types.Hash{1}).Return(&types.Header{Number: 5}, nil).AnyTimes()
+	s.mockMetrics.EXPECT().TrackBlockDelta(s.domainID, big.NewInt(5), big.NewInt(5)).AnyTimes()
+
+	s.mockEventHandler.EXPECT().HandleEvents(big.NewInt(5), big.NewInt(5)).Return(nil)
+	s.mockConn.EXPECT().GetBlockHash(uint64(5)).Return(types.Hash{5}, nil)
+	s.mockBlockHashStore.EXPECT().StoreBlockHash(s.domainID, big.NewInt(5), [32]byte(types.Hash{5})).Return(nil)
+	s.mockBlockStorer.EXPECT().StoreBlock(big.NewInt(6), s.domainID).DoAndReturn(func(block *big.Int, domainID uint8) error {
+		cancel()
+		return nil
+	})
+
+	s.listener.ListenToEvents(ctx, big.NewInt(5))
+}
@@ -114,6 +114,21 @@ func (mr *MockConnectionMockRecorder) GetFinalizedHead() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinalizedHead", reflect.TypeOf((*MockConnection)(nil).GetFinalizedHead))
 }
 
+// GetRuntimeVersion mocks base method.
+func (m *MockConnection) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntimeVersion", blockHash)
+	ret0, _ := ret[0].(*types.RuntimeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntimeVersion indicates an expected call of GetRuntimeVersion.
+func (mr *MockConnectionMockRecorder) GetRuntimeVersion(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeVersion", reflect.TypeOf((*MockConnection)(nil).GetRuntimeVersion), blockHash)
+}
+
 // UpdateMetatdata mocks base method.
 func (m *MockConnection) UpdateMetatdata() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,259 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/listener/listener.go
+
+// Package mock_listener is a generated GoMock package.
+package mock_listener
+
+import (
+	big "math/big"
+	reflect "reflect"
+
+	chain "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventHandler is a mock of EventHandler interface.
+type MockEventHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventHandlerMockRecorder
+}
+
+// MockEventHandlerMockRecorder is the mock recorder for MockEventHandler.
+type MockEventHandlerMockRecorder struct {
+	mock *MockEventHandler
+}
+
+// NewMockEventHandler creates a new mock instance.
+func NewMockEventHandler(ctrl *gomock.Controller) *MockEventHandler {
+	mock := &MockEventHandler{ctrl: ctrl}
+	mock.recorder = &MockEventHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventHandler) EXPECT() *MockEventHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleEvents mocks base method.
+func (m *MockEventHandler) HandleEvents(startBlock, endBlock *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvents", startBlock, endBlock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvents indicates an expected call of HandleEvents.
+func (mr *MockEventHandlerMockRecorder) HandleEvents(startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvents", reflect.TypeOf((*MockEventHandler)(nil).HandleEvents), startBlock, endBlock)
+}
+
+// MockChainConnection is a mock of ChainConnection interface.
+type MockChainConnection struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainConnectionMockRecorder
+}
+
+// MockChainConnectionMockRecorder is the mock recorder for MockChainConnection.
+type MockChainConnectionMockRecorder struct {
+	mock *MockChainConnection
+}
+
+// NewMockChainConnection creates a new mock instance.
+func NewMockChainConnection(ctrl *gomock.Controller) *MockChainConnection {
+	mock := &MockChainConnection{ctrl: ctrl}
+	mock.recorder = &MockChainConnectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainConnection) EXPECT() *MockChainConnectionMockRecorder {
+	return m.recorder
+}
+
+// GetBlockHash mocks base method.
+func (m *MockChainConnection) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHash", blockNumber)
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHash indicates an expected call of GetBlockHash.
+func (mr *MockChainConnectionMockRecorder) GetBlockHash(blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHash", reflect.TypeOf((*MockChainConnection)(nil).GetBlockHash), blockNumber)
+}
+
+// GetFinalizedHead mocks base method.
+func (m *MockChainConnection) GetFinalizedHead() (types.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinalizedHead")
+	ret0, _ := ret[0].(types.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinalizedHead indicates an expected call of GetFinalizedHead.
+func (mr *MockChainConnectionMockRecorder) GetFinalizedHead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinalizedHead", reflect.TypeOf((*MockChainConnection)(nil).GetFinalizedHead))
+}
+
+// GetHeader mocks base method.
+func (m *MockChainConnection) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", blockHash)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockChainConnectionMockRecorder) GetHeader(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockChainConnection)(nil).GetHeader), blockHash)
+}
+
+// SubscribeFinalizedHeads mocks base method.
+func (m *MockChainConnection) SubscribeFinalizedHeads() (*chain.FinalizedHeadsSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFinalizedHeads")
+	ret0, _ := ret[0].(*chain.FinalizedHeadsSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeFinalizedHeads indicates an expected call of SubscribeFinalizedHeads.
+func (mr *MockChainConnectionMockRecorder) SubscribeFinalizedHeads() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFinalizedHeads", reflect.TypeOf((*MockChainConnection)(nil).SubscribeFinalizedHeads))
+}
+
+// MockBlockStorer is a mock of BlockStorer interface.
+type MockBlockStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStorerMockRecorder
+}
+
+// MockBlockStorerMockRecorder is the mock recorder for MockBlockStorer.
+type MockBlockStorerMockRecorder struct {
+	mock *MockBlockStorer
+}
+
+// NewMockBlockStorer creates a new mock instance.
+func NewMockBlockStorer(ctrl *gomock.Controller) *MockBlockStorer {
+	mock := &MockBlockStorer{ctrl: ctrl}
+	mock.recorder = &MockBlockStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStorer) EXPECT() *MockBlockStorerMockRecorder {
+	return m.recorder
+}
+
+// StoreBlock mocks base method.
+func (m *MockBlockStorer) StoreBlock(block *big.Int, domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlock", block, domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlock indicates an expected call of StoreBlock.
+func (mr *MockBlockStorerMockRecorder) StoreBlock(block, domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlock", reflect.TypeOf((*MockBlockStorer)(nil).StoreBlock), block, domainID)
+}
+
+// MockBlockHashStorer is a mock of BlockHashStorer interface.
+type MockBlockHashStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockHashStorerMockRecorder
+}
+
+// MockBlockHashStorerMockRecorder is the mock recorder for MockBlockHashStorer.
+type MockBlockHashStorerMockRecorder struct {
+	mock *MockBlockHashStorer
+}
+
+// NewMockBlockHashStorer creates a new mock instance.
+func NewMockBlockHashStorer(ctrl *gomock.Controller) *MockBlockHashStorer {
+	mock := &MockBlockHashStorer{ctrl: ctrl}
+	mock.recorder = &MockBlockHashStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockHashStorer) EXPECT() *MockBlockHashStorerMockRecorder {
+	return m.recorder
+}
+
+// LastBlockHash mocks base method.
+func (m *MockBlockHashStorer) LastBlockHash(domainID uint8) (*big.Int, [32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastBlockHash", domainID)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].([32]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LastBlockHash indicates an expected call of LastBlockHash.
+func (mr *MockBlockHashStorerMockRecorder) LastBlockHash(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastBlockHash", reflect.TypeOf((*MockBlockHashStorer)(nil).LastBlockHash), domainID)
+}
+
+// StoreBlockHash mocks base method.
+func (m *MockBlockHashStorer) StoreBlockHash(domainID uint8, block *big.Int, hash [32]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlockHash", domainID, block, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlockHash indicates an expected call of StoreBlockHash.
+func (mr *MockBlockHashStorerMockRecorder) StoreBlockHash(domainID, block, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlockHash", reflect.TypeOf((*MockBlockHashStorer)(nil).StoreBlockHash), domainID, block, hash)
+}
+
+// MockBlockDeltaMeter is a mock of BlockDeltaMeter interface.
+type MockBlockDeltaMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockDeltaMeterMockRecorder
+}
+
+// MockBlockDeltaMeterMockRecorder is the mock recorder for MockBlockDeltaMeter.
+type MockBlockDeltaMeterMockRecorder struct {
+	mock *MockBlockDeltaMeter
+}
+
+// NewMockBlockDeltaMeter creates a new mock instance.
+func NewMockBlockDeltaMeter(ctrl *gomock.Controller) *MockBlockDeltaMeter {
+	mock := &MockBlockDeltaMeter{ctrl: ctrl}
+	mock.recorder = &MockBlockDeltaMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockDeltaMeter) EXPECT() *MockBlockDeltaMeterMockRecorder {
+	return m.recorder
+}
+
+// TrackBlockDelta mocks base method.
+func (m *MockBlockDeltaMeter) TrackBlockDelta(domainID uint8, head, current *big.Int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackBlockDelta", domainID, head, current)
+}
+
+// TrackBlockDelta indicates an expected call of TrackBlockDelta.
+func (mr *MockBlockDeltaMeterMockRecorder) TrackBlockDelta(domainID, head, current interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBlockDelta", reflect.TypeOf((*MockBlockDeltaMeter)(nil).TrackBlockDelta), domainID, head, current)
+}
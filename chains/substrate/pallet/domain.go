@@ -0,0 +1,215 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	bridgePallet = "SygmaBridge"
+
+	defaultBridgeVersion     = "3.1.0"
+	defaultVerifyingContract = "6CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68"
+)
+
+// EIP712Domain contains domain parameters the bridge pallet uses to verify proposal signatures
+type EIP712Domain struct {
+	Version           string
+	VerifyingContract string
+	ChainID           *big.Int
+}
+
+// Domain returns EIP712 domain used to hash proposals
+func (p *Pallet) Domain() EIP712Domain {
+	p.domainLock.RLock()
+	defer p.domainLock.RUnlock()
+	return p.domain
+}
+
+// LoadDomain reads EIP712 domain parameters from the bridge pallet constants
+// and applies configured overrides. Defaults are used for parameters the pallet
+// does not expose. It should be called on startup and after each runtime upgrade.
+func (p *Pallet) LoadDomain() error {
+	palletDomain, err := p.palletDomain()
+	if err != nil {
+		return err
+	}
+
+	domain := EIP712Domain{
+		Version:           defaultBridgeVersion,
+		VerifyingContract: defaultVerifyingContract,
+		ChainID:           p.ChainID,
+	}
+	if palletDomain.Version != "" {
+		domain.Version = palletDomain.Version
+	} else {
+		log.Debug().Msgf("Bridge version not exposed by pallet, using default %s", defaultBridgeVersion)
+	}
+	if palletDomain.VerifyingContract != "" {
+		domain.VerifyingContract = palletDomain.VerifyingContract
+	} else {
+		log.Warn().Msgf("Verifying contract not exposed by pallet, using default %s", defaultVerifyingContract)
+	}
+	if palletDomain.ChainID != nil {
+		domain.ChainID = palletDomain.ChainID
+	} else {
+		log.Warn().Msgf("EIP712 chainID not exposed by pallet, using configured %s", p.ChainID)
+	}
+	if p.domainOverride.Version != "" {
+		domain.Version = p.domainOverride.Version
+	}
+	if p.domainOverride.VerifyingContract != "" {
+		domain.VerifyingContract = p.domainOverride.VerifyingContract
+	}
+
+	p.domainLock.Lock()
+	p.domain = domain
+	p.domainLock.Unlock()
+
+	log.Info().Msgf(
+		"Loaded bridge pallet domain, version: %s, verifying contract: %s, chainID: %s",
+		domain.Version, domain.VerifyingContract, domain.ChainID,
+	)
+	return nil
+}
+
+// VerifyDomain verifies that configured domain overrides match parameters exposed by the
+// bridge pallet and that proposals are signed with the current domain of the bridge pallet.
+// Parameters the pallet does not expose can't be verified.
+func (p *Pallet) VerifyDomain() error {
+	palletDomain, err := p.palletDomain()
+	if err != nil {
+		return err
+	}
+
+	err = VerifyDomainOverride(p.domainOverride, palletDomain)
+	if err != nil {
+		return err
+	}
+	if palletDomain.Version == "" {
+		log.Warn().Msgf("Bridge version %s can't be verified, not exposed by pallet", p.Domain().Version)
+	}
+	if palletDomain.VerifyingContract == "" {
+		log.Warn().Msgf("Verifying contract %s can't be verified, not exposed by pallet", p.Domain().VerifyingContract)
+	}
+	return VerifyDomainSeparator(p.Domain(), palletDomain)
+}
+
+// VerifyDomainOverride returns an error if a configured domain parameter differs from
+// the parameter exposed by the bridge pallet
+func VerifyDomainOverride(override EIP712Domain, palletDomain EIP712Domain) error {
+	if override.Version != "" && palletDomain.Version != "" && override.Version != palletDomain.Version {
+		return fmt.Errorf("configured bridge version %s does not match bridge pallet version %s", override.Version, palletDomain.Version)
+	}
+	if override.VerifyingContract != "" && palletDomain.VerifyingContract != "" &&
+		!strings.EqualFold(strings.TrimPrefix(override.VerifyingContract, "0x"), strings.TrimPrefix(palletDomain.VerifyingContract, "0x")) {
+		return fmt.Errorf(
+			"configured verifying contract %s does not match bridge pallet verifying contract %s",
+			override.VerifyingContract, palletDomain.VerifyingContract,
+		)
+	}
+	return nil
+}
+
+// VerifyDomainSeparator compares the domain separator of the relayer domain with the
+// domain separator of the relayer domain updated with parameters of the pallet domain.
+// Empty pallet domain parameters are not exposed by the pallet and are not verified.
+func VerifyDomainSeparator(relayerDomain EIP712Domain, palletDomain EIP712Domain) error {
+	expectedDomain := relayerDomain
+	if palletDomain.Version != "" {
+		expectedDomain.Version = palletDomain.Version
+	}
+	if palletDomain.VerifyingContract != "" {
+		expectedDomain.VerifyingContract = palletDomain.VerifyingContract
+	}
+	if palletDomain.ChainID != nil {
+		expectedDomain.ChainID = palletDomain.ChainID
+	}
+
+	relayerSeparator, err := chains.DomainSeparator(relayerDomain.ChainID.Int64(), relayerDomain.VerifyingContract, relayerDomain.Version)
+	if err != nil {
+		return err
+	}
+	palletSeparator, err := chains.DomainSeparator(expectedDomain.ChainID.Int64(), expectedDomain.VerifyingContract, expectedDomain.Version)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(relayerSeparator, palletSeparator) {
+		return fmt.Errorf("relayer domain %+v does not match bridge pallet domain %+v", relayerDomain, palletDomain)
+	}
+
+	return nil
+}
+
+// palletDomain reads EIP712 domain from the bridge pallet constants. Parameters
+// the pallet does not expose are left empty.
+func (p *Pallet) palletDomain() (EIP712Domain, error) {
+	meta := p.Conn.GetMetadata()
+	domain := EIP712Domain{}
+
+	var version []byte
+	found, err := decodeConstant(&meta, "BridgeVersion", &version)
+	if err != nil {
+		return EIP712Domain{}, err
+	}
+	if found {
+		domain.Version = string(version)
+	}
+
+	var verifyingContract types.H160
+	found, err = decodeConstant(&meta, "DestVerifyingContractAddress", &verifyingContract)
+	if err != nil {
+		return EIP712Domain{}, err
+	}
+	if found {
+		domain.VerifyingContract = hex.EncodeToString(verifyingContract[:])
+	}
+
+	var chainID types.U256
+	found, err = decodeConstant(&meta, "EIP712ChainID", &chainID)
+	if err != nil {
+		return EIP712Domain{}, err
+	}
+	if found {
+		domain.ChainID = chainID.Int
+	}
+
+	return domain, nil
+}
+
+// decodeConstant decodes bridge pallet constant into target and
+// returns false if the pallet does not define the constant
+func decodeConstant(meta *types.Metadata, constant string, target interface{}) (bool, error) {
+	if meta.Version != 14 {
+		return false, fmt.Errorf("unsupported metadata version %d", meta.Version)
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) != bridgePallet {
+			continue
+		}
+		for _, palletConstant := range pallet.Constants {
+			if string(palletConstant.Name) != constant {
+				continue
+			}
+
+			err := codec.Decode(palletConstant.Value, target)
+			if err != nil {
+				return false, fmt.Errorf("failed decoding %s constant: %w", constant, err)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,190 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pallet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/suite"
+)
+
+type DomainTestSuite struct {
+	suite.Suite
+	domain EIP712Domain
+}
+
+func TestRunDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainTestSuite))
+}
+
+func (s *DomainTestSuite) SetupTest() {
+	s.domain = EIP712Domain{
+		Version:           "3.1.0",
+		VerifyingContract: "6CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68",
+		ChainID:           big.NewInt(5),
+	}
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_MatchingDomain() {
+	palletDomain := s.domain
+	palletDomain.VerifyingContract = "0x6cde2cd82a4f8b74693ff5e194c19ca08c2d1c68"
+
+	err := VerifyDomainSeparator(s.domain, palletDomain)
+
+	s.Nil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_DifferentVersion() {
+	palletDomain := s.domain
+	palletDomain.Version = "3.2.0"
+
+	err := VerifyDomainSeparator(s.domain, palletDomain)
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_DifferentVerifyingContract() {
+	palletDomain := s.domain
+	palletDomain.VerifyingContract = "5CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68"
+
+	err := VerifyDomainSeparator(s.domain, palletDomain)
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_DifferentChainID() {
+	palletDomain := s.domain
+	palletDomain.ChainID = big.NewInt(6)
+
+	err := VerifyDomainSeparator(s.domain, palletDomain)
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_UnexposedParametersNotVerified() {
+	relayerDomain := s.domain
+	relayerDomain.Version = "3.2.0"
+	relayerDomain.VerifyingContract = "5CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68"
+
+	err := VerifyDomainSeparator(relayerDomain, EIP712Domain{
+		ChainID: big.NewInt(5),
+	})
+
+	s.Nil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainSeparator_ExposedParameterDiffers() {
+	err := VerifyDomainSeparator(s.domain, EIP712Domain{
+		Version: "3.2.0",
+	})
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainOverride_MatchingPallet() {
+	palletDomain := s.domain
+	palletDomain.VerifyingContract = "6cde2cd82a4f8b74693ff5e194c19ca08c2d1c68"
+
+	err := VerifyDomainOverride(EIP712Domain{
+		Version:           "3.1.0",
+		VerifyingContract: "0x6CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68",
+	}, palletDomain)
+
+	s.Nil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainOverride_DifferentVersion() {
+	err := VerifyDomainOverride(EIP712Domain{
+		Version: "3.2.0",
+	}, s.domain)
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainOverride_DifferentVerifyingContract() {
+	err := VerifyDomainOverride(EIP712Domain{
+		VerifyingContract: "5CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68",
+	}, s.domain)
+
+	s.NotNil(err)
+}
+
+func (s *DomainTestSuite) Test_VerifyDomainOverride_NoOverride() {
+	err := VerifyDomainOverride(EIP712Domain{}, s.domain)
+
+	s.Nil(err)
+}
+
+type DecodeConstantTestSuite struct {
+	suite.Suite
+	meta types.Metadata
+}
+
+func TestRunDecodeConstantTestSuite(t *testing.T) {
+	suite.Run(t, new(DecodeConstantTestSuite))
+}
+
+func (s *DecodeConstantTestSuite) SetupTest() {
+	chainID, err := codec.Encode(types.NewU256(*big.NewInt(5)))
+	s.Nil(err)
+	s.meta = types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Pallets: []types.PalletMetadataV14{
+				{
+					Name: "System",
+					Constants: []types.ConstantMetadataV14{
+						{Name: "BridgeVersion", Value: []byte{0}},
+					},
+				},
+				{
+					Name: bridgePallet,
+					Constants: []types.ConstantMetadataV14{
+						{Name: "EIP712ChainID", Value: chainID},
+						{Name: "DestVerifyingContractAddress", Value: []byte{1, 2}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *DecodeConstantTestSuite) Test_ConstantExposed() {
+	var chainID types.U256
+
+	found, err := decodeConstant(&s.meta, "EIP712ChainID", &chainID)
+
+	s.Nil(err)
+	s.True(found)
+	s.Equal(chainID.Int, big.NewInt(5))
+}
+
+func (s *DecodeConstantTestSuite) Test_ConstantNotExposed() {
+	var version []byte
+
+	found, err := decodeConstant(&s.meta, "BridgeVersion", &version)
+
+	s.Nil(err)
+	s.False(found)
+}
+
+func (s *DecodeConstantTestSuite) Test_InvalidConstant() {
+	var verifyingContract types.H160
+
+	_, err := decodeConstant(&s.meta, "DestVerifyingContractAddress", &verifyingContract)
+
+	s.NotNil(err)
+}
+
+func (s *DecodeConstantTestSuite) Test_UnsupportedMetadataVersion() {
+	var chainID types.U256
+	s.meta.Version = 13
+
+	_, err := decodeConstant(&s.meta, "EIP712ChainID", &chainID)
+
+	s.NotNil(err)
+}
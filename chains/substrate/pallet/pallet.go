@@ -5,21 +5,20 @@ package pallet
 
 import (
 	"strconv"
+	"sync"
 
 	"github.com/ChainSafe/sygma-relayer/chains"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/transactor"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	"github.com/sygmaprotocol/sygma-core/chains/substrate/client"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rs/zerolog/log"
 )
 
-const bridgeVersion = "3.1.0"
-const verifyingContract = "6CdE2Cd82a4F8B74693Ff5e194c19CA08c2d1c68"
-
 type BridgeProposal struct {
 	OriginDomainID uint8
 	DepositNonce   uint64
@@ -27,41 +26,58 @@ type BridgeProposal struct {
 	Data           []byte
 }
 
+type Transactor interface {
+	Transact(method string, args ...interface{}) (types.Hash, error)
+	TrackExtrinsic(extHash types.Hash) (*transactor.Receipt, error)
+}
+
 type Pallet struct {
 	*client.SubstrateClient
+	transactor Transactor
+
+	domainOverride EIP712Domain
+	domainLock     sync.RWMutex
+	domain         EIP712Domain
 }
 
+// NewPallet creates a bridge pallet client. Non empty domain override
+// parameters replace parameters read from the pallet.
 func NewPallet(
 	client *client.SubstrateClient,
+	transactor Transactor,
+	domainOverride EIP712Domain,
 ) *Pallet {
 	return &Pallet{
-		client,
+		SubstrateClient: client,
+		transactor:      transactor,
+		domainOverride:  domainOverride,
+		domain: EIP712Domain{
+			Version:           defaultBridgeVersion,
+			VerifyingContract: defaultVerifyingContract,
+			ChainID:           client.ChainID,
+		},
 	}
 }
 
 func (p *Pallet) ExecuteProposals(
 	proposals []*transfer.TransferProposal,
 	signature []byte,
-) (types.Hash, *author.ExtrinsicStatusSubscription, error) {
-	bridgeProposals := make([]BridgeProposal, 0)
-	for _, prop := range proposals {
-		bridgeProposals = append(bridgeProposals, BridgeProposal{
-			OriginDomainID: prop.Source,
-			DepositNonce:   prop.Data.DepositNonce,
-			ResourceID:     prop.Data.ResourceId,
-			Data:           prop.Data.Data,
-		})
-	}
-
-	return p.Transact(
+) (types.Hash, error) {
+	return p.transactor.Transact(
 		"SygmaBridge.execute_proposal",
-		bridgeProposals,
+		bridgeProposals(proposals),
 		signature,
 	)
 }
 
+// TrackExtrinsic waits until the extrinsic is finalized
+func (p *Pallet) TrackExtrinsic(extHash types.Hash) (*transactor.Receipt, error) {
+	return p.transactor.TrackExtrinsic(extHash)
+}
+
 func (p *Pallet) ProposalsHash(proposals []*transfer.TransferProposal) ([]byte, error) {
-	return chains.ProposalsHash(proposals, p.ChainID.Int64(), verifyingContract, bridgeVersion)
+	domain := p.Domain()
+	return chains.ProposalsHash(proposals, domain.ChainID.Int64(), domain.VerifyingContract, domain.Version)
 }
 
 func (p *Pallet) IsProposalExecuted(prop *transfer.TransferProposal) (bool, error) {
@@ -78,3 +94,32 @@ func (p *Pallet) IsProposalExecuted(prop *transfer.TransferProposal) (bool, erro
 
 	return res, nil
 }
+
+// MPCAddress returns address of the MPC key that signs proposals for the bridge pallet
+func (p *Pallet) MPCAddress() (common.Address, error) {
+	meta := p.Conn.GetMetadata()
+	key, err := types.CreateStorageKey(&meta, bridgePallet, "MpcAddr")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var mpcAddress types.H160
+	_, err = p.Conn.RPC.State.GetStorageLatest(key, &mpcAddress)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(mpcAddress[:]), nil
+}
+
+func bridgeProposals(proposals []*transfer.TransferProposal) []BridgeProposal {
+	bridgeProposals := make([]BridgeProposal, 0)
+	for _, prop := range proposals {
+		bridgeProposals = append(bridgeProposals, BridgeProposal{
+			OriginDomainID: prop.Source,
+			DepositNonce:   prop.Data.DepositNonce,
+			ResourceID:     prop.Data.ResourceId,
+			Data:           prop.Data.Data,
+		})
+	}
+	return bridgeProposals
+}
@@ -0,0 +1,164 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pallet
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// signatureLength is the length of the ECDSA signature passed to execute_proposal
+const signatureLength = 65
+
+// Weight is the two dimensional weight of a substrate extrinsic
+type Weight struct {
+	RefTime   uint64
+	ProofSize uint64
+}
+
+func (w Weight) Add(other Weight) Weight {
+	return Weight{
+		RefTime:   w.RefTime + other.RefTime,
+		ProofSize: w.ProofSize + other.ProofSize,
+	}
+}
+
+// Sub subtracts the other weight without going below zero
+func (w Weight) Sub(other Weight) Weight {
+	weight := Weight{}
+	if w.RefTime > other.RefTime {
+		weight.RefTime = w.RefTime - other.RefTime
+	}
+	if w.ProofSize > other.ProofSize {
+		weight.ProofSize = w.ProofSize - other.ProofSize
+	}
+	return weight
+}
+
+// Exceeds returns true if any of the weight dimensions is over the limit
+func (w Weight) Exceeds(limit Weight) bool {
+	return w.RefTime > limit.RefTime || w.ProofSize > limit.ProofSize
+}
+
+func (w *Weight) UnmarshalJSON(data []byte) error {
+	// weight v1 is a single number of ref time
+	var refTime uint64
+	if err := json.Unmarshal(data, &refTime); err == nil {
+		w.RefTime = refTime
+		return nil
+	}
+
+	weight := struct {
+		RefTime        uint64 `json:"refTime"`
+		ProofSize      uint64 `json:"proofSize"`
+		SnakeRefTime   uint64 `json:"ref_time"`
+		SnakeProofSize uint64 `json:"proof_size"`
+	}{}
+	err := json.Unmarshal(data, &weight)
+	if err != nil {
+		return err
+	}
+	w.RefTime = weight.RefTime + weight.SnakeRefTime
+	w.ProofSize = weight.ProofSize + weight.SnakeProofSize
+	return nil
+}
+
+type dispatchInfo struct {
+	Weight Weight `json:"weight"`
+}
+
+type weightsPerClass struct {
+	BaseExtrinsic types.Weight
+	MaxExtrinsic  types.Option[types.Weight]
+	MaxTotal      types.Option[types.Weight]
+	Reserved      types.Option[types.Weight]
+}
+
+type blockWeights struct {
+	BaseBlock types.Weight
+	MaxBlock  types.Weight
+	PerClass  struct {
+		Normal      weightsPerClass
+		Operational weightsPerClass
+		Mandatory   weightsPerClass
+	}
+}
+
+// EstimateWeight estimates weight of the execute_proposal extrinsic for the proposals
+// with payment_queryInfo
+func (p *Pallet) EstimateWeight(proposals []*transfer.TransferProposal) (Weight, error) {
+	meta := p.Conn.GetMetadata()
+	call, err := types.NewCall(
+		&meta,
+		"SygmaBridge.execute_proposal",
+		bridgeProposals(proposals),
+		make([]byte, signatureLength),
+	)
+	if err != nil {
+		return Weight{}, err
+	}
+	ext, err := codec.EncodeToHex(extrinsic.NewExtrinsic(call))
+	if err != nil {
+		return Weight{}, err
+	}
+
+	var info dispatchInfo
+	err = p.Conn.Client.Call(&info, "payment_queryInfo", ext)
+	if err != nil {
+		return Weight{}, err
+	}
+	return info.Weight, nil
+}
+
+// MaxExtrinsicWeight returns the max weight of a normal extrinsic as defined
+// by the System.BlockWeights constant. Max block weight is used if there is
+// no explicit extrinsic limit.
+func (p *Pallet) MaxExtrinsicWeight() (Weight, error) {
+	weights, err := p.blockWeights()
+	if err != nil {
+		return Weight{}, err
+	}
+
+	ok, maxExtrinsic := weights.PerClass.Normal.MaxExtrinsic.Unwrap()
+	if !ok {
+		maxExtrinsic = weights.MaxBlock
+	}
+	return newWeight(maxExtrinsic), nil
+}
+
+// BaseExtrinsicWeight returns the base weight of a normal extrinsic as defined
+// by the System.BlockWeights constant. It is included once in the estimated
+// weight of every extrinsic.
+func (p *Pallet) BaseExtrinsicWeight() (Weight, error) {
+	weights, err := p.blockWeights()
+	if err != nil {
+		return Weight{}, err
+	}
+
+	return newWeight(weights.PerClass.Normal.BaseExtrinsic), nil
+}
+
+func (p *Pallet) blockWeights() (blockWeights, error) {
+	meta := p.Conn.GetMetadata()
+	value, err := meta.FindConstantValue("System", "BlockWeights")
+	if err != nil {
+		return blockWeights{}, err
+	}
+
+	var weights blockWeights
+	err = codec.Decode(value, &weights)
+	return weights, err
+}
+
+func newWeight(weight types.Weight) Weight {
+	return Weight{
+		RefTime:   (*big.Int)(&weight.RefTime).Uint64(),
+		ProofSize: (*big.Int)(&weight.ProofSize).Uint64(),
+	}
+}
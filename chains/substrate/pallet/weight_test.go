@@ -0,0 +1,61 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pallet_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	"github.com/stretchr/testify/suite"
+)
+
+type WeightTestSuite struct {
+	suite.Suite
+}
+
+func TestRunWeightTestSuite(t *testing.T) {
+	suite.Run(t, new(WeightTestSuite))
+}
+
+func (s *WeightTestSuite) Test_UnmarshalJSON_CamelCase() {
+	var weight pallet.Weight
+
+	err := json.Unmarshal([]byte(`{"refTime": 100, "proofSize": 200}`), &weight)
+
+	s.Nil(err)
+	s.Equal(weight, pallet.Weight{RefTime: 100, ProofSize: 200})
+}
+
+func (s *WeightTestSuite) Test_UnmarshalJSON_SnakeCase() {
+	var weight pallet.Weight
+
+	err := json.Unmarshal([]byte(`{"ref_time": 100, "proof_size": 200}`), &weight)
+
+	s.Nil(err)
+	s.Equal(weight, pallet.Weight{RefTime: 100, ProofSize: 200})
+}
+
+func (s *WeightTestSuite) Test_UnmarshalJSON_V1Weight() {
+	var weight pallet.Weight
+
+	err := json.Unmarshal([]byte(`100`), &weight)
+
+	s.Nil(err)
+	s.Equal(weight, pallet.Weight{RefTime: 100})
+}
+
+func (s *WeightTestSuite) Test_Exceeds() {
+	limit := pallet.Weight{RefTime: 100, ProofSize: 100}
+
+	s.False(pallet.Weight{RefTime: 100, ProofSize: 100}.Exceeds(limit))
+	s.True(pallet.Weight{RefTime: 50, ProofSize: 50}.Add(pallet.Weight{RefTime: 10, ProofSize: 51}).Exceeds(limit))
+	s.True(pallet.Weight{RefTime: 101}.Exceeds(limit))
+}
+
+func (s *WeightTestSuite) Test_Sub() {
+	weight := pallet.Weight{RefTime: 100, ProofSize: 10}
+
+	s.Equal(weight.Sub(pallet.Weight{RefTime: 30, ProofSize: 20}), pallet.Weight{RefTime: 70})
+}
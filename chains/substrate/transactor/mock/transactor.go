@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/substrate/transactor/transactor.go
+
+// Package mock_transactor is a generated GoMock package.
+package mock_transactor
+
+import (
+	reflect "reflect"
+
+	parser "github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	author "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	block "github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	extrinsic "github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConnection is a mock of Connection interface.
+type MockConnection struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionMockRecorder
+}
+
+// MockConnectionMockRecorder is the mock recorder for MockConnection.
+type MockConnectionMockRecorder struct {
+	mock *MockConnection
+}
+
+// NewMockConnection creates a new mock instance.
+func NewMockConnection(ctrl *gomock.Controller) *MockConnection {
+	mock := &MockConnection{ctrl: ctrl}
+	mock.recorder = &MockConnectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnection) EXPECT() *MockConnectionMockRecorder {
+	return m.recorder
+}
+
+// AccountNextIndex mocks base method.
+func (m *MockConnection) AccountNextIndex(address string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountNextIndex", address)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountNextIndex indicates an expected call of AccountNextIndex.
+func (mr *MockConnectionMockRecorder) AccountNextIndex(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountNextIndex", reflect.TypeOf((*MockConnection)(nil).AccountNextIndex), address)
+}
+
+// GetBlock mocks base method.
+func (m *MockConnection) GetBlock(blockHash types.Hash) (*block.SignedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlock", blockHash)
+	ret0, _ := ret[0].(*block.SignedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlock indicates an expected call of GetBlock.
+func (mr *MockConnectionMockRecorder) GetBlock(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockConnection)(nil).GetBlock), blockHash)
+}
+
+// GetBlockEvents mocks base method.
+func (m *MockConnection) GetBlockEvents(hash types.Hash) ([]*parser.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockEvents", hash)
+	ret0, _ := ret[0].([]*parser.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockEvents indicates an expected call of GetBlockEvents.
+func (mr *MockConnectionMockRecorder) GetBlockEvents(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockEvents", reflect.TypeOf((*MockConnection)(nil).GetBlockEvents), hash)
+}
+
+// GetGenesisHash mocks base method.
+func (m *MockConnection) GetGenesisHash() types.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenesisHash")
+	ret0, _ := ret[0].(types.Hash)
+	return ret0
+}
+
+// GetGenesisHash indicates an expected call of GetGenesisHash.
+func (mr *MockConnectionMockRecorder) GetGenesisHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesisHash", reflect.TypeOf((*MockConnection)(nil).GetGenesisHash))
+}
+
+// GetHeaderLatest mocks base method.
+func (m *MockConnection) GetHeaderLatest() (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeaderLatest")
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeaderLatest indicates an expected call of GetHeaderLatest.
+func (mr *MockConnectionMockRecorder) GetHeaderLatest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeaderLatest", reflect.TypeOf((*MockConnection)(nil).GetHeaderLatest))
+}
+
+// GetMetadata mocks base method.
+func (m *MockConnection) GetMetadata() types.Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata")
+	ret0, _ := ret[0].(types.Metadata)
+	return ret0
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockConnectionMockRecorder) GetMetadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockConnection)(nil).GetMetadata))
+}
+
+// GetRuntimeVersionLatest mocks base method.
+func (m *MockConnection) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntimeVersionLatest")
+	ret0, _ := ret[0].(*types.RuntimeVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntimeVersionLatest indicates an expected call of GetRuntimeVersionLatest.
+func (mr *MockConnectionMockRecorder) GetRuntimeVersionLatest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeVersionLatest", reflect.TypeOf((*MockConnection)(nil).GetRuntimeVersionLatest))
+}
+
+// SubmitAndWatchExtrinsic mocks base method.
+func (m *MockConnection) SubmitAndWatchExtrinsic(ext extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAndWatchExtrinsic", ext)
+	ret0, _ := ret[0].(*author.ExtrinsicStatusSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAndWatchExtrinsic indicates an expected call of SubmitAndWatchExtrinsic.
+func (mr *MockConnectionMockRecorder) SubmitAndWatchExtrinsic(ext interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAndWatchExtrinsic", reflect.TypeOf((*MockConnection)(nil).SubmitAndWatchExtrinsic), ext)
+}
+
+// MockextrinsicSubscription is a mock of extrinsicSubscription interface.
+type MockextrinsicSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockextrinsicSubscriptionMockRecorder
+}
+
+// MockextrinsicSubscriptionMockRecorder is the mock recorder for MockextrinsicSubscription.
+type MockextrinsicSubscriptionMockRecorder struct {
+	mock *MockextrinsicSubscription
+}
+
+// NewMockextrinsicSubscription creates a new mock instance.
+func NewMockextrinsicSubscription(ctrl *gomock.Controller) *MockextrinsicSubscription {
+	mock := &MockextrinsicSubscription{ctrl: ctrl}
+	mock.recorder = &MockextrinsicSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockextrinsicSubscription) EXPECT() *MockextrinsicSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Chan mocks base method.
+func (m *MockextrinsicSubscription) Chan() <-chan types.ExtrinsicStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chan")
+	ret0, _ := ret[0].(<-chan types.ExtrinsicStatus)
+	return ret0
+}
+
+// Chan indicates an expected call of Chan.
+func (mr *MockextrinsicSubscriptionMockRecorder) Chan() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chan", reflect.TypeOf((*MockextrinsicSubscription)(nil).Chan))
+}
+
+// Err mocks base method.
+func (m *MockextrinsicSubscription) Err() <-chan error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(<-chan error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockextrinsicSubscriptionMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockextrinsicSubscription)(nil).Err))
+}
+
+// Unsubscribe mocks base method.
+func (m *MockextrinsicSubscription) Unsubscribe() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe")
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockextrinsicSubscriptionMockRecorder) Unsubscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockextrinsicSubscription)(nil).Unsubscribe))
+}
@@ -0,0 +1,441 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transactor
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxResubmissions     = 5
+	extrinsicFailedEvent = "System.ExtrinsicFailed"
+)
+
+var (
+	blockCheckPeriod = 6 * time.Second
+	extrinsicTimeout = 10 * time.Minute
+)
+
+type Connection interface {
+	GetMetadata() types.Metadata
+	GetGenesisHash() types.Hash
+	GetRuntimeVersionLatest() (*types.RuntimeVersion, error)
+	AccountNextIndex(address string) (uint64, error)
+	GetHeaderLatest() (*types.Header, error)
+	GetBlock(blockHash types.Hash) (*block.SignedBlock, error)
+	GetBlockEvents(hash types.Hash) ([]*parser.Event, error)
+	SubmitAndWatchExtrinsic(ext extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error)
+}
+
+// Receipt describes the finalized inclusion of an extrinsic
+type Receipt struct {
+	ExtrinsicHash  types.Hash
+	BlockHash      types.Hash
+	BlockNumber    uint64
+	ExtrinsicIndex uint32
+	Events         []*parser.Event
+}
+
+// extrinsicSubscription delivers status updates of a submitted extrinsic
+type extrinsicSubscription interface {
+	Chan() <-chan types.ExtrinsicStatus
+	Err() <-chan error
+	Unsubscribe()
+}
+
+type pendingExtrinsic struct {
+	method      string
+	call        types.Call
+	nonce       uint64
+	tip         uint64
+	hash        types.Hash
+	sub         extrinsicSubscription
+	submitBlock uint64
+	inBlock     bool
+	resubmitted int
+}
+
+type MonitoredTransactor struct {
+	domainID uint8
+	log      zerolog.Logger
+
+	conn  Connection
+	key   *signature.KeyringPair
+	watch func(ext extrinsic.Extrinsic) (extrinsicSubscription, error)
+
+	tip                   uint64
+	maxTip                uint64
+	tipIncreasePercentage uint64
+	resubmitBlocks        uint64
+
+	nonceLock sync.Mutex
+	nonce     uint64
+
+	pendingLock sync.Mutex
+	pending     map[types.Hash]*pendingExtrinsic
+}
+
+// NewMonitoredTransactor creates a substrate transactor that tracks the account nonce
+// locally and resubmits extrinsics with a higher tip if they are not included in
+// a block in resubmitBlocks blocks or if they are dropped from the transaction pool.
+//
+// Tip is increased by tipIncreasePercentage up to maxTip. Tip is not limited if maxTip is 0.
+func NewMonitoredTransactor(
+	domainID uint8,
+	conn Connection,
+	key *signature.KeyringPair,
+	tip uint64,
+	maxTip uint64,
+	tipIncreasePercentage uint64,
+	resubmitBlocks uint64,
+) *MonitoredTransactor {
+	t := &MonitoredTransactor{
+		domainID:              domainID,
+		log:                   log.With().Uint8("domainID", domainID).Logger(),
+		conn:                  conn,
+		key:                   key,
+		tip:                   tip,
+		maxTip:                maxTip,
+		tipIncreasePercentage: tipIncreasePercentage,
+		resubmitBlocks:        resubmitBlocks,
+		pending:               make(map[types.Hash]*pendingExtrinsic),
+	}
+	t.watch = t.submitAndWatch
+	return t
+}
+
+// Transact constructs and submits an extrinsic to call the method with the given arguments.
+// Returned hash identifies the extrinsic in TrackExtrinsic even if it is resubmitted.
+func (t *MonitoredTransactor) Transact(method string, args ...interface{}) (types.Hash, error) {
+	meta := t.conn.GetMetadata()
+	call, err := types.NewCall(&meta, method, args...)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("failed to construct call: %w", err)
+	}
+
+	t.nonceLock.Lock()
+	defer t.nonceLock.Unlock()
+
+	nonce, err := t.nextNonce()
+	if err != nil {
+		return types.Hash{}, err
+	}
+	ext := &pendingExtrinsic{
+		method: method,
+		call:   call,
+		nonce:  nonce,
+		tip:    t.tip,
+	}
+	err = t.submit(ext)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("submission of extrinsic failed: %w", err)
+	}
+	t.nonce = nonce + 1
+
+	t.pendingLock.Lock()
+	t.pending[ext.hash] = ext
+	t.pendingLock.Unlock()
+
+	t.log.Info().Str("extrinsic", ext.hash.Hex()).Msgf("Extrinsic call submitted... method %s, sender %s, nonce %d", method, t.key.Address, nonce)
+	return ext.hash, nil
+}
+
+// TrackExtrinsic waits until the extrinsic is finalized and returns the block it was
+// included in with the events it emitted. Extrinsic is resubmitted if it is dropped,
+// usurped, invalid or not included in a block in time.
+func (t *MonitoredTransactor) TrackExtrinsic(extHash types.Hash) (*Receipt, error) {
+	t.pendingLock.Lock()
+	ext, ok := t.pending[extHash]
+	t.pendingLock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("extrinsic %s is not pending", extHash.Hex())
+	}
+	defer func() {
+		ext.sub.Unsubscribe()
+		t.pendingLock.Lock()
+		delete(t.pending, extHash)
+		t.pendingLock.Unlock()
+	}()
+
+	ticker := time.NewTicker(blockCheckPeriod)
+	timeout := time.NewTimer(extrinsicTimeout)
+	defer ticker.Stop()
+	defer timeout.Stop()
+
+	for {
+		select {
+		case status := <-ext.sub.Chan():
+			{
+				var err error
+				switch {
+				case status.IsInBlock:
+					ext.inBlock = true
+					t.log.Debug().Str("extrinsic", ext.hash.Hex()).Msgf("Extrinsic in block with hash: %#x", status.AsInBlock)
+				case status.IsRetracted:
+					ext.inBlock = false
+					t.log.Warn().Str("extrinsic", ext.hash.Hex()).Msgf("Extrinsic block %#x retracted", status.AsRetracted)
+				case status.IsFinalized:
+					return t.receipt(ext, status.AsFinalized)
+				case status.IsFinalityTimeout:
+					return nil, fmt.Errorf("extrinsic %s finality timed out in block %#x", ext.hash.Hex(), status.AsFinalityTimeout)
+				case status.IsDropped:
+					t.log.Warn().Str("extrinsic", ext.hash.Hex()).Msg("Extrinsic dropped from the transaction pool")
+					err = t.resubmit(ext, false)
+				case status.IsUsurped:
+					t.log.Warn().Str("extrinsic", ext.hash.Hex()).Msgf("Extrinsic usurped by %#x", status.AsUsurped)
+					err = t.resubmit(ext, true)
+				case status.IsInvalid:
+					t.log.Warn().Str("extrinsic", ext.hash.Hex()).Msg("Extrinsic invalid")
+					err = t.resubmit(ext, true)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+		case err := <-ext.sub.Err():
+			{
+				return nil, fmt.Errorf("extrinsic %s subscription failed: %w", ext.hash.Hex(), err)
+			}
+		case <-ticker.C:
+			{
+				if ext.inBlock {
+					continue
+				}
+
+				header, err := t.conn.GetHeaderLatest()
+				if err != nil {
+					t.log.Warn().Err(err).Msg("Failed fetching latest header")
+					continue
+				}
+				if uint64(header.Number) < ext.submitBlock+t.resubmitBlocks {
+					continue
+				}
+
+				t.log.Warn().Str("extrinsic", ext.hash.Hex()).Msgf("Extrinsic not included in %d blocks", t.resubmitBlocks)
+				err = t.resubmit(ext, false)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case <-timeout.C:
+			{
+				return nil, fmt.Errorf("extrinsic %s has timed out", extHash.Hex())
+			}
+		}
+	}
+}
+
+// IncreaseTip bumps tip by preset percentage.
+//
+// If tip was 10 and the increase percentage is 15 the new tip
+// would be 11 (it floors the value). In case the tip didn't
+// change it increases it by 1. Increased tip is limited by
+// max tip, but tip is never decreased.
+func (t *MonitoredTransactor) IncreaseTip(tip uint64) uint64 {
+	increasedTip := tip + tip*t.tipIncreasePercentage/100
+	if increasedTip == tip {
+		increasedTip = tip + 1
+	}
+
+	if t.maxTip != 0 && increasedTip > t.maxTip {
+		increasedTip = t.maxTip
+	}
+	if increasedTip < tip {
+		return tip
+	}
+	return increasedTip
+}
+
+// resubmit signs the extrinsic again with a higher tip. Extrinsic is signed with
+// a fresh nonce if its nonce was used by another extrinsic.
+func (t *MonitoredTransactor) resubmit(ext *pendingExtrinsic, refreshNonce bool) error {
+	if ext.resubmitted >= maxResubmissions {
+		return fmt.Errorf("extrinsic %s failed after %d resubmissions", ext.hash.Hex(), ext.resubmitted)
+	}
+	ext.resubmitted++
+	ext.sub.Unsubscribe()
+	ext.inBlock = false
+	ext.tip = t.IncreaseTip(ext.tip)
+
+	if !refreshNonce {
+		err := t.submit(ext)
+		if err != nil {
+			return err
+		}
+	} else {
+		t.nonceLock.Lock()
+		t.nonce = 0
+		nonce, err := t.nextNonce()
+		if err != nil {
+			t.nonceLock.Unlock()
+			return err
+		}
+		ext.nonce = nonce
+		err = t.submit(ext)
+		if err != nil {
+			t.nonceLock.Unlock()
+			return err
+		}
+		t.nonce = nonce + 1
+		t.nonceLock.Unlock()
+	}
+
+	t.log.Info().Str("extrinsic", ext.hash.Hex()).Msgf("Resubmitted extrinsic... method %s, nonce %d, tip %d", ext.method, ext.nonce, ext.tip)
+	return nil
+}
+
+func (t *MonitoredTransactor) submit(ext *pendingExtrinsic) error {
+	meta := t.conn.GetMetadata()
+	rv, err := t.conn.GetRuntimeVersionLatest()
+	if err != nil {
+		return err
+	}
+	header, err := t.conn.GetHeaderLatest()
+	if err != nil {
+		return err
+	}
+
+	genesisHash := t.conn.GetGenesisHash()
+	e := extrinsic.NewExtrinsic(ext.call)
+	err = e.Sign(
+		*t.key,
+		&meta,
+		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, genesisHash),
+		extrinsic.WithNonce(types.NewUCompactFromUInt(ext.nonce)),
+		extrinsic.WithTip(types.NewUCompactFromUInt(ext.tip)),
+		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled),
+		extrinsic.WithSpecVersion(rv.SpecVersion),
+		extrinsic.WithTransactionVersion(rv.TransactionVersion),
+		extrinsic.WithGenesisHash(genesisHash),
+		extrinsic.WithMetadataHash(extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+	)
+	if err != nil {
+		return err
+	}
+
+	enc, err := codec.EncodeToHex(e)
+	if err != nil {
+		return err
+	}
+	extHash, err := extrinsicHash(enc)
+	if err != nil {
+		return err
+	}
+
+	sub, err := t.watch(e)
+	if err != nil {
+		return err
+	}
+
+	ext.hash = extHash
+	ext.sub = sub
+	ext.submitBlock = uint64(header.Number)
+	return nil
+}
+
+func (t *MonitoredTransactor) submitAndWatch(ext extrinsic.Extrinsic) (extrinsicSubscription, error) {
+	sub, err := t.conn.SubmitAndWatchExtrinsic(ext)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// nextNonce returns the local nonce unless the chain nonce, which
+// includes extrinsics pending in the transaction pool, is higher
+func (t *MonitoredTransactor) nextNonce() (uint64, error) {
+	chainNonce, err := t.conn.AccountNextIndex(t.key.Address)
+	if err != nil {
+		return 0, err
+	}
+
+	if chainNonce < t.nonce {
+		return t.nonce, nil
+	}
+	return chainNonce, nil
+}
+
+func (t *MonitoredTransactor) receipt(ext *pendingExtrinsic, blockHash types.Hash) (*Receipt, error) {
+	signedBlock, err := t.conn.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &Receipt{
+		ExtrinsicHash: ext.hash,
+		BlockHash:     blockHash,
+		BlockNumber:   uint64(signedBlock.Block.Header.Number),
+	}
+	found := false
+	for i, blockExtrinsic := range signedBlock.Block.Extrinsics {
+		h, err := extrinsicHash(blockExtrinsic)
+		if err != nil {
+			return nil, err
+		}
+		if h == ext.hash {
+			receipt.ExtrinsicIndex = uint32(i)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("extrinsic %s not found in block %s", ext.hash.Hex(), blockHash.Hex())
+	}
+
+	evts, err := t.conn.GetBlockEvents(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	eventNames := make([]string, 0)
+	for _, evt := range evts {
+		if evt.Phase == nil || !evt.Phase.IsApplyExtrinsic || evt.Phase.AsApplyExtrinsic != receipt.ExtrinsicIndex {
+			continue
+		}
+
+		receipt.Events = append(receipt.Events, evt)
+		eventNames = append(eventNames, evt.Name)
+	}
+
+	t.log.Info().Str("extrinsic", ext.hash.Hex()).Msgf(
+		"Extrinsic is finalized in block %d with hash %s, events: %s",
+		receipt.BlockNumber, blockHash.Hex(), strings.Join(eventNames, ", "),
+	)
+	for _, evt := range receipt.Events {
+		if evt.Name == extrinsicFailedEvent {
+			return receipt, fmt.Errorf("extrinsic %s failed in block %s", ext.hash.Hex(), blockHash.Hex())
+		}
+	}
+	return receipt, nil
+}
+
+// extrinsicHash returns blake2b-256 hash of the hex encoded extrinsic
+func extrinsicHash(enc string) (types.Hash, error) {
+	extBytes, err := hex.DecodeString(strings.TrimPrefix(enc, "0x"))
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("failed to decode extrinsic hex: %w", err)
+	}
+
+	hasher, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("failed to create hasher: %w", err)
+	}
+	hasher.Write(extBytes)
+	return types.NewHash(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,261 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transactor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mock_transactor "github.com/ChainSafe/sygma-relayer/chains/substrate/transactor/mock"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactorTestSuite struct {
+	suite.Suite
+}
+
+func TestRunTransactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactorTestSuite))
+}
+
+func (s *TransactorTestSuite) TestTransactor_IncreaseTip_15PercentIncrease() {
+	t := NewMonitoredTransactor(1, nil, &signature.TestKeyringPairAlice, 0, 150, 15, 10)
+
+	s.Equal(t.IncreaseTip(0), uint64(1))
+	s.Equal(t.IncreaseTip(1), uint64(2))
+	s.Equal(t.IncreaseTip(10), uint64(11))
+	s.Equal(t.IncreaseTip(100), uint64(115))
+}
+
+func (s *TransactorTestSuite) TestTransactor_IncreaseTip_MaxTipReached() {
+	t := NewMonitoredTransactor(1, nil, &signature.TestKeyringPairAlice, 0, 15, 15, 10)
+
+	s.Equal(t.IncreaseTip(14), uint64(15))
+	s.Equal(t.IncreaseTip(15), uint64(15))
+}
+
+func (s *TransactorTestSuite) TestTransactor_IncreaseTip_TipOverMaxTip_NotDecreased() {
+	t := NewMonitoredTransactor(1, nil, &signature.TestKeyringPairAlice, 0, 15, 15, 10)
+
+	s.Equal(t.IncreaseTip(100), uint64(100))
+}
+
+func (s *TransactorTestSuite) TestTransactor_IncreaseTip_NoMaxTip() {
+	t := NewMonitoredTransactor(1, nil, &signature.TestKeyringPairAlice, 0, 0, 15, 10)
+
+	s.Equal(t.IncreaseTip(1000000), uint64(1150000))
+}
+
+func (s *TransactorTestSuite) TestTransactor_TrackExtrinsic_UnknownExtrinsic() {
+	t := NewMonitoredTransactor(1, nil, &signature.TestKeyringPairAlice, 0, 0, 15, 10)
+
+	_, err := t.TrackExtrinsic(types.Hash{1})
+
+	s.NotNil(err)
+}
+
+type ExtrinsicTrackingTestSuite struct {
+	suite.Suite
+	mockConn     *mock_transactor.MockConnection
+	transactor   *MonitoredTransactor
+	statuses     []chan types.ExtrinsicStatus
+	submitted    []string
+	head         types.BlockNumber
+	blockCheck   time.Duration
+	finalizedRef types.Hash
+}
+
+func TestRunExtrinsicTrackingTestSuite(t *testing.T) {
+	suite.Run(t, new(ExtrinsicTrackingTestSuite))
+}
+
+func (s *ExtrinsicTrackingTestSuite) SetupSuite() {
+	s.blockCheck = blockCheckPeriod
+	blockCheckPeriod = time.Millisecond
+}
+
+func (s *ExtrinsicTrackingTestSuite) TearDownSuite() {
+	blockCheckPeriod = s.blockCheck
+}
+
+func (s *ExtrinsicTrackingTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.mockConn = mock_transactor.NewMockConnection(ctrl)
+	s.transactor = NewMonitoredTransactor(1, s.mockConn, &signature.TestKeyringPairAlice, 10, 100, 50, 5)
+
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	s.Nil(err)
+	s.head = 10
+	s.finalizedRef = types.Hash{2}
+	s.submitted = make([]string, 0)
+	s.statuses = make([]chan types.ExtrinsicStatus, maxResubmissions+1)
+	for i := range s.statuses {
+		s.statuses[i] = make(chan types.ExtrinsicStatus, 1)
+	}
+	s.mockConn.EXPECT().GetMetadata().Return(meta).AnyTimes()
+	s.mockConn.EXPECT().GetGenesisHash().Return(types.Hash{1}).AnyTimes()
+	s.mockConn.EXPECT().GetRuntimeVersionLatest().Return(&types.RuntimeVersion{SpecVersion: 1, TransactionVersion: 1}, nil).AnyTimes()
+	s.mockConn.EXPECT().GetHeaderLatest().DoAndReturn(func() (*types.Header, error) {
+		return &types.Header{Number: s.head}, nil
+	}).AnyTimes()
+	s.mockConn.EXPECT().GetBlock(s.finalizedRef).DoAndReturn(func(hash types.Hash) (*block.SignedBlock, error) {
+		return &block.SignedBlock{
+			Block: block.Block{
+				Header:     types.Header{Number: s.head},
+				Extrinsics: []string{s.submitted[len(s.submitted)-1]},
+			},
+		}, nil
+	}).AnyTimes()
+	s.mockConn.EXPECT().GetBlockEvents(s.finalizedRef).Return([]*parser.Event{}, nil).AnyTimes()
+
+	s.transactor.watch = func(ext extrinsic.Extrinsic) (extrinsicSubscription, error) {
+		enc, err := codec.EncodeToHex(ext)
+		if err != nil {
+			return nil, err
+		}
+		sub := mock_transactor.NewMockextrinsicSubscription(ctrl)
+		sub.EXPECT().Chan().Return(s.statuses[len(s.submitted)]).AnyTimes()
+		sub.EXPECT().Err().Return(make(chan error)).AnyTimes()
+		sub.EXPECT().Unsubscribe().AnyTimes()
+		s.submitted = append(s.submitted, enc)
+		return sub, nil
+	}
+}
+
+func (s *ExtrinsicTrackingTestSuite) transact() (types.Hash, *pendingExtrinsic) {
+	hash, err := s.transactor.Transact("System.remark", []byte{1})
+	s.Nil(err)
+	return hash, s.transactor.pending[hash]
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_Transact_LocalNonceTracked() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil).Times(2)
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(10), nil)
+
+	_, first := s.transact()
+	_, second := s.transact()
+	_, third := s.transact()
+
+	s.Equal(first.nonce, uint64(5))
+	s.Equal(second.nonce, uint64(6))
+	s.Equal(third.nonce, uint64(10))
+	s.Equal(first.tip, uint64(10))
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_Transact_SubmissionFails_NonceNotIncreased() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil).Times(2)
+	s.transactor.watch = func(ext extrinsic.Extrinsic) (extrinsicSubscription, error) {
+		return nil, errors.New("error")
+	}
+
+	_, err := s.transactor.Transact("System.remark", []byte{1})
+	s.NotNil(err)
+	nonce, err := s.transactor.nextNonce()
+
+	s.Nil(err)
+	s.Equal(nonce, uint64(5))
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_Finalized() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	hash, ext := s.transact()
+	s.statuses[0] <- types.ExtrinsicStatus{IsInBlock: true, AsInBlock: s.finalizedRef}
+	go func() {
+		s.statuses[0] <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: s.finalizedRef}
+	}()
+
+	receipt, err := s.transactor.TrackExtrinsic(hash)
+
+	s.Nil(err)
+	s.Equal(receipt.ExtrinsicHash, hash)
+	s.Equal(receipt.BlockHash, s.finalizedRef)
+	s.Equal(ext.resubmitted, 0)
+	s.Equal(len(s.submitted), 1)
+	s.NotContains(s.transactor.pending, hash)
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_Dropped_ResubmittedWithSameNonce() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	hash, ext := s.transact()
+	s.statuses[0] <- types.ExtrinsicStatus{IsDropped: true}
+	s.statuses[1] <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: s.finalizedRef}
+
+	receipt, err := s.transactor.TrackExtrinsic(hash)
+
+	s.Nil(err)
+	s.Equal(receipt.ExtrinsicHash, ext.hash)
+	s.Equal(ext.nonce, uint64(5))
+	s.Equal(ext.tip, uint64(15))
+	s.Equal(ext.resubmitted, 1)
+	s.Equal(len(s.submitted), 2)
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_Usurped_ResubmittedWithNewNonce() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(7), nil)
+	hash, ext := s.transact()
+	s.statuses[0] <- types.ExtrinsicStatus{IsUsurped: true, AsUsurped: types.Hash{3}}
+	s.statuses[1] <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: s.finalizedRef}
+
+	_, err := s.transactor.TrackExtrinsic(hash)
+
+	s.Nil(err)
+	s.Equal(ext.nonce, uint64(7))
+	s.Equal(ext.tip, uint64(15))
+	s.Equal(s.transactor.nonce, uint64(8))
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_Invalid_ResubmittedWithNewNonce() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(6), nil)
+	hash, ext := s.transact()
+	s.statuses[0] <- types.ExtrinsicStatus{IsInvalid: true}
+	s.statuses[1] <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: s.finalizedRef}
+
+	_, err := s.transactor.TrackExtrinsic(hash)
+
+	s.Nil(err)
+	s.Equal(ext.nonce, uint64(6))
+	s.Equal(ext.resubmitted, 1)
+	s.Equal(s.transactor.nonce, uint64(7))
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_NotIncludedInTime_Resubmitted() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	hash, ext := s.transact()
+	s.head = 15
+	s.statuses[1] <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: s.finalizedRef}
+
+	_, err := s.transactor.TrackExtrinsic(hash)
+
+	s.Nil(err)
+	s.Equal(ext.nonce, uint64(5))
+	s.Equal(ext.tip, uint64(15))
+	s.Equal(ext.submitBlock, uint64(15))
+	s.Equal(len(s.submitted), 2)
+}
+
+func (s *ExtrinsicTrackingTestSuite) Test_TrackExtrinsic_MaxResubmissionsReached() {
+	s.mockConn.EXPECT().AccountNextIndex(signature.TestKeyringPairAlice.Address).Return(uint64(5), nil)
+	hash, ext := s.transact()
+	for i := range s.statuses {
+		s.statuses[i] <- types.ExtrinsicStatus{IsDropped: true}
+	}
+
+	_, err := s.transactor.TrackExtrinsic(hash)
+
+	s.NotNil(err)
+	s.Equal(ext.resubmitted, maxResubmissions)
+	s.Equal(ext.tip, uint64(73))
+	s.NotContains(s.transactor.pending, hash)
+}
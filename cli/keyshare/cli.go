@@ -0,0 +1,17 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var KeyshareCLI = &cobra.Command{
+	Use:   "keyshare",
+	Short: "Keyshare management",
+}
+
+func init() {
+	KeyshareCLI.AddCommand(migrateCMD, listCMD, inspectCMD, rollbackCMD, publicKeyCMD, derivedAddressCMD, verifyCMD)
+}
@@ -0,0 +1,111 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	ecdsaCommon "github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+)
+
+var (
+	derivedAddressCMD = &cobra.Command{
+		Use:   "derived-address",
+		Short: "Print derived signing addresses",
+		Long:  "Prints addresses of ECDSA keys derived from the MPC key of the ECDSA or CMP keyshare for each domain",
+		RunE:  derivedAddress,
+	}
+)
+
+var (
+	domains       []uint
+	bridgeVersion uint32
+)
+
+func init() {
+	derivedAddressCMD.PersistentFlags().StringVar(&path, "path", "", "path to the active keyshare file")
+	_ = derivedAddressCMD.MarkPersistentFlagRequired("path")
+	derivedAddressCMD.PersistentFlags().StringVar(&passphrase, "passphrase", "", "passphrase or secret reference (file://, env://, vault://) used to decrypt the keyshare")
+	derivedAddressCMD.PersistentFlags().StringVar(&keyshareType, "type", "ecdsa", "keyshare type (ecdsa or cmp)")
+	derivedAddressCMD.PersistentFlags().UintSliceVar(&domains, "domains", []uint{}, "domain IDs to derive signing addresses for")
+	_ = derivedAddressCMD.MarkPersistentFlagRequired("domains")
+	derivedAddressCMD.PersistentFlags().Uint32Var(&bridgeVersion, "bridge-version", 0, "bridge version used to derive signing keys")
+}
+
+func derivedAddress(cmd *cobra.Command, args []string) error {
+	if bridgeVersion >= ecdsaCommon.HardenedKeyStart {
+		return fmt.Errorf("bridge version has to be lower than %d", ecdsaCommon.HardenedKeyStart)
+	}
+
+	var encrypter keyshare.Encrypter = keyshare.PlaintextEncrypter{}
+	if passphrase != "" {
+		resolvedPassphrase, err := config.ResolveSecret(passphrase)
+		if err != nil {
+			return err
+		}
+		encrypter = keyshare.NewPassphraseEncrypter(resolvedPassphrase)
+	}
+
+	publicKey, chainCode, err := readChainKey(encrypter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("MPC address: %s\n", crypto.PubkeyToAddress(*publicKey.ToECDSA()))
+	for _, domain := range domains {
+		if domain > math.MaxUint8 {
+			return fmt.Errorf("invalid domain ID %d", domain)
+		}
+
+		derivationPath := ecdsaCommon.DomainDerivationPath(uint8(domain), bridgeVersion)
+		_, childKey, err := ecdsaCommon.DeriveKey(publicKey, chainCode, derivationPath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("domain %d, path %v: %s\n", domain, derivationPath, crypto.PubkeyToAddress(*childKey.ToECDSA()))
+	}
+	return nil
+}
+
+// readChainKey returns MPC public key and chain code of the ECDSA or CMP keyshare
+func readChainKey(encrypter keyshare.Encrypter) (*btcec.PublicKey, []byte, error) {
+	switch keyshareType {
+	case "ecdsa":
+		{
+			key, err := keyshare.NewEncryptedECDSAKeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, nil, err
+			}
+			if key.Key.ECDSAPub == nil {
+				return nil, nil, fmt.Errorf("missing public key in keyshare %s", path)
+			}
+			return key.Key.ECDSAPub.ToBtcecPubKey(), key.ChainCode, nil
+		}
+	case "cmp":
+		{
+			key, err := keyshare.NewEncryptedCMPKeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, nil, err
+			}
+			publicKeyBytes, err := key.Key.PublicPoint().MarshalBinary()
+			if err != nil {
+				return nil, nil, err
+			}
+			publicKey, err := btcec.ParsePubKey(publicKeyBytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			return publicKey, key.ChainCode, nil
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported keyshare type: %s", keyshareType)
+	}
+}
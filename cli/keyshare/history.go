@@ -0,0 +1,113 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var (
+	listCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List keyshare versions",
+		Long:  "Lists all keyshare versions stored in the keyshare history",
+		RunE:  list,
+	}
+	inspectCMD = &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect keyshare version",
+		Long:  "Prints public parts of the keyshare version. Active version is inspected if version is not provided",
+		RunE:  inspect,
+	}
+	rollbackCMD = &cobra.Command{
+		Use:   "rollback",
+		Short: "Activate previous keyshare version",
+		Long:  "Replaces the active keyshare with the keyshare version from the history. Relayer should be stopped while rolling back",
+		RunE:  rollback,
+	}
+)
+
+var (
+	version int
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{listCMD, inspectCMD, rollbackCMD} {
+		cmd.PersistentFlags().StringVar(&path, "path", "", "path to the active keyshare file")
+		_ = cmd.MarkPersistentFlagRequired("path")
+	}
+	inspectCMD.PersistentFlags().IntVar(&version, "version", 0, "keyshare version to inspect")
+	rollbackCMD.PersistentFlags().IntVar(&version, "version", 0, "keyshare version to activate")
+	_ = rollbackCMD.MarkPersistentFlagRequired("version")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	versions, active, err := keyshare.NewKeyshareHistory(path, keyshare.PlaintextEncrypter{}).Versions()
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Printf("No keyshare versions stored for %s\n", path)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tACTIVE\tCREATED\tPUBLIC KEY\tSESSION\tTOPOLOGY HASH")
+	for _, metadata := range versions {
+		activeMarker := ""
+		if metadata.Version == active {
+			activeMarker = "*"
+		}
+		fmt.Fprintf(
+			w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			metadata.Version, activeMarker, metadata.Timestamp.Format(time.RFC3339),
+			metadata.PublicKey, metadata.SessionID, metadata.TopologyHash,
+		)
+	}
+	return w.Flush()
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	history := keyshare.NewKeyshareHistory(path, keyshare.PlaintextEncrypter{})
+	if version == 0 {
+		_, active, err := history.Versions()
+		if err != nil {
+			return err
+		}
+		if active == 0 {
+			return fmt.Errorf("no active keyshare version for %s", path)
+		}
+		version = active
+	}
+
+	metadata, err := history.Version(version)
+	if err != nil {
+		return err
+	}
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(metadataJSON))
+	return nil
+}
+
+func rollback(cmd *cobra.Command, args []string) error {
+	err := keyshare.NewKeyshareHistory(path, keyshare.PlaintextEncrypter{}).Activate(version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Activated keyshare version %d for %s\n", version, path)
+	return nil
+}
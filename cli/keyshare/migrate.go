@@ -0,0 +1,88 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var (
+	migrateCMD = &cobra.Command{
+		Use:   "migrate",
+		Short: "Encrypt plaintext keyshare",
+		Long:  "Encrypts existing plaintext ECDSA or FROST keyshare file in place with the provided passphrase",
+		RunE:  migrate,
+	}
+)
+
+var (
+	path       string
+	passphrase string
+)
+
+func init() {
+	migrateCMD.PersistentFlags().StringVar(&path, "path", "", "path to the keyshare file")
+	_ = migrateCMD.MarkFlagRequired("path")
+	migrateCMD.PersistentFlags().StringVar(&passphrase, "passphrase", "", "passphrase or secret reference (file://, env://, vault://) used to encrypt the keyshare")
+	_ = migrateCMD.MarkFlagRequired("passphrase")
+}
+
+func migrate(cmd *cobra.Command, args []string) error {
+	resolvedPassphrase, err := config.ResolveSecret(passphrase)
+	if err != nil {
+		return err
+	}
+	if resolvedPassphrase == "" {
+		return fmt.Errorf("empty passphrase")
+	}
+	encrypter := keyshare.NewPassphraseEncrypter(resolvedPassphrase)
+
+	// keyshare versions in the history are stored with the same encryption as the active keyshare
+	history := keyshare.NewKeyshareHistory(path, encrypter)
+	versions, _, err := history.Versions()
+	if err != nil {
+		return err
+	}
+	for _, metadata := range versions {
+		err = encryptFile(history.VersionPath(metadata.Version), encrypter, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = encryptFile(path, encrypter, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully encrypted keyshare %s\n", path)
+	return nil
+}
+
+// encryptFile encrypts keyshare file in place. Already encrypted files are skipped
+// if skipEncrypted is set so interrupted migration can be repeated.
+func encryptFile(path string, encrypter keyshare.Encrypter, skipEncrypted bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if keyshare.IsEncrypted(content) {
+		if skipEncrypted {
+			return nil
+		}
+		return fmt.Errorf("keyshare %s is already encrypted", path)
+	}
+
+	encrypted, err := encrypter.Encrypt(content)
+	if err != nil {
+		return err
+	}
+	return keyshare.WriteFileAtomic(path, encrypted)
+}
@@ -0,0 +1,95 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var (
+	publicKeyCMD = &cobra.Command{
+		Use:   "public-key",
+		Short: "Print group public key",
+		Long:  "Prints hex encoded group public key of the active ECDSA, CMP, FROST or Ed25519 keyshare",
+		RunE:  publicKey,
+	}
+)
+
+var (
+	keyshareType string
+)
+
+func init() {
+	publicKeyCMD.PersistentFlags().StringVar(&path, "path", "", "path to the active keyshare file")
+	_ = publicKeyCMD.MarkPersistentFlagRequired("path")
+	publicKeyCMD.PersistentFlags().StringVar(&passphrase, "passphrase", "", "passphrase or secret reference (file://, env://, vault://) used to decrypt the keyshare")
+	publicKeyCMD.PersistentFlags().StringVar(&keyshareType, "type", "ecdsa", "keyshare type (ecdsa, cmp, frost or ed25519)")
+}
+
+func publicKey(cmd *cobra.Command, args []string) error {
+	var encrypter keyshare.Encrypter = keyshare.PlaintextEncrypter{}
+	if passphrase != "" {
+		resolvedPassphrase, err := config.ResolveSecret(passphrase)
+		if err != nil {
+			return err
+		}
+		encrypter = keyshare.NewPassphraseEncrypter(resolvedPassphrase)
+	}
+
+	publicKey, err := readPublicKey(encrypter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(hex.EncodeToString(publicKey))
+	return nil
+}
+
+func readPublicKey(encrypter keyshare.Encrypter) ([]byte, error) {
+	switch keyshareType {
+	case "ecdsa":
+		{
+			key, err := keyshare.NewEncryptedECDSAKeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, err
+			}
+			if key.Key.ECDSAPub == nil {
+				return nil, fmt.Errorf("missing public key in keyshare %s", path)
+			}
+			return key.Key.ECDSAPub.ToBtcecPubKey().SerializeCompressed(), nil
+		}
+	case "cmp":
+		{
+			key, err := keyshare.NewEncryptedCMPKeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, err
+			}
+			return key.Key.PublicPoint().MarshalBinary()
+		}
+	case "frost":
+		{
+			key, err := keyshare.NewEncryptedFrostKeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, err
+			}
+			return key.Key.PublicKey, nil
+		}
+	case "ed25519":
+		{
+			key, err := keyshare.NewEncryptedEd25519KeyshareStore(path, encrypter).GetKeyshare()
+			if err != nil {
+				return nil, err
+			}
+			return key.PublicKey()
+		}
+	default:
+		return nil, fmt.Errorf("unknown keyshare type: %s", keyshareType)
+	}
+}
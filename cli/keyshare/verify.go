@@ -0,0 +1,99 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+var (
+	verifyCMD = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify keyshare integrity",
+		Long:  "Verifies checksum of the active keyshare and all keyshare versions in the history and checks that secret shares match public shares and the group public key",
+		RunE:  verify,
+	}
+)
+
+func init() {
+	verifyCMD.PersistentFlags().StringVar(&path, "path", "", "path to the active keyshare file")
+	_ = verifyCMD.MarkPersistentFlagRequired("path")
+	verifyCMD.PersistentFlags().StringVar(&passphrase, "passphrase", "", "passphrase or secret reference (file://, env://, vault://) used to decrypt the keyshare")
+	verifyCMD.PersistentFlags().StringVar(&keyshareType, "type", "ecdsa", "keyshare type (ecdsa, cmp, frost or ed25519)")
+}
+
+func verify(cmd *cobra.Command, args []string) error {
+	var encrypter keyshare.Encrypter = keyshare.PlaintextEncrypter{}
+	if passphrase != "" {
+		resolvedPassphrase, err := config.ResolveSecret(passphrase)
+		if err != nil {
+			return err
+		}
+		encrypter = keyshare.NewPassphraseEncrypter(resolvedPassphrase)
+	}
+
+	history := keyshare.NewKeyshareHistory(path, encrypter)
+	versions, _, err := history.Versions()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, metadata := range versions {
+		if !verifyFile(history.VersionPath(metadata.Version), encrypter) {
+			failed++
+		}
+	}
+	if !verifyFile(path, encrypter) {
+		failed++
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d keyshare files failed verification", failed)
+	}
+	return nil
+}
+
+// verifyFile loads the keyshare file, which runs integrity checks, and prints the result
+func verifyFile(path string, encrypter keyshare.Encrypter) bool {
+	err := loadKeyshare(path, encrypter)
+	if err != nil {
+		fmt.Printf("%s: INVALID: %s\n", path, err)
+		return false
+	}
+
+	header, err := keyshare.ReadHeader(path, encrypter)
+	if err != nil {
+		fmt.Printf("%s: INVALID: %s\n", path, err)
+		return false
+	}
+	if header.Version == 0 {
+		fmt.Printf("%s: OK (no checksum header, stored before integrity checks were introduced)\n", path)
+		return true
+	}
+	fmt.Printf("%s: OK (public key %s, checksum %s)\n", path, header.PublicKey, header.Checksum)
+	return true
+}
+
+func loadKeyshare(path string, encrypter keyshare.Encrypter) error {
+	var err error
+	switch keyshareType {
+	case keyshare.ECDSAKeyshareType:
+		_, err = keyshare.NewEncryptedECDSAKeyshareStore(path, encrypter).GetKeyshare()
+	case keyshare.CMPKeyshareType:
+		_, err = keyshare.NewEncryptedCMPKeyshareStore(path, encrypter).GetKeyshare()
+	case keyshare.FrostKeyshareType:
+		_, err = keyshare.NewEncryptedFrostKeyshareStore(path, encrypter).GetKeyshare()
+	case keyshare.Ed25519KeyshareType:
+		_, err = keyshare.NewEncryptedEd25519KeyshareStore(path, encrypter).GetKeyshare()
+	default:
+		err = fmt.Errorf("unknown keyshare type: %s", keyshareType)
+	}
+	return err
+}
@@ -0,0 +1,17 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var TssCLI = &cobra.Command{
+	Use:   "tss",
+	Short: "tss session related commands",
+}
+
+func init() {
+	TssCLI.AddCommand(sessionsCMD)
+}
@@ -0,0 +1,101 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionsCMD = &cobra.Command{
+		Use:   "sessions",
+		Short: "List tss sessions pending on the relayer",
+		Long:  "List tss sessions pending on the running relayer queried through the relayer admin endpoint",
+		RunE:  sessions,
+	}
+)
+
+var (
+	adminURL  string
+	sessionID string
+)
+
+func init() {
+	sessionsCMD.PersistentFlags().StringVar(&adminURL, "admin-url", "http://localhost:9002", "URL of the relayer admin endpoint")
+	sessionsCMD.PersistentFlags().StringVar(&sessionID, "session-id", "", "Show only the session with the session ID")
+}
+
+func sessions(cmd *cobra.Command, args []string) error {
+	sessionsURL, err := url.JoinPath(adminURL, "/tss/sessions")
+	if err != nil {
+		return err
+	}
+	if sessionID != "" {
+		sessionsURL = fmt.Sprintf("%s?sessionID=%s", sessionsURL, url.QueryEscape(sessionID))
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(sessionsURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var sessions []tss.Session
+	if sessionID != "" {
+		var session tss.Session
+		err = json.Unmarshal(body, &session)
+		sessions = append(sessions, session)
+	} else {
+		err = json.Unmarshal(body, &sessions)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No pending tss sessions")
+		return nil
+	}
+	for _, session := range sessions {
+		fmt.Printf(`
+Session ID: %s
+Process: %s
+Coordinator: %s
+Started: %s (%s ago)
+Retries: %d
+Ready peers: %s
+Excluded peers: %s
+Waiting for: %s
+`,
+			session.SessionID,
+			session.ProcessType,
+			session.Coordinator,
+			session.StartTime.Format(time.RFC3339),
+			time.Since(session.StartTime).Round(time.Second),
+			session.Retries,
+			strings.Join(session.ReadyPeers, ", "),
+			strings.Join(session.ExcludedPeers, ", "),
+			strings.Join(session.WaitingFor, ", "),
+		)
+	}
+	return nil
+}
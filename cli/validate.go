@@ -0,0 +1,50 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/config/validator"
+)
+
+var (
+	validateConfigCMD = &cobra.Command{
+		Use:   "validate-config",
+		Short: "Validate relayer configuration",
+		Long: "Loads configuration the same way the run command does, builds every domain " +
+			"configuration and reports all found problems",
+		RunE: validateConfig,
+	}
+)
+
+var (
+	checkConnectivity bool
+)
+
+func init() {
+	validateConfigCMD.PersistentFlags().BoolVar(&checkConnectivity, "check-connectivity", false, "check that domain endpoints are reachable")
+}
+
+func validateConfig(cmd *cobra.Command, args []string) error {
+	configuration, err := config.LoadConfig(viper.GetString(config.ConfigFlagName), viper.GetString("config-url"))
+	if err != nil {
+		return err
+	}
+
+	errs := validator.Validate(configuration, checkConnectivity)
+	if len(errs) == 0 {
+		fmt.Printf("Configuration is valid\n")
+		return nil
+	}
+
+	for _, err := range errs {
+		fmt.Printf("%s\n", err)
+	}
+	return fmt.Errorf("found %d configuration problems", len(errs))
+}
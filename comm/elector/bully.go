@@ -29,10 +29,13 @@ type bullyCoordinatorElector struct {
 	mu           *sync.RWMutex
 	coordinator  peer.ID
 	sortedPeers  util.SortablePeerSlice
+	peerTiers    util.PeerTiers
 }
 
+// NewBullyCoordinatorElector creates bully coordinator elector. Peers in lower reputation
+// tiers are preferred, so tiers should be the same on all relayers.
 func NewBullyCoordinatorElector(
-	sessionID string, host host.Host, config relayer.BullyConfig, communication comm.Communication,
+	sessionID string, host host.Host, config relayer.BullyConfig, communication comm.Communication, peerTiers util.PeerTiers,
 ) CoordinatorElector {
 	bully := &bullyCoordinatorElector{
 		sessionID:    sessionID,
@@ -45,6 +48,7 @@ func NewBullyCoordinatorElector(
 		hostID:       host.ID(),
 		mu:           &sync.RWMutex{},
 		coordinator:  host.ID(),
+		peerTiers:    peerTiers,
 	}
 
 	return bully
@@ -59,7 +63,7 @@ func (bc *bullyCoordinatorElector) Coordinator(ctx context.Context, peers peer.I
 	go bc.listen(ctx)
 	defer cancel()
 
-	bc.sortedPeers = util.SortPeersForSession(peers, bc.sessionID)
+	bc.sortedPeers = util.SortPeersByTier(peers, bc.sessionID, bc.peerTiers)
 	errChan := make(chan error)
 	go bc.startBullyCoordination(errChan)
 
@@ -111,7 +111,7 @@ func (s *BullyTestSuite) SetupIndividualTest(c BullyTestCase) ([]elector.Coordin
 				PingInterval:     1 * time.Second,
 				ElectionWaitTime: 2 * time.Second,
 				BullyWaitTime:    25 * time.Second,
-			}, com)
+			}, com, nil)
 			testBullyCoordinators = append(testBullyCoordinators, b)
 		}
 	}
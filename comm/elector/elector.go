@@ -9,6 +9,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/comm/p2p"
 	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -46,15 +47,16 @@ func NewCoordinatorElectorFactory(h host.Host, config relayer.BullyConfig) *Coor
 	}
 }
 
-// CoordinatorElector creates CoordinatorElector for a specific session
+// CoordinatorElector creates CoordinatorElector for a specific session.
+// Peer tiers are used by the bully elector to prefer well-behaved peers.
 func (c *CoordinatorElectorFactory) CoordinatorElector(
-	sessionID string, electorType CoordinatorElectorType,
+	sessionID string, electorType CoordinatorElectorType, peerTiers util.PeerTiers,
 ) CoordinatorElector {
 	switch electorType {
 	case Static:
 		return NewCoordinatorElector(sessionID)
 	case Bully:
-		return NewBullyCoordinatorElector(sessionID, c.h, c.config, c.comm)
+		return NewBullyCoordinatorElector(sessionID, c.h, c.config, c.comm, peerTiers)
 	default:
 		return nil
 	}
@@ -33,6 +33,8 @@ const (
 	CoordinatorPingMsg
 	// CoordinatorPingResponseMsg message type used to respond on CoordinatorPingMsg message.
 	CoordinatorPingResponseMsg
+	// TssConfirmationMsg message type used to confirm the result of a tss process with other parties.
+	TssConfirmationMsg
 	// Unknown message type
 	Unknown
 )
@@ -66,6 +68,8 @@ func (msgType MessageType) String() string {
 		return "CoordinatorPingMsg"
 	case CoordinatorPingResponseMsg:
 		return "CoordinatorPingResponseMsg"
+	case TssConfirmationMsg:
+		return "TssConfirmationMsg"
 	default:
 		return "UnknownMsg"
 	}
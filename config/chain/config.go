@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ChainSafe/sygma-relayer/config"
+	ecdsaCommon "github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
 	"github.com/spf13/viper"
 )
 
@@ -18,6 +19,8 @@ type GeneralChainConfig struct {
 	BlockstorePath string `mapstructure:"blockstorePath"`
 	FreshStart     bool   `mapstructure:"fresh"`
 	LatestBlock    bool   `mapstructure:"latest"`
+	DeriveKey      bool   `mapstructure:"deriveKey"`
+	BridgeVersion  uint32 `mapstructure:"bridgeVersion"`
 	Key            string
 	Insecure       bool
 }
@@ -33,9 +36,21 @@ func (c *GeneralChainConfig) Validate() error {
 	if c.Name == "" {
 		return fmt.Errorf("required field chain.Name empty for chain %v", *c.Id)
 	}
+	if c.BridgeVersion >= ecdsaCommon.HardenedKeyStart {
+		return fmt.Errorf("bridgeVersion %d has to be lower than %d for chain %v", c.BridgeVersion, ecdsaCommon.HardenedKeyStart, *c.Id)
+	}
 	return nil
 }
 
+// DerivationPath returns derivation path of the ECDSA key used to sign proposals
+// for the domain or empty path if proposals are signed with the MPC key
+func (c *GeneralChainConfig) DerivationPath() []uint32 {
+	if !c.DeriveKey {
+		return nil
+	}
+	return ecdsaCommon.DomainDerivationPath(*c.Id, c.BridgeVersion)
+}
+
 func (c *GeneralChainConfig) ParseFlags() {
 	blockstore := viper.GetString(config.BlockstoreFlagName)
 	if blockstore != "" {
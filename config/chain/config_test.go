@@ -4,6 +4,7 @@
 package chain
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -32,6 +33,13 @@ func TestValidateConfig(t *testing.T) {
 		Endpoint: "endpoint",
 	}
 
+	hardenedBridgeVersion := GeneralChainConfig{
+		Name:          "chain",
+		Id:            &id,
+		Endpoint:      "endpoint",
+		BridgeVersion: 1 << 31,
+	}
+
 	err := valid.Validate()
 	if err != nil {
 		t.Fatal(err)
@@ -51,4 +59,28 @@ func TestValidateConfig(t *testing.T) {
 	if err == nil {
 		t.Fatalf("must require domain id field, %v", err)
 	}
+
+	err = hardenedBridgeVersion.Validate()
+	if err == nil {
+		t.Fatal("must require non-hardened bridge version")
+	}
+}
+
+func TestDerivationPath(t *testing.T) {
+	var id uint8 = 2
+	config := GeneralChainConfig{
+		Name:          "chain",
+		Id:            &id,
+		Endpoint:      "endpoint",
+		BridgeVersion: 3,
+	}
+
+	if config.DerivationPath() != nil {
+		t.Fatal("must not derive key if deriveKey is disabled")
+	}
+
+	config.DeriveKey = true
+	if !reflect.DeepEqual(config.DerivationPath(), []uint32{2, 3}) {
+		t.Fatalf("invalid derivation path %v", config.DerivationPath())
+	}
 }
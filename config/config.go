@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/config/relayer"
 	"github.com/creasty/defaults"
@@ -27,6 +29,24 @@ type RawConfig struct {
 	ChainConfigs  []map[string]interface{} `mapstructure:"domains" json:"domains"`
 }
 
+// LoadConfig loads shared configuration from configURL, if provided, and merges it
+// with configuration read from env variables or from the file at configFlag path.
+func LoadConfig(configFlag string, configURL string) (*Config, error) {
+	var configuration *Config
+	var err error
+	if configURL != "" {
+		configuration, err = GetSharedConfigFromNetwork(configURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.ToLower(configFlag) == "env" {
+		return GetConfigFromENV(configuration)
+	}
+	return GetConfigFromFile(configFlag, configuration)
+}
+
 // GetConfigFromENV reads config from Env variables, validates it and parses
 // it into config suitable for application
 //
@@ -74,6 +94,7 @@ func GetSharedConfigFromNetwork(url string) (*Config, error) {
 	if err != nil {
 		return &Config{}, err
 	}
+	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -94,11 +115,23 @@ func processRawConfig(rawConfig RawConfig, config *Config) (*Config, error) {
 		return config, err
 	}
 
+	err := resolveRelayerSecrets(&rawConfig)
+	if err != nil {
+		return config, err
+	}
+
 	relayerConfig, err := relayer.NewRelayerConfig(rawConfig.RelayerConfig)
 	if err != nil {
 		return config, err
 	}
 	if config == nil {
+		for _, chain := range rawConfig.ChainConfigs {
+			err := resolveChainSecrets(chain)
+			if err != nil {
+				return config, err
+			}
+		}
+
 		config := &Config{}
 		config.RelayerConfig = relayerConfig
 		config.ChainConfigs = rawConfig.ChainConfigs
@@ -124,6 +157,11 @@ func processRawConfig(rawConfig RawConfig, config *Config) (*Config, error) {
 			return config, err
 		}
 
+		err = resolveChainSecrets(chain)
+		if err != nil {
+			return config, err
+		}
+
 		chainConfigs = append(chainConfigs, chain)
 	}
 
@@ -169,6 +207,7 @@ var (
 	BlockstoreFlagName  = "blockstore"
 	FreshStartFlagName  = "fresh"
 	LatestBlockFlagName = "latest"
+	ReloadIntervalFlag  = "config-reload-interval"
 )
 
 func BindFlags(rootCMD *cobra.Command) {
@@ -184,6 +223,9 @@ func BindFlags(rootCMD *cobra.Command) {
 	rootCMD.PersistentFlags().Bool(LatestBlockFlagName, false, "Overrides blockstore and start block, starts from latest block (default: false)")
 	_ = viper.BindPFlag(LatestBlockFlagName, rootCMD.PersistentFlags().Lookup(LatestBlockFlagName))
 
+	rootCMD.PersistentFlags().Duration(ReloadIntervalFlag, time.Minute, "Interval at which configuration is reloaded and safe changes applied without restart. Disabled if 0")
+	_ = viper.BindPFlag(ReloadIntervalFlag, rootCMD.PersistentFlags().Lookup(ReloadIntervalFlag))
+
 	rootCMD.PersistentFlags().String(KeystoreFlagName, "./keys", "Path to keystore directory")
 	_ = viper.BindPFlag(KeystoreFlagName, rootCMD.PersistentFlags().Lookup(KeystoreFlagName))
 }
@@ -65,6 +65,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_KEY", "test-pk")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_KEYSHAREPATH", "/cfg/keyshares/0.keyshare")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_FROSTKEYSHAREPATH", "/cfg/keyshares/0-frost.keyshare")
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_ED25519KEYSHAREPATH", "/cfg/keyshares/0-ed25519.keyshare")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_PORT", "9000")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_ENCRYPTIONKEY", "test-enc-key")
 	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_URL", "http://test.com")
@@ -92,6 +93,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 			Env:        "TEST",
 			Id:         "123",
 			HealthPort: 9001,
+			AdminPort:  9002,
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -101,8 +103,16 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV() {
 				Port:                    9000,
 				KeysharePath:            "/cfg/keyshares/0.keyshare",
 				FrostKeysharePath:       "/cfg/keyshares/0-frost.keyshare",
+				Ed25519KeysharePath:     "/cfg/keyshares/0-ed25519.keyshare",
 				Key:                     "test-pk",
 				CommHealthCheckInterval: 5 * time.Minute,
+				ReputationHalfLife:      24 * time.Hour,
+				MaxConcurrentSessions:   10,
+				EcdsaProtocol:           relayer.GG18,
+				EcdsaSigningProtocol:    relayer.GG18,
+				PresignaturePoolSize:    10,
+				PresignInterval:         time.Minute,
+				KeyCheckInterval:        10 * time.Minute,
 			},
 			BullyConfig: relayer.BullyConfig{
 				PingWaitTime:     1 * time.Second,
@@ -209,6 +219,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 			Env:        "TEST",
 			Id:         "123",
 			HealthPort: 9001,
+			AdminPort:  9002,
 			MpcConfig: relayer.MpcRelayerConfig{
 				TopologyConfiguration: relayer.TopologyConfiguration{
 					EncryptionKey: "test-enc-key",
@@ -220,6 +231,13 @@ func (s *GetConfigTestSuite) Test_GetConfigFromENV_RandomOrder() {
 				FrostKeysharePath:       "/cfg/keyshares/0-frost.keyshare",
 				Key:                     "test-pk",
 				CommHealthCheckInterval: 5 * time.Minute,
+				ReputationHalfLife:      24 * time.Hour,
+				MaxConcurrentSessions:   10,
+				EcdsaProtocol:           relayer.GG18,
+				EcdsaSigningProtocol:    relayer.GG18,
+				PresignaturePoolSize:    10,
+				PresignInterval:         time.Minute,
+				KeyCheckInterval:        10 * time.Minute,
 			},
 			BullyConfig: relayer.BullyConfig{
 				PingWaitTime:     1 * time.Second,
@@ -453,6 +471,99 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 			errorMsg:   "topology configuration encryption key not provided",
 			outConfig:  config.Config{},
 		},
+		{
+			name: "missing cmp keyshare path",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port:          "2020",
+						EcdsaProtocol: "cmp",
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						URL:            "https://testIPFSProvider.com",
+						AuthToken:      "testToken",
+						MaxRetries:     5,
+						MaxElapsedTime: 5 * time.Minute,
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "cmp keyshare path not provided",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "cmp signing without cmp protocol",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port:                 "2020",
+						EcdsaSigningProtocol: "cmp",
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						URL:            "https://testIPFSProvider.com",
+						AuthToken:      "testToken",
+						MaxRetries:     5,
+						MaxElapsedTime: 5 * time.Minute,
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "cmp signing requires cmp ecdsa protocol",
+			outConfig:  config.Config{},
+		},
+		{
+			name: "key refresh without domain",
+			inConfig: config.RawConfig{
+				RelayerConfig: relayer.RawRelayerConfig{
+					LogLevel: "info",
+					MpcConfig: relayer.RawMpcRelayerConfig{
+						TopologyConfiguration: relayer.TopologyConfiguration{
+							EncryptionKey: "enc-key",
+							Url:           "url",
+							Path:          "path",
+						},
+						Port:               "2020",
+						KeyRefreshInterval: "100",
+					},
+					UploaderConfig: relayer.UploaderConfig{
+						URL:            "https://testIPFSProvider.com",
+						AuthToken:      "testToken",
+						MaxRetries:     5,
+						MaxElapsedTime: 5 * time.Minute,
+					},
+				},
+				ChainConfigs: []map[string]interface{}{{
+					"id":   float64(1),
+					"type": "evm",
+					"name": "chain1",
+				}},
+			},
+			shouldFail: true,
+			errorMsg:   "key refresh domain ID not provided",
+			outConfig:  config.Config{},
+		},
 		{
 			name: "set default values in config",
 			inConfig: config.RawConfig{
@@ -488,6 +599,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					LogFile:                   "out.log",
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9001,
+					AdminPort:                 9002,
 					MpcConfig: relayer.MpcRelayerConfig{
 						Port: 9000,
 						TopologyConfiguration: relayer.TopologyConfiguration{
@@ -496,6 +608,13 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 							Path:          "path",
 						},
 						CommHealthCheckInterval: 5 * time.Minute,
+						ReputationHalfLife:      24 * time.Hour,
+						MaxConcurrentSessions:   10,
+						EcdsaProtocol:           relayer.GG18,
+						EcdsaSigningProtocol:    relayer.GG18,
+						PresignaturePoolSize:    10,
+						PresignInterval:         time.Minute,
+						KeyCheckInterval:        10 * time.Minute,
 					},
 					BullyConfig: relayer.BullyConfig{
 						PingWaitTime:     1 * time.Second,
@@ -535,6 +654,8 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 						KeysharePath:            "./share.key",
 						Key:                     "./key.pk",
 						CommHealthCheckInterval: "10m",
+						KeyRefreshInterval:      "100",
+						KeyRefreshDomainID:      "1",
 					},
 					BullyConfig: relayer.RawBullyConfig{
 						PingWaitTime:     "1s",
@@ -564,6 +685,7 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 					LogFile:                   "custom.log",
 					OpenTelemetryCollectorURL: "",
 					HealthPort:                9002,
+					AdminPort:                 9002,
 					MpcConfig: relayer.MpcRelayerConfig{
 						Port:         2020,
 						KeysharePath: "./share.key",
@@ -574,6 +696,15 @@ func (s *GetConfigTestSuite) Test_GetConfigFromFile() {
 							Path:          "path",
 						},
 						CommHealthCheckInterval: 10 * time.Minute,
+						ReputationHalfLife:      24 * time.Hour,
+						MaxConcurrentSessions:   10,
+						EcdsaProtocol:           relayer.GG18,
+						EcdsaSigningProtocol:    relayer.GG18,
+						PresignaturePoolSize:    10,
+						PresignInterval:         time.Minute,
+						KeyCheckInterval:        10 * time.Minute,
+						KeyRefreshInterval:      100,
+						KeyRefreshDomainID:      1,
 					},
 					BullyConfig: relayer.BullyConfig{
 						PingWaitTime:     time.Second,
@@ -17,6 +17,7 @@ type RelayerConfig struct {
 	LogLevel                  zerolog.Level
 	LogFile                   string
 	HealthPort                uint16
+	AdminPort                 uint16
 	Env                       string
 	Id                        string
 	MpcConfig                 MpcRelayerConfig
@@ -29,10 +30,30 @@ type MpcRelayerConfig struct {
 	Port                    uint16
 	KeysharePath            string
 	FrostKeysharePath       string
+	CmpKeysharePath         string
+	Ed25519KeysharePath     string
+	KeysharePassphrase      string
 	Key                     string
 	CommHealthCheckInterval time.Duration
+	ReputationHalfLife      time.Duration
+	MaxConcurrentSessions   int
+	EcdsaProtocol           EcdsaProtocol
+	EcdsaSigningProtocol    EcdsaProtocol
+	PresignaturePoolSize    int
+	PresignInterval         time.Duration
+	KeyCheckInterval        time.Duration
+	KeyRefreshInterval      uint64
+	KeyRefreshDomainID      uint8
 }
 
+// EcdsaProtocol is the threshold ECDSA protocol used for signing
+type EcdsaProtocol string
+
+const (
+	GG18 EcdsaProtocol = "gg18"
+	CMP  EcdsaProtocol = "cmp"
+)
+
 type BullyConfig struct {
 	PingWaitTime     time.Duration
 	PingBackOff      time.Duration
@@ -58,6 +79,7 @@ type RawRelayerConfig struct {
 	LogLevel                  string              `mapstructure:"LogLevel" json:"logLevel" default:"info"`
 	LogFile                   string              `mapstructure:"LogFile" json:"logFile" default:"out.log"`
 	HealthPort                string              `mapstructure:"HealthPort" json:"healthPort" default:"9001"`
+	AdminPort                 string              `mapstructure:"AdminPort" json:"adminPort" default:"9002"`
 	Env                       string              `mapstructure:"Env" json:"env"`
 	Id                        string              `mapstructure:"Id" json:"id"`
 	MpcConfig                 RawMpcRelayerConfig `mapstructure:"MpcConfig" json:"mpcConfig"`
@@ -68,10 +90,22 @@ type RawRelayerConfig struct {
 type RawMpcRelayerConfig struct {
 	KeysharePath            string                `mapstructure:"KeysharePath" json:"keysharePath"`
 	FrostKeysharePath       string                `mapstructure:"FrostKeysharePath" json:"frostKeysharePath"`
+	CmpKeysharePath         string                `mapstructure:"CmpKeysharePath" json:"cmpKeysharePath"`
+	Ed25519KeysharePath     string                `mapstructure:"Ed25519KeysharePath" json:"ed25519KeysharePath"`
+	KeysharePassphrase      string                `mapstructure:"KeysharePassphrase" json:"keysharePassphrase"`
 	Key                     string                `mapstructure:"Key" json:"key"`
 	Port                    string                `mapstructure:"Port" json:"port" default:"9000"`
 	TopologyConfiguration   TopologyConfiguration `mapstructure:"TopologyConfiguration" json:"topologyConfiguration"`
 	CommHealthCheckInterval string                `mapstructure:"CommHealthCheckInterval" json:"commHealthCheckInterval" default:"5m"`
+	ReputationHalfLife      string                `mapstructure:"ReputationHalfLife" json:"reputationHalfLife" default:"24h"`
+	MaxConcurrentSessions   string                `mapstructure:"MaxConcurrentSessions" json:"maxConcurrentSessions" default:"10"`
+	EcdsaProtocol           string                `mapstructure:"EcdsaProtocol" json:"ecdsaProtocol" default:"gg18"`
+	EcdsaSigningProtocol    string                `mapstructure:"EcdsaSigningProtocol" json:"ecdsaSigningProtocol" default:"gg18"`
+	PresignaturePoolSize    string                `mapstructure:"PresignaturePoolSize" json:"presignaturePoolSize" default:"10"`
+	PresignInterval         string                `mapstructure:"PresignInterval" json:"presignInterval" default:"1m"`
+	KeyCheckInterval        string                `mapstructure:"KeyCheckInterval" json:"keyCheckInterval" default:"10m"`
+	KeyRefreshInterval      string                `mapstructure:"KeyRefreshInterval" json:"keyRefreshInterval" default:"0"`
+	KeyRefreshDomainID      string                `mapstructure:"KeyRefreshDomainID" json:"keyRefreshDomainID" default:"0"`
 }
 
 type RawBullyConfig struct {
@@ -118,6 +152,12 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 	}
 	config.HealthPort = uint16(healthPort)
 
+	adminPort, err := strconv.ParseInt(rawConfig.AdminPort, 0, 16)
+	if err != nil {
+		return RelayerConfig{}, fmt.Errorf("unable to parse admin port %v", err)
+	}
+	config.AdminPort = uint16(adminPort)
+
 	mpcConfig, err := parseMpcConfig(rawConfig)
 	if err != nil {
 		return RelayerConfig{}, err
@@ -147,6 +187,9 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	mpcConfig.TopologyConfiguration = rawConfig.MpcConfig.TopologyConfiguration
 	mpcConfig.KeysharePath = rawConfig.MpcConfig.KeysharePath
 	mpcConfig.FrostKeysharePath = rawConfig.MpcConfig.FrostKeysharePath
+	mpcConfig.CmpKeysharePath = rawConfig.MpcConfig.CmpKeysharePath
+	mpcConfig.Ed25519KeysharePath = rawConfig.MpcConfig.Ed25519KeysharePath
+	mpcConfig.KeysharePassphrase = rawConfig.MpcConfig.KeysharePassphrase
 	mpcConfig.Key = rawConfig.MpcConfig.Key
 
 	duration, err := time.ParseDuration(rawConfig.MpcConfig.CommHealthCheckInterval)
@@ -155,6 +198,74 @@ func parseMpcConfig(rawConfig RawRelayerConfig) (MpcRelayerConfig, error) {
 	}
 	mpcConfig.CommHealthCheckInterval = duration
 
+	reputationHalfLife, err := time.ParseDuration(rawConfig.MpcConfig.ReputationHalfLife)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse reputation half-life: %w", err)
+	}
+	mpcConfig.ReputationHalfLife = reputationHalfLife
+
+	maxConcurrentSessions, err := strconv.Atoi(rawConfig.MpcConfig.MaxConcurrentSessions)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse max concurrent sessions: %w", err)
+	}
+	mpcConfig.MaxConcurrentSessions = maxConcurrentSessions
+
+	mpcConfig.EcdsaProtocol = EcdsaProtocol(rawConfig.MpcConfig.EcdsaProtocol)
+	switch mpcConfig.EcdsaProtocol {
+	case GG18:
+	case CMP:
+		if mpcConfig.CmpKeysharePath == "" {
+			return MpcRelayerConfig{}, errors.New("cmp keyshare path not provided")
+		}
+	default:
+		return MpcRelayerConfig{}, fmt.Errorf("unknown ecdsa protocol: %s", rawConfig.MpcConfig.EcdsaProtocol)
+	}
+
+	// signing protocol has to be switched on all relayers at once after all of them migrated to CMP
+	mpcConfig.EcdsaSigningProtocol = EcdsaProtocol(rawConfig.MpcConfig.EcdsaSigningProtocol)
+	switch mpcConfig.EcdsaSigningProtocol {
+	case GG18:
+	case CMP:
+		if mpcConfig.EcdsaProtocol != CMP {
+			return MpcRelayerConfig{}, errors.New("cmp signing requires cmp ecdsa protocol")
+		}
+	default:
+		return MpcRelayerConfig{}, fmt.Errorf("unknown ecdsa signing protocol: %s", rawConfig.MpcConfig.EcdsaSigningProtocol)
+	}
+
+	presignaturePoolSize, err := strconv.Atoi(rawConfig.MpcConfig.PresignaturePoolSize)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse presignature pool size: %w", err)
+	}
+	mpcConfig.PresignaturePoolSize = presignaturePoolSize
+
+	presignInterval, err := time.ParseDuration(rawConfig.MpcConfig.PresignInterval)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse presign interval: %w", err)
+	}
+	mpcConfig.PresignInterval = presignInterval
+
+	keyCheckInterval, err := time.ParseDuration(rawConfig.MpcConfig.KeyCheckInterval)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse key check interval: %w", err)
+	}
+	mpcConfig.KeyCheckInterval = keyCheckInterval
+
+	keyRefreshInterval, err := strconv.ParseUint(rawConfig.MpcConfig.KeyRefreshInterval, 10, 64)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse key refresh interval: %w", err)
+	}
+	mpcConfig.KeyRefreshInterval = keyRefreshInterval
+
+	keyRefreshDomainID, err := strconv.ParseUint(rawConfig.MpcConfig.KeyRefreshDomainID, 10, 8)
+	if err != nil {
+		return MpcRelayerConfig{}, fmt.Errorf("unable to parse key refresh domain ID: %w", err)
+	}
+	mpcConfig.KeyRefreshDomainID = uint8(keyRefreshDomainID)
+	if mpcConfig.KeyRefreshInterval != 0 && mpcConfig.KeyRefreshDomainID == 0 {
+		return MpcRelayerConfig{}, errors.New("key refresh domain ID not provided")
+	}
+
 	return mpcConfig, nil
 }
 
@@ -0,0 +1,155 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	FileSecretPrefix  = "file://"
+	EnvSecretPrefix   = "env://"
+	VaultSecretPrefix = "vault://"
+)
+
+// secretChainFields are domain configuration fields that can contain secret references
+var secretChainFields = []string{"key", "password"}
+
+// ResolveSecret resolves value if it is a secret reference, otherwise
+// value is returned as is. Supported references are:
+//
+// file://<path> - secret is read from the file at path
+//
+// env://<name> - secret is read from the env variable name
+//
+// vault://<path>#<field> - secret is read from the field of the Vault secret at path.
+// Vault address and token are read from VAULT_ADDR and VAULT_TOKEN env variables.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, FileSecretPrefix):
+		return fileSecret(strings.TrimPrefix(value, FileSecretPrefix))
+	case strings.HasPrefix(value, EnvSecretPrefix):
+		return envSecret(strings.TrimPrefix(value, EnvSecretPrefix))
+	case strings.HasPrefix(value, VaultSecretPrefix):
+		return vaultSecret(strings.TrimPrefix(value, VaultSecretPrefix))
+	default:
+		return value, nil
+	}
+}
+
+func fileSecret(path string) (string, error) {
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+func envSecret(name string) (string, error) {
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("secret env variable %s not set", name)
+	}
+	return secret, nil
+}
+
+func vaultSecret(reference string) (string, error) {
+	path, field, found := strings.Cut(reference, "#")
+	if !found || field == "" {
+		return "", fmt.Errorf("vault secret reference %s missing field", reference)
+	}
+
+	vaultAddr := os.Getenv("VAULT_ADDR")
+	if vaultAddr == "" {
+		return "", fmt.Errorf("VAULT_ADDR env variable not set")
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(vaultAddr, "/"), path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", os.Getenv("VAULT_TOKEN"))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to fetch vault secret %s: status code %d", path, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	secretResponse := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &secretResponse)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode vault secret %s", path)
+	}
+
+	data := secretResponse.Data
+	// KV version 2 secrets engine nests secret fields under an additional data key
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+	secret, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s missing field %s", path, field)
+	}
+	return secret, nil
+}
+
+func resolveRelayerSecrets(rawConfig *RawConfig) error {
+	secrets := map[string]*string{
+		"mpc key":                 &rawConfig.RelayerConfig.MpcConfig.Key,
+		"keyshare passphrase":     &rawConfig.RelayerConfig.MpcConfig.KeysharePassphrase,
+		"topology encryption key": &rawConfig.RelayerConfig.MpcConfig.TopologyConfiguration.EncryptionKey,
+		"uploader auth token":     &rawConfig.RelayerConfig.UploaderConfig.AuthToken,
+	}
+	for name, value := range secrets {
+		secret, err := ResolveSecret(*value)
+		if err != nil {
+			return fmt.Errorf("unable to resolve relayer %s: %w", name, err)
+		}
+		*value = secret
+	}
+	return nil
+}
+
+func resolveChainSecrets(chainConfig map[string]interface{}) error {
+	for field, value := range chainConfig {
+		if !isSecretChainField(field) {
+			continue
+		}
+		reference, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		secret, err := ResolveSecret(reference)
+		if err != nil {
+			return fmt.Errorf("unable to resolve '%s' for chain %v: %w", field, chainConfig["id"], err)
+		}
+		chainConfig[field] = secret
+	}
+	return nil
+}
+
+func isSecretChainField(field string) bool {
+	for _, secretField := range secretChainFields {
+		// mapstructure matches field names case insensitively
+		if strings.EqualFold(field, secretField) {
+			return true
+		}
+	}
+	return false
+}
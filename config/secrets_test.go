@@ -0,0 +1,133 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResolveSecretTestSuite struct {
+	suite.Suite
+	vault *httptest.Server
+}
+
+func TestRunResolveSecretTestSuite(t *testing.T) {
+	suite.Run(t, new(ResolveSecretTestSuite))
+}
+
+func (s *ResolveSecretTestSuite) SetupTest() {
+	s.vault = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/relayer":
+			_, _ = w.Write([]byte(`{"data": {"data": {"key": "kv2-secret"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/relayer":
+			_, _ = w.Write([]byte(`{"data": {"key": "kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	_ = os.Setenv("VAULT_ADDR", s.vault.URL)
+	_ = os.Setenv("VAULT_TOKEN", "token")
+}
+
+func (s *ResolveSecretTestSuite) TearDownTest() {
+	s.vault.Close()
+	os.Clearenv()
+}
+
+func (s *ResolveSecretTestSuite) Test_PlainValue() {
+	secret, err := config.ResolveSecret("plain-secret")
+
+	s.Nil(err)
+	s.Equal(secret, "plain-secret")
+}
+
+func (s *ResolveSecretTestSuite) Test_FileSecret() {
+	f, _ := os.CreateTemp("", "secret")
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString("file-secret\n")
+	f.Close()
+
+	secret, err := config.ResolveSecret("file://" + f.Name())
+
+	s.Nil(err)
+	s.Equal(secret, "file-secret")
+}
+
+func (s *ResolveSecretTestSuite) Test_MissingFile() {
+	_, err := config.ResolveSecret("file:///invalid/path")
+
+	s.NotNil(err)
+}
+
+func (s *ResolveSecretTestSuite) Test_EnvSecret() {
+	_ = os.Setenv("RELAYER_SECRET", "env-secret")
+
+	secret, err := config.ResolveSecret("env://RELAYER_SECRET")
+
+	s.Nil(err)
+	s.Equal(secret, "env-secret")
+}
+
+func (s *ResolveSecretTestSuite) Test_MissingEnv() {
+	_, err := config.ResolveSecret("env://RELAYER_SECRET")
+
+	s.NotNil(err)
+}
+
+func (s *ResolveSecretTestSuite) Test_VaultKV2Secret() {
+	secret, err := config.ResolveSecret("vault://secret/data/relayer#key")
+
+	s.Nil(err)
+	s.Equal(secret, "kv2-secret")
+}
+
+func (s *ResolveSecretTestSuite) Test_VaultKV1Secret() {
+	secret, err := config.ResolveSecret("vault://kv/relayer#key")
+
+	s.Nil(err)
+	s.Equal(secret, "kv1-secret")
+}
+
+func (s *ResolveSecretTestSuite) Test_VaultMissingField() {
+	_, err := config.ResolveSecret("vault://kv/relayer#password")
+
+	s.NotNil(err)
+}
+
+func (s *ResolveSecretTestSuite) Test_VaultInvalidToken() {
+	_ = os.Setenv("VAULT_TOKEN", "invalid")
+
+	_, err := config.ResolveSecret("vault://kv/relayer#key")
+
+	s.NotNil(err)
+}
+
+func (s *ResolveSecretTestSuite) Test_ConfigFromENV_ResolvesSecrets() {
+	_ = os.Setenv("SYG_CHAINS", `[{"id": 1, "type": "evm", "key": "env://CHAIN_KEY"}]`)
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_KEY", "vault://secret/data/relayer#key")
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_ENCRYPTIONKEY", "env://ENCRYPTION_KEY")
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_URL", "http://test.com")
+	_ = os.Setenv("SYG_RELAYER_MPCCONFIG_TOPOLOGYCONFIGURATION_PATH", "path")
+	_ = os.Setenv("CHAIN_KEY", "chain-key")
+	_ = os.Setenv("ENCRYPTION_KEY", "encryption-key")
+
+	cnf, err := config.GetConfigFromENV(nil)
+
+	s.Nil(err)
+	s.Equal(cnf.RelayerConfig.MpcConfig.Key, "kv2-secret")
+	s.Equal(cnf.RelayerConfig.MpcConfig.TopologyConfiguration.EncryptionKey, "encryption-key")
+	s.Equal(cnf.ChainConfigs[0]["key"], "chain-key")
+}
@@ -0,0 +1,180 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	btcConnection "github.com/ChainSafe/sygma-relayer/chains/btc/connection"
+	"github.com/ChainSafe/sygma-relayer/chains/evm"
+	"github.com/ChainSafe/sygma-relayer/chains/evm/listener/depositHandlers"
+	"github.com/ChainSafe/sygma-relayer/chains/substrate"
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
+)
+
+var connectivityTimeout = 10 * time.Second
+
+// Validate builds relayer and domain configurations the same way the app does and
+// returns every problem found. If checkConnectivity is set, domain endpoints are
+// also queried to verify they are reachable.
+func Validate(configuration *config.Config, checkConnectivity bool) []error {
+	errs := make([]error, 0)
+	errs = append(errs, validateRelayer(configuration)...)
+
+	domainIDs := make(map[uint8]bool)
+	for i, chainConfig := range configuration.ChainConfigs {
+		domainID, domainErrs := validateDomain(chainConfig, checkConnectivity)
+		if domainID == nil {
+			for _, err := range domainErrs {
+				errs = append(errs, fmt.Errorf("domain at index %d: %w", i, err))
+			}
+			continue
+		}
+
+		if domainIDs[*domainID] {
+			domainErrs = append(domainErrs, fmt.Errorf("duplicate domain ID"))
+		}
+		domainIDs[*domainID] = true
+		for _, err := range domainErrs {
+			errs = append(errs, fmt.Errorf("domain %d: %w", *domainID, err))
+		}
+	}
+	return errs
+}
+
+func validateRelayer(configuration *config.Config) []error {
+	errs := make([]error, 0)
+	privBytes, err := crypto.ConfigDecodeKey(configuration.RelayerConfig.MpcConfig.Key)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("relayer: invalid mpc key: %w", err))
+	} else if _, err := crypto.UnmarshalPrivateKey(privBytes); err != nil {
+		errs = append(errs, fmt.Errorf("relayer: invalid mpc key: %w", err))
+	}
+
+	if configuration.RelayerConfig.MpcConfig.KeysharePath == "" {
+		errs = append(errs, fmt.Errorf("relayer: keyshare path not provided"))
+	}
+	if configuration.RelayerConfig.MpcConfig.FrostKeysharePath == "" {
+		errs = append(errs, fmt.Errorf("relayer: frost keyshare path not provided"))
+	}
+	return errs
+}
+
+func validateDomain(chainConfig map[string]interface{}, checkConnectivity bool) (*uint8, []error) {
+	switch chainConfig["type"] {
+	case "evm":
+		return validateEVM(chainConfig, checkConnectivity)
+	case "substrate":
+		return validateSubstrate(chainConfig, checkConnectivity)
+	case "btc":
+		return validateBtc(chainConfig, checkConnectivity)
+	default:
+		return nil, []error{fmt.Errorf("type '%v' not recognized", chainConfig["type"])}
+	}
+}
+
+func validateEVM(chainConfig map[string]interface{}, checkConnectivity bool) (*uint8, []error) {
+	c, err := evm.NewEVMConfig(chainConfig)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	errs := make([]error, 0)
+	if _, err := secp256k1.NewKeypairFromString(c.GeneralChainConfig.Key); err != nil {
+		errs = append(errs, fmt.Errorf("invalid key: %w", err))
+	}
+	if !common.IsHexAddress(c.Bridge) {
+		errs = append(errs, fmt.Errorf("invalid bridge address '%s'", c.Bridge))
+	}
+	if c.Retry != "" && !common.IsHexAddress(c.Retry) {
+		errs = append(errs, fmt.Errorf("invalid retry address '%s'", c.Retry))
+	}
+	if c.FrostKeygen == "" {
+		errs = append(errs, fmt.Errorf("frostKeygen address not provided"))
+	} else if !common.IsHexAddress(c.FrostKeygen) {
+		errs = append(errs, fmt.Errorf("invalid frostKeygen address '%s'", c.FrostKeygen))
+	}
+	for _, handler := range c.Handlers {
+		if !common.IsHexAddress(handler.Address) {
+			errs = append(errs, fmt.Errorf("invalid handler address '%s'", handler.Address))
+		}
+		if _, err := depositHandlers.DepositHandlerForType(handler.Type); err != nil {
+			errs = append(errs, fmt.Errorf("handler %s: %w", handler.Address, err))
+		}
+	}
+
+	if checkConnectivity {
+		if err := checkRPC(c.GeneralChainConfig.Endpoint, "eth_chainId"); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint unreachable: %w", err))
+		}
+	}
+	return c.GeneralChainConfig.Id, errs
+}
+
+func validateSubstrate(chainConfig map[string]interface{}, checkConnectivity bool) (*uint8, []error) {
+	c, err := substrate.NewSubstrateConfig(chainConfig)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	errs := make([]error, 0)
+	if _, err := signature.KeyringPairFromSecret(c.GeneralChainConfig.Key, c.SubstrateNetwork); err != nil {
+		errs = append(errs, fmt.Errorf("invalid key: %w", err))
+	}
+
+	if checkConnectivity {
+		if err := checkRPC(c.GeneralChainConfig.Endpoint, "system_chain"); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint unreachable: %w", err))
+		}
+	}
+	return c.GeneralChainConfig.Id, errs
+}
+
+func validateBtc(chainConfig map[string]interface{}, checkConnectivity bool) (*uint8, []error) {
+	c, err := btcConfig.NewBtcConfig(chainConfig)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	errs := make([]error, 0)
+	resourceIDs := make(map[[32]byte]bool)
+	for _, resource := range c.Resources {
+		if resourceIDs[resource.ResourceID] {
+			errs = append(errs, fmt.Errorf("duplicate resource ID %s", common.Bytes2Hex(resource.ResourceID[:])))
+		}
+		resourceIDs[resource.ResourceID] = true
+	}
+
+	if checkConnectivity {
+		conn, err := btcConnection.NewBtcConnection(c.GeneralChainConfig.Endpoint, c.Username, c.Password, c.GeneralChainConfig.Insecure)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("endpoint unreachable: %w", err))
+		} else {
+			conn.Shutdown()
+		}
+	}
+	return c.GeneralChainConfig.Id, errs
+}
+
+func checkRPC(endpoint string, method string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), connectivityTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var result interface{}
+	return client.CallContext(ctx, &result, method)
+}
@@ -0,0 +1,164 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package validator_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/ChainSafe/sygma-relayer/config/relayer"
+	"github.com/ChainSafe/sygma-relayer/config/validator"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/suite"
+)
+
+func rpcStub() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := make(map[string]interface{})
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		result := map[string]interface{}{
+			"chain":      "regtest",
+			"subversion": "/Satoshi:25.0.0/",
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req["id"],
+			"result":  result,
+		})
+	}))
+}
+
+type ValidateTestSuite struct {
+	suite.Suite
+	relayerConfig relayer.RelayerConfig
+}
+
+func TestRunValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
+
+func (s *ValidateTestSuite) SetupTest() {
+	priv, _, _ := crypto.GenerateKeyPair(crypto.ECDSA, 0)
+	privBytes, _ := crypto.MarshalPrivateKey(priv)
+	s.relayerConfig = relayer.RelayerConfig{
+		MpcConfig: relayer.MpcRelayerConfig{
+			Key:               crypto.ConfigEncodeKey(privBytes),
+			KeysharePath:      "keyshare",
+			FrostKeysharePath: "frost-keyshare",
+		},
+	}
+}
+
+func (s *ValidateTestSuite) evmConfig(id int, endpoint string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"type":        "evm",
+		"name":        "evm",
+		"endpoint":    endpoint,
+		"key":         "cc2c32b154490f09f70c1c8d4b997238448d649e0777495863db231c4ced3616",
+		"bridge":      "0xd606A00c1A39dA53EA7Bb3Ab570BBE40b156EB66",
+		"frostKeygen": "0x3cA3808176Ad060Ad80c4e08F30d85973Ef1d99e",
+		"handlers": []interface{}{
+			map[string]interface{}{
+				"type":    "erc20",
+				"address": "0x75dF75bcdCa8eA2360c562b4aaDBAF3dfAf5b19b",
+			},
+		},
+	}
+}
+
+func (s *ValidateTestSuite) btcConfig(id int, endpoint string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "btc",
+		"name":       "btc",
+		"endpoint":   endpoint,
+		"insecure":   true,
+		"username":   "username",
+		"password":   "password",
+		"network":    "testnet",
+		"feeAddress": "mkHS9ne12qx9pS9VojpwU5xtRd4T7X7ZUt",
+		"resources": []interface{}{
+			map[string]interface{}{
+				"address":    "tb1qln69zuhdunc9stwfh6t7adexxrcr04ppy6thgm",
+				"feeAmount":  "10000000",
+				"resourceID": "0x0000000000000000000000000000000000000000000000000000000000000300",
+				"script":     "51206a698882348433b57d549d6344f74500fcd13ad8d2200cdf89f8e39e5cafa7d5",
+			},
+		},
+	}
+}
+
+func (s *ValidateTestSuite) Test_ValidConfig() {
+	stub := rpcStub()
+	defer stub.Close()
+
+	errs := validator.Validate(&config.Config{
+		RelayerConfig: s.relayerConfig,
+		ChainConfigs: []map[string]interface{}{
+			s.evmConfig(1, stub.URL),
+			s.btcConfig(2, strings.TrimPrefix(stub.URL, "http://")),
+			{
+				"id":       3,
+				"type":     "substrate",
+				"name":     "substrate",
+				"endpoint": stub.URL,
+				"key":      "//Alice",
+			},
+		},
+	}, true)
+
+	s.Equal(len(errs), 0)
+}
+
+func (s *ValidateTestSuite) Test_UnreachableEndpoint() {
+	stub := rpcStub()
+	stub.Close()
+
+	errs := validator.Validate(&config.Config{
+		RelayerConfig: s.relayerConfig,
+		ChainConfigs: []map[string]interface{}{
+			s.evmConfig(1, stub.URL),
+		},
+	}, true)
+
+	s.Equal(len(errs), 1)
+	s.Contains(errs[0].Error(), "domain 1: endpoint unreachable")
+}
+
+func (s *ValidateTestSuite) Test_ReportsAllProblems() {
+	invalidEVM := s.evmConfig(1, "http://localhost")
+	invalidEVM["handlers"] = []interface{}{
+		map[string]interface{}{
+			"type":    "erc9999",
+			"address": "0x75dF75bcdCa8eA2360c562b4aaDBAF3dfAf5b19b",
+		},
+	}
+	delete(invalidEVM, "frostKeygen")
+	invalidBtc := s.btcConfig(2, "localhost")
+	invalidBtc["resources"].([]interface{})[0].(map[string]interface{})["resourceID"] = "0x03"
+	s.relayerConfig.MpcConfig.Key = "invalid"
+
+	errs := validator.Validate(&config.Config{
+		RelayerConfig: s.relayerConfig,
+		ChainConfigs: []map[string]interface{}{
+			invalidEVM,
+			invalidBtc,
+			s.evmConfig(1, "http://localhost"),
+			{"id": 4, "type": "unknown"},
+		},
+	}, false)
+
+	s.Equal(len(errs), 6)
+	s.Contains(errs[0].Error(), "relayer: invalid mpc key")
+	s.Equal(errs[1].Error(), "domain 1: frostKeygen address not provided")
+	s.Equal(errs[2].Error(), "domain 1: handler 0x75dF75bcdCa8eA2360c562b4aaDBAF3dfAf5b19b: unknown handler type erc9999")
+	s.Equal(errs[3].Error(), "domain at index 1: invalid resource ID 0x03: expected 32 bytes")
+	s.Equal(errs[4].Error(), "domain 1: duplicate domain ID")
+	s.Equal(errs[5].Error(), "domain at index 3: type 'unknown' not recognized")
+}
@@ -0,0 +1,92 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ConfigLoader func() (*Config, error)
+
+// Watcher periodically reloads configuration and notifies subscriber
+// when domain configuration changes.
+type Watcher struct {
+	loader   ConfigLoader
+	interval time.Duration
+	checksum [32]byte
+}
+
+// NewWatcher creates a Watcher that compares reloaded configuration
+// against the initially loaded configuration.
+func NewWatcher(loader ConfigLoader, interval time.Duration, initial *Config) (*Watcher, error) {
+	checksum, err := chainConfigsChecksum(initial)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		loader:   loader,
+		interval: interval,
+		checksum: checksum,
+	}, nil
+}
+
+// Watch reloads configuration on every interval and calls onChange with the new
+// configuration if domain configuration changed since the last successful reload.
+func (w *Watcher) Watch(ctx context.Context, onChange func(*Config)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			{
+				changed, config, err := w.reload()
+				if err != nil {
+					log.Warn().Err(err).Msgf("Failed reloading configuration")
+					continue
+				}
+				if !changed {
+					continue
+				}
+
+				log.Info().Msgf("Configuration change detected")
+				onChange(config)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *Watcher) reload() (bool, *Config, error) {
+	config, err := w.loader()
+	if err != nil {
+		return false, nil, err
+	}
+
+	checksum, err := chainConfigsChecksum(config)
+	if err != nil {
+		return false, nil, err
+	}
+	if checksum == w.checksum {
+		return false, config, nil
+	}
+
+	w.checksum = checksum
+	return true, config, nil
+}
+
+func chainConfigsChecksum(config *Config) ([32]byte, error) {
+	b, err := json.Marshal(config.ChainConfigs)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
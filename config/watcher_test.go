@@ -0,0 +1,85 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type WatcherTestSuite struct {
+	suite.Suite
+	initial *config.Config
+}
+
+func TestRunWatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(WatcherTestSuite))
+}
+
+func (s *WatcherTestSuite) SetupTest() {
+	s.initial = &config.Config{
+		ChainConfigs: []map[string]interface{}{{
+			"id":                 1,
+			"type":               "evm",
+			"blockConfirmations": 5,
+		}},
+	}
+}
+
+func (s *WatcherTestSuite) watch(loader config.ConfigLoader) []*config.Config {
+	watcher, err := config.NewWatcher(loader, time.Millisecond, s.initial)
+	s.Nil(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	changes := make([]*config.Config, 0)
+	watcher.Watch(ctx, func(c *config.Config) {
+		changes = append(changes, c)
+	})
+	return changes
+}
+
+func (s *WatcherTestSuite) Test_UnchangedConfig_NoNotification() {
+	changes := s.watch(func() (*config.Config, error) {
+		return &config.Config{
+			ChainConfigs: []map[string]interface{}{{
+				"id":                 1,
+				"type":               "evm",
+				"blockConfirmations": 5,
+			}},
+		}, nil
+	})
+
+	s.Equal(len(changes), 0)
+}
+
+func (s *WatcherTestSuite) Test_LoaderFails_NoNotification() {
+	changes := s.watch(func() (*config.Config, error) {
+		return nil, errors.New("error")
+	})
+
+	s.Equal(len(changes), 0)
+}
+
+func (s *WatcherTestSuite) Test_ChangedConfig_NotifiedOnce() {
+	changed := &config.Config{
+		ChainConfigs: []map[string]interface{}{{
+			"id":                 1,
+			"type":               "evm",
+			"blockConfirmations": 10,
+		}},
+	}
+	changes := s.watch(func() (*config.Config, error) {
+		return changed, nil
+	})
+
+	s.Equal(len(changes), 1)
+	s.Equal(changes[0], changed)
+}
@@ -16,19 +16,21 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/btc"
 	"github.com/ChainSafe/sygma-relayer/chains/btc/mempool"
 	"github.com/ChainSafe/sygma-relayer/chains/btc/uploader"
+	substrateConnection "github.com/ChainSafe/sygma-relayer/chains/substrate/connection"
 	substrateListener "github.com/ChainSafe/sygma-relayer/chains/substrate/listener"
 	substratePallet "github.com/ChainSafe/sygma-relayer/chains/substrate/pallet"
+	substrateTransactor "github.com/ChainSafe/sygma-relayer/chains/substrate/transactor"
 	"github.com/ChainSafe/sygma-relayer/relayer/retry"
 	"github.com/ChainSafe/sygma-relayer/relayer/transfer"
 	propStore "github.com/ChainSafe/sygma-relayer/store"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa"
+	"github.com/ChainSafe/sygma-relayer/tss/reputation"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/listener"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/gas"
 	"github.com/sygmaprotocol/sygma-core/chains/evm/transactor/transaction"
 	coreSubstrate "github.com/sygmaprotocol/sygma-core/chains/substrate"
 	substrateClient "github.com/sygmaprotocol/sygma-core/chains/substrate/client"
-	"github.com/sygmaprotocol/sygma-core/chains/substrate/connection"
-	coreSubstrateListener "github.com/sygmaprotocol/sygma-core/chains/substrate/listener"
 	"github.com/sygmaprotocol/sygma-core/crypto/secp256k1"
 	"github.com/sygmaprotocol/sygma-core/observability"
 	"github.com/sygmaprotocol/sygma-core/relayer"
@@ -52,6 +54,7 @@ import (
 	"github.com/ChainSafe/sygma-relayer/chains/substrate"
 	substrateExecutor "github.com/ChainSafe/sygma-relayer/chains/substrate/executor"
 	"github.com/ChainSafe/sygma-relayer/jobs"
+	"github.com/ChainSafe/sygma-relayer/keycheck"
 	"github.com/ChainSafe/sygma-relayer/metrics"
 	coreEvm "github.com/sygmaprotocol/sygma-core/chains/evm"
 
@@ -88,6 +91,7 @@ func Run() error {
 		panic(err)
 	}
 	blockstore := store.NewBlockStore(db)
+	blockHashStore := propStore.NewBlockHashStore(db)
 
 	privBytes, err := crypto.ConfigDecodeKey(configuration.RelayerConfig.MpcConfig.Key)
 	if err != nil {
@@ -106,7 +110,11 @@ func Run() error {
 
 	communication := p2p.NewCommunication(host, "p2p/sygma")
 	electorFactory := elector.NewCoordinatorElectorFactory(host, configuration.RelayerConfig.BullyConfig)
-	coordinator := tss.NewCoordinator(host, communication, electorFactory)
+	peerReputation, err := reputation.NewReputation(propStore.NewReputationStore(db), configuration.RelayerConfig.MpcConfig.ReputationHalfLife)
+	if err != nil {
+		panic(err)
+	}
+	coordinator := tss.NewCoordinator(host, communication, electorFactory, peerReputation)
 	keyshareStore := keyshare.NewECDSAKeyshareStore(configuration.RelayerConfig.MpcConfig.KeysharePath)
 	frostKeyshareStore := keyshare.NewFrostKeyshareStore(configuration.RelayerConfig.MpcConfig.FrostKeysharePath)
 	propStore := propStore.NewPropStore(db)
@@ -132,7 +140,17 @@ func Run() error {
 	if err != nil {
 		panic(err)
 	}
+	scheduler := tss.NewScheduler(coordinator, configuration.RelayerConfig.MpcConfig.MaxConcurrentSessions, sygmaMetrics)
+	keyMonitor := keycheck.NewKeyMonitor(sygmaMetrics)
+	signingFactory := ecdsa.NewGG18SigningFactory(host, communication, keyshareStore)
 
+	// FROST key is reshared together with the ECDSA key only if it is used to sign for bitcoin domains
+	var frostResharingStorer hubEventHandlers.FrostKeyshareStorer
+	for _, chainConfig := range configuration.ChainConfigs {
+		if chainConfig["type"] == "btc" {
+			frostResharingStorer = frostKeyshareStore
+		}
+	}
 	msgChan := make(chan []*message.Message)
 	domains := make(map[uint8]relayer.RelayedChain)
 	for _, chainConfig := range configuration.ChainConfigs {
@@ -187,9 +205,9 @@ func Run() error {
 
 				depositEventHandler := hubEventHandlers.NewDepositEventHandler(depositListener, depositHandler, bridgeAddress, *config.GeneralChainConfig.Id, msgChan)
 				eventHandlers = append(eventHandlers, depositEventHandler)
-				eventHandlers = append(eventHandlers, hubEventHandlers.NewKeygenEventHandler(l, tssListener, coordinator, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, hubEventHandlers.NewFrostKeygenEventHandler(l, tssListener, coordinator, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
-				eventHandlers = append(eventHandlers, hubEventHandlers.NewRefreshEventHandler(l, nil, nil, tssListener, coordinator, host, communication, connectionGate, keyshareStore, frostKeyshareStore, bridgeAddress))
+				eventHandlers = append(eventHandlers, hubEventHandlers.NewKeygenEventHandler(l, tssListener, scheduler, host, communication, keyshareStore, bridgeAddress, networkTopology.Threshold))
+				eventHandlers = append(eventHandlers, hubEventHandlers.NewFrostKeygenEventHandler(l, tssListener, scheduler, host, communication, frostKeyshareStore, frostAddress, networkTopology.Threshold))
+				eventHandlers = append(eventHandlers, hubEventHandlers.NewRefreshEventHandler(l, nil, nil, tssListener, scheduler, host, communication, connectionGate, keyshareStore, frostResharingStorer, nil, nil, bridgeAddress, nil))
 				eventHandlers = append(eventHandlers, hubEventHandlers.NewRetryV1EventHandler(l, tssListener, depositHandler, propStore, bridgeAddress, *config.GeneralChainConfig.Id, config.BlockConfirmations, msgChan))
 				if config.Retry != "" {
 					eventHandlers = append(eventHandlers, hubEventHandlers.NewRetryV2EventHandler(l, tssListener, common.HexToAddress(config.Retry), *config.GeneralChainConfig.Id, msgChan))
@@ -199,7 +217,8 @@ func Run() error {
 				mh := message.NewMessageHandler()
 				mh.RegisterMessageHandler(retry.RetryMessageType, executor.NewRetryMessageHandler(depositEventHandler, client, propStore, config.BlockConfirmations, msgChan))
 				mh.RegisterMessageHandler(transfer.TransferMessageType, &executor.TransferMessageHandler{})
-				executor := executor.NewExecutor(host, communication, coordinator, bridgeContract, keyshareStore, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				executor := executor.NewExecutor(host, communication, scheduler, bridgeContract, signingFactory, config.GeneralChainConfig.DerivationPath(), keyMonitor, exitLock, config.GasLimit.Uint64(), config.TransferGas)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewECDSAKeyChecker(bridgeContract, signingFactory, config.GeneralChainConfig.DerivationPath()))
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -227,7 +246,7 @@ func Run() error {
 					panic(err)
 				}
 
-				conn, err := connection.NewSubstrateConnection(config.GeneralChainConfig.Endpoint)
+				conn, err := substrateConnection.NewSubstrateConnection(config.GeneralChainConfig.Endpoint)
 				if err != nil {
 					panic(err)
 				}
@@ -236,25 +255,39 @@ func Run() error {
 					panic(err)
 				}
 
-				substrateClient := substrateClient.NewSubstrateClient(conn, &keyPair, config.ChainID, config.Tip)
-				bridgePallet := substratePallet.NewPallet(substrateClient)
+				substrateClient := substrateClient.NewSubstrateClient(conn.Connection, &keyPair, config.ChainID, config.Tip)
+				substrateTransactor := substrateTransactor.NewMonitoredTransactor(*config.GeneralChainConfig.Id, conn, &keyPair, config.Tip, config.MaxTip, config.TipIncreasePercentage, config.ResubmitBlocks)
+				bridgePallet := substratePallet.NewPallet(substrateClient, substrateTransactor, substratePallet.EIP712Domain{
+					Version:           config.BridgeVersion,
+					VerifyingContract: config.VerifyingContract,
+				})
+				err = bridgePallet.LoadDomain()
+				if err != nil {
+					panic(err)
+				}
+				err = bridgePallet.VerifyDomain()
+				if err != nil {
+					panic(err)
+				}
 
 				log.Info().Str("domain", config.String()).Msgf("Registering substrate domain")
 
 				l := log.With().Str("chain", fmt.Sprintf("%v", config.GeneralChainConfig.Name)).Uint8("domainID", *config.GeneralChainConfig.Id)
 				depositHandler := substrateListener.NewSubstrateDepositHandler()
 				depositHandler.RegisterDepositHandler(transfer.FungibleTransfer, substrateListener.FungibleTransferHandler)
-				eventHandlers := make([]coreSubstrateListener.EventHandler, 0)
+				eventHandlers := make([]substrateListener.EventHandler, 0)
+				eventHandlers = append(eventHandlers, substrateListener.NewSystemUpdateEventHandler(conn, bridgePallet.LoadDomain))
 				depositEventHandler := substrateListener.NewFungibleTransferEventHandler(l, *config.GeneralChainConfig.Id, depositHandler, msgChan, conn)
 				eventHandlers = append(eventHandlers, substrateListener.NewRetryEventHandler(l, conn, depositHandler, *config.GeneralChainConfig.Id, msgChan))
 				eventHandlers = append(eventHandlers, depositEventHandler)
-				substrateListener := coreSubstrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
+				substrateListener := substrateListener.NewSubstrateListener(conn, eventHandlers, blockstore, blockHashStore, sygmaMetrics, *config.GeneralChainConfig.Id, config.BlockRetryInterval, config.BlockInterval)
 
 				mh := message.NewMessageHandler()
-				mh.RegisterMessageHandler(transfer.TransferMessageType, &substrateExecutor.SubstrateMessageHandler{})
+				mh.RegisterMessageHandler(transfer.TransferMessageType, substrateExecutor.NewSubstrateMessageHandler(substrateExecutor.NewRecipientValidator(config.AllowedParachains), propStore))
 				mh.RegisterMessageHandler(retry.RetryMessageType, substrateExecutor.NewRetryMessageHandler(depositEventHandler, conn, propStore, msgChan))
 
-				sExecutor := substrateExecutor.NewExecutor(host, communication, coordinator, bridgePallet, keyshareStore, conn, exitLock)
+				sExecutor := substrateExecutor.NewExecutor(host, communication, scheduler, bridgePallet, signingFactory, config.GeneralChainConfig.DerivationPath(), keyMonitor, conn.Connection, exitLock)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewECDSAKeyChecker(bridgePallet, signingFactory, config.GeneralChainConfig.DerivationPath()))
 
 				startBlock, err := blockstore.GetStartBlock(*config.GeneralChainConfig.Id, config.StartBlock, config.GeneralChainConfig.LatestBlock, config.GeneralChainConfig.FreshStart)
 				if err != nil {
@@ -313,14 +346,16 @@ func Run() error {
 					propStore,
 					host,
 					communication,
-					coordinator,
+					scheduler,
 					frostKeyshareStore,
+					keyMonitor,
 					conn,
 					mempool,
 					resources,
 					config.Network,
 					exitLock,
 					uploader)
+				keyMonitor.Register(*config.GeneralChainConfig.Id, keycheck.NewFrostKeyChecker(frostKeyshareStore, executor, config.Network))
 
 				btcChain := btc.NewBtcChain(listener, executor, mh, *config.GeneralChainConfig.Id)
 				domains[*config.GeneralChainConfig.Id] = btcChain
@@ -331,6 +366,9 @@ func Run() error {
 		}
 	}
 
+	// keys are checked before relaying starts so signing is refused for mismatched domains
+	keyMonitor.Check()
+	go keyMonitor.Start(ctx, configuration.RelayerConfig.MpcConfig.KeyCheckInterval)
 	go jobs.StartCommunicationHealthCheckJob(host, configuration.RelayerConfig.MpcConfig.CommHealthCheckInterval, sygmaMetrics)
 	r := relayer.NewRelayer(domains, sygmaMetrics)
 
@@ -4,12 +4,21 @@
 package health
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
+
+	"github.com/ChainSafe/sygma-relayer/keycheck"
 )
 
+// KeyStatus reports results of the last check of local keys against on-chain keys
+type KeyStatus interface {
+	Healthy() bool
+	Results() map[uint8]keycheck.Result
+}
+
 // StartHealthEndpoint starts /health endpoint on provided port that returns ok on invocation
 func StartHealthEndpoint(port uint16) {
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -19,3 +28,19 @@ func StartHealthEndpoint(port uint16) {
 	_ = http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
 	log.Info().Msgf("started /health endpoint on port %d", port)
 }
+
+// RegisterKeyStatusEndpoint adds /health/keys endpoint that returns results of key checks
+// per domain and service unavailable status if any local key doesn't match the on-chain key
+func RegisterKeyStatusEndpoint(keyStatus KeyStatus) {
+	http.HandleFunc("/health/keys", keyStatusHandler(keyStatus))
+}
+
+func keyStatusHandler(keyStatus KeyStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !keyStatus.Healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(keyStatus.Results())
+	}
+}
@@ -0,0 +1,113 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keycheck
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/signing"
+)
+
+type MPCAddressFetcher interface {
+	MPCAddress() (common.Address, error)
+}
+
+type PublicKeyFetcher interface {
+	PublicKey(derivationPath []uint32) (*btcec.PublicKey, error)
+}
+
+// ECDSAKeyChecker checks that the address of the ECDSA key used to sign proposals
+// for the domain matches the MPC address configured on the bridge
+type ECDSAKeyChecker struct {
+	bridge         MPCAddressFetcher
+	signer         PublicKeyFetcher
+	derivationPath []uint32
+}
+
+func NewECDSAKeyChecker(bridge MPCAddressFetcher, signer PublicKeyFetcher, derivationPath []uint32) *ECDSAKeyChecker {
+	return &ECDSAKeyChecker{
+		bridge:         bridge,
+		signer:         signer,
+		derivationPath: derivationPath,
+	}
+}
+
+func (c *ECDSAKeyChecker) CheckKey() error {
+	mpcAddress, err := c.bridge.MPCAddress()
+	if err != nil {
+		return err
+	}
+	if mpcAddress == (common.Address{}) {
+		return fmt.Errorf("MPC address not set on the bridge")
+	}
+
+	publicKey, err := c.signer.PublicKey(c.derivationPath)
+	if err != nil {
+		return err
+	}
+	address := crypto.PubkeyToAddress(*publicKey.ToECDSA())
+	if address != mpcAddress {
+		return &KeyMismatchError{
+			Local:   address.Hex(),
+			OnChain: mpcAddress.Hex(),
+		}
+	}
+	return nil
+}
+
+type ResourceFetcher interface {
+	Resources() map[[32]byte]btcConfig.Resource
+}
+
+// FrostKeyChecker checks that the FROST key tweaked with the tweak
+// of each BTC resource produces the configured resource address
+type FrostKeyChecker struct {
+	fetcher   signing.SaveDataFetcher
+	resources ResourceFetcher
+	network   chaincfg.Params
+}
+
+func NewFrostKeyChecker(fetcher signing.SaveDataFetcher, resources ResourceFetcher, network chaincfg.Params) *FrostKeyChecker {
+	return &FrostKeyChecker{
+		fetcher:   fetcher,
+		resources: resources,
+		network:   network,
+	}
+}
+
+func (c *FrostKeyChecker) CheckKey() error {
+	c.fetcher.LockKeyshare()
+	key, err := c.fetcher.GetKeyshare()
+	c.fetcher.UnlockKeyshare()
+	if err != nil {
+		return err
+	}
+
+	for resourceID, resource := range c.resources.Resources() {
+		publicKey, err := signing.TweakedPublicKey(key, resource.Tweak)
+		if err != nil {
+			return fmt.Errorf("resource %s: %w", hex.EncodeToString(resourceID[:]), err)
+		}
+		address, err := btcutil.NewAddressTaproot(publicKey, &c.network)
+		if err != nil {
+			return fmt.Errorf("resource %s: %w", hex.EncodeToString(resourceID[:]), err)
+		}
+
+		if address.EncodeAddress() != resource.Address.EncodeAddress() {
+			return fmt.Errorf("resource %s: %w", hex.EncodeToString(resourceID[:]), &KeyMismatchError{
+				Local:   address.EncodeAddress(),
+				OnChain: resource.Address.EncodeAddress(),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keycheck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+
+	btcConfig "github.com/ChainSafe/sygma-relayer/chains/btc/config"
+	"github.com/ChainSafe/sygma-relayer/keycheck"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/signing"
+)
+
+type mockBridge struct {
+	address common.Address
+	err     error
+}
+
+func (b *mockBridge) MPCAddress() (common.Address, error) {
+	return b.address, b.err
+}
+
+type mockSigner struct {
+	publicKey *btcec.PublicKey
+}
+
+func (s *mockSigner) PublicKey(derivationPath []uint32) (*btcec.PublicKey, error) {
+	return s.publicKey, nil
+}
+
+type ECDSAKeyCheckerTestSuite struct {
+	suite.Suite
+	signer  *mockSigner
+	address common.Address
+}
+
+func TestRunECDSAKeyCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(ECDSAKeyCheckerTestSuite))
+}
+
+func (s *ECDSAKeyCheckerTestSuite) SetupTest() {
+	privateKey, err := btcec.NewPrivateKey()
+	s.Nil(err)
+	s.signer = &mockSigner{publicKey: privateKey.PubKey()}
+	s.address = crypto.PubkeyToAddress(*privateKey.PubKey().ToECDSA())
+}
+
+func (s *ECDSAKeyCheckerTestSuite) Test_MatchingKey() {
+	checker := keycheck.NewECDSAKeyChecker(&mockBridge{address: s.address}, s.signer, nil)
+
+	s.Nil(checker.CheckKey())
+}
+
+func (s *ECDSAKeyCheckerTestSuite) Test_MismatchedKey() {
+	checker := keycheck.NewECDSAKeyChecker(&mockBridge{address: common.HexToAddress("0x1")}, s.signer, nil)
+
+	err := checker.CheckKey()
+
+	_, ok := err.(*keycheck.KeyMismatchError)
+	s.True(ok)
+}
+
+func (s *ECDSAKeyCheckerTestSuite) Test_MPCAddressNotSet() {
+	checker := keycheck.NewECDSAKeyChecker(&mockBridge{}, s.signer, nil)
+
+	err := checker.CheckKey()
+
+	_, ok := err.(*keycheck.KeyMismatchError)
+	s.NotNil(err)
+	s.False(ok)
+}
+
+func (s *ECDSAKeyCheckerTestSuite) Test_BridgeError() {
+	checker := keycheck.NewECDSAKeyChecker(&mockBridge{err: fmt.Errorf("error")}, s.signer, nil)
+
+	err := checker.CheckKey()
+
+	_, ok := err.(*keycheck.KeyMismatchError)
+	s.NotNil(err)
+	s.False(ok)
+}
+
+type mockResources struct {
+	resources map[[32]byte]btcConfig.Resource
+}
+
+func (r *mockResources) Resources() map[[32]byte]btcConfig.Resource {
+	return r.resources
+}
+
+type FrostKeyCheckerTestSuite struct {
+	suite.Suite
+	fetcher *keyshare.FrostKeyshareStore
+	tweak   string
+	address btcutil.Address
+}
+
+func TestRunFrostKeyCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(FrostKeyCheckerTestSuite))
+}
+
+func (s *FrostKeyCheckerTestSuite) SetupTest() {
+	s.fetcher = keyshare.NewFrostKeyshareStore("../tss/test/keyshares/0-frost.keyshare")
+	s.tweak = "c82aa6ae534bb28aaafeb3660c31d6a52e187d8f05d48bb6bdb9b733a9b42212"
+
+	key, err := s.fetcher.GetKeyshare()
+	s.Nil(err)
+	publicKey, err := signing.TweakedPublicKey(key, s.tweak)
+	s.Nil(err)
+	s.address, err = btcutil.NewAddressTaproot(publicKey, &chaincfg.TestNet3Params)
+	s.Nil(err)
+}
+
+func (s *FrostKeyCheckerTestSuite) Test_MatchingKey() {
+	resources := &mockResources{resources: map[[32]byte]btcConfig.Resource{
+		{1}: {Address: s.address, Tweak: s.tweak},
+	}}
+	checker := keycheck.NewFrostKeyChecker(s.fetcher, resources, chaincfg.TestNet3Params)
+
+	s.Nil(checker.CheckKey())
+}
+
+func (s *FrostKeyCheckerTestSuite) Test_MismatchedTweak() {
+	resources := &mockResources{resources: map[[32]byte]btcConfig.Resource{
+		{1}: {Address: s.address, Tweak: "a82aa6ae534bb28aaafeb3660c31d6a52e187d8f05d48bb6bdb9b733a9b42212"},
+	}}
+	checker := keycheck.NewFrostKeyChecker(s.fetcher, resources, chaincfg.TestNet3Params)
+
+	err := checker.CheckKey()
+
+	var mismatchErr *keycheck.KeyMismatchError
+	s.ErrorAs(err, &mismatchErr)
+	s.Equal(mismatchErr.OnChain, s.address.EncodeAddress())
+}
@@ -0,0 +1,155 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keycheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Status string
+
+const (
+	// StatusUnknown is reported if the key wasn't checked yet or the check failed
+	StatusUnknown  Status = "unknown"
+	StatusMatch    Status = "match"
+	StatusMismatch Status = "mismatch"
+)
+
+// KeyMismatchError is returned by checkers when the local key
+// doesn't match the key configured on-chain
+type KeyMismatchError struct {
+	Local   string
+	OnChain string
+}
+
+func (e *KeyMismatchError) Error() string {
+	return fmt.Sprintf("local key %s doesn't match on-chain key %s", e.Local, e.OnChain)
+}
+
+// Checker compares the local keyshare with the key configured on-chain for a domain
+type Checker interface {
+	CheckKey() error
+}
+
+type KeyStatusMeter interface {
+	TrackKeyStatus(domainID uint8, status string)
+}
+
+type Result struct {
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// KeyMonitor periodically checks that local keyshares match the keys configured
+// on-chain for each domain and keeps results of the last check
+type KeyMonitor struct {
+	checkers map[uint8]Checker
+	metrics  KeyStatusMeter
+
+	lock    sync.RWMutex
+	results map[uint8]Result
+}
+
+func NewKeyMonitor(metrics KeyStatusMeter) *KeyMonitor {
+	return &KeyMonitor{
+		checkers: make(map[uint8]Checker),
+		metrics:  metrics,
+		results:  make(map[uint8]Result),
+	}
+}
+
+// Register adds key checker for the domain. Checkers have
+// to be registered before the monitor is started.
+func (m *KeyMonitor) Register(domainID uint8, checker Checker) {
+	m.checkers[domainID] = checker
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.results[domainID] = Result{Status: StatusUnknown}
+}
+
+// Start checks keys of all registered domains on each interval until the context is cancelled
+func (m *KeyMonitor) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.Check()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check checks keys of all registered domains and stores results
+func (m *KeyMonitor) Check() {
+	for domainID, checker := range m.checkers {
+		result := Result{
+			Status:    StatusMatch,
+			CheckedAt: time.Now(),
+		}
+
+		err := checker.CheckKey()
+		if err != nil {
+			result.Error = err.Error()
+			var mismatchErr *KeyMismatchError
+			if errors.As(err, &mismatchErr) {
+				result.Status = StatusMismatch
+				log.Error().Uint8("domainID", domainID).Err(err).Msgf("Local key doesn't match on-chain key")
+			} else {
+				result.Status = StatusUnknown
+				log.Warn().Uint8("domainID", domainID).Err(err).Msgf("Failed checking local key")
+			}
+		}
+
+		m.lock.Lock()
+		m.results[domainID] = result
+		m.lock.Unlock()
+		m.metrics.TrackKeyStatus(domainID, string(result.Status))
+	}
+}
+
+// VerifyKey returns error if the last check found that the local key
+// doesn't match the on-chain key of the domain
+func (m *KeyMonitor) VerifyKey(domainID uint8) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	result, ok := m.results[domainID]
+	if !ok || result.Status != StatusMismatch {
+		return nil
+	}
+	return fmt.Errorf("refusing to sign for domain %d: %s", domainID, result.Error)
+}
+
+// Results returns results of the last check for each registered domain
+func (m *KeyMonitor) Results() map[uint8]Result {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	results := make(map[uint8]Result, len(m.results))
+	for domainID, result := range m.results {
+		results[domainID] = result
+	}
+	return results
+}
+
+// Healthy returns false if the local key doesn't match the on-chain key of any domain
+func (m *KeyMonitor) Healthy() bool {
+	for _, result := range m.Results() {
+		if result.Status == StatusMismatch {
+			return false
+		}
+	}
+	return true
+}
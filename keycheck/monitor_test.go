@@ -0,0 +1,81 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keycheck_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keycheck"
+)
+
+type mockChecker struct {
+	err error
+}
+
+func (c *mockChecker) CheckKey() error {
+	return c.err
+}
+
+type mockMeter struct {
+	statuses map[uint8]string
+}
+
+func (m *mockMeter) TrackKeyStatus(domainID uint8, status string) {
+	m.statuses[domainID] = status
+}
+
+type KeyMonitorTestSuite struct {
+	suite.Suite
+	meter   *mockMeter
+	monitor *keycheck.KeyMonitor
+}
+
+func TestRunKeyMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyMonitorTestSuite))
+}
+
+func (s *KeyMonitorTestSuite) SetupTest() {
+	s.meter = &mockMeter{statuses: make(map[uint8]string)}
+	s.monitor = keycheck.NewKeyMonitor(s.meter)
+}
+
+func (s *KeyMonitorTestSuite) Test_NotChecked() {
+	s.monitor.Register(1, &mockChecker{})
+
+	s.Nil(s.monitor.VerifyKey(1))
+	s.True(s.monitor.Healthy())
+	s.Equal(s.monitor.Results()[1].Status, keycheck.StatusUnknown)
+}
+
+func (s *KeyMonitorTestSuite) Test_Check() {
+	s.monitor.Register(1, &mockChecker{})
+	s.monitor.Register(2, &mockChecker{err: fmt.Errorf("resource: %w", &keycheck.KeyMismatchError{Local: "local", OnChain: "on-chain"})})
+	s.monitor.Register(3, &mockChecker{err: fmt.Errorf("connection error")})
+
+	s.monitor.Check()
+
+	results := s.monitor.Results()
+	s.Equal(results[1].Status, keycheck.StatusMatch)
+	s.Equal(results[2].Status, keycheck.StatusMismatch)
+	s.Equal(results[2].Error, "resource: local key local doesn't match on-chain key on-chain")
+	s.Equal(results[3].Status, keycheck.StatusUnknown)
+	s.Equal(s.meter.statuses, map[uint8]string{1: "match", 2: "mismatch", 3: "unknown"})
+	s.False(s.monitor.Healthy())
+}
+
+func (s *KeyMonitorTestSuite) Test_VerifyKey() {
+	s.monitor.Register(1, &mockChecker{})
+	s.monitor.Register(2, &mockChecker{err: &keycheck.KeyMismatchError{Local: "local", OnChain: "on-chain"}})
+	s.monitor.Register(3, &mockChecker{err: fmt.Errorf("connection error")})
+
+	s.monitor.Check()
+
+	s.Nil(s.monitor.VerifyKey(1))
+	s.NotNil(s.monitor.VerifyKey(2))
+	s.Nil(s.monitor.VerifyKey(3))
+	s.Nil(s.monitor.VerifyKey(4))
+}
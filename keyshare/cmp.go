@@ -0,0 +1,292 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/cronokirby/saferith"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/arith"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pedersen"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+// CMPKeyshare stores ECDSA key generated by CMP keygen or resharing
+// and treshold and peers from current signing committee
+type CMPKeyshare struct {
+	Key       *cmp.Config
+	Threshold int
+	Peers     []peer.ID
+	// ChainCode is used to derive child keys of the MPC key. It is kept separately
+	// from the CMP config as CMP refresh replaces the chain key of the config.
+	ChainCode []byte
+}
+
+type cmpKeyshareStore struct {
+	Key       []byte
+	Threshold int
+	Peers     []peer.ID
+	ChainCode []byte `json:",omitempty"`
+}
+
+func NewCMPKeyshare(key *cmp.Config, threshold int, peers []peer.ID, chainCode []byte) CMPKeyshare {
+	return CMPKeyshare{
+		Key:       key,
+		Threshold: threshold,
+		Peers:     peers,
+		ChainCode: chainCode,
+	}
+}
+
+// ShareID identifies the keyshare of the party as it changes
+// with each resharing while the public key stays the same
+func (k CMPKeyshare) ShareID() string {
+	shareBytes, err := k.Key.Public[k.Key.ID].ECDSA.MarshalBinary()
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(shareBytes)
+}
+
+func legacyCMPChainCode(key *cmp.Config) []byte {
+	publicKey, err := key.PublicPoint().MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return LegacyChainCode(publicKey)
+}
+
+type CMPKeyshareStore struct {
+	mu        sync.Mutex
+	path      string
+	encrypter Encrypter
+	history   *KeyshareHistory
+}
+
+// NewCMPKeyshareStore creates a store that keeps the keyshare unencrypted
+func NewCMPKeyshareStore(filePath string) *CMPKeyshareStore {
+	return NewEncryptedCMPKeyshareStore(filePath, PlaintextEncrypter{})
+}
+
+// NewEncryptedCMPKeyshareStore creates a store that encrypts the keyshare at rest
+func NewEncryptedCMPKeyshareStore(filePath string, encrypter Encrypter) *CMPKeyshareStore {
+	return &CMPKeyshareStore{
+		path:      filePath,
+		encrypter: encrypter,
+		history:   NewKeyshareHistory(filePath, encrypter),
+	}
+}
+
+// LockKeyshare locks keyshare from reading and writing to
+// prevent keygen or resharing being done in parallel with other
+// tss processes.
+func (ks *CMPKeyshareStore) LockKeyshare() {
+	ks.mu.Lock()
+}
+
+// UnlockKeyshare unlocks keyshare to allow for tss processes to continue
+func (ks *CMPKeyshareStore) UnlockKeyshare() {
+	ks.mu.Unlock()
+}
+
+// StoreKeyshare stores CMP keyshare as a new version in the keyshare history
+// and activates it.
+func (ks *CMPKeyshareStore) StoreKeyshare(keyshare CMPKeyshare) error {
+	version, err := ks.StoreKeyshareVersion(keyshare, Metadata{})
+	if err != nil {
+		return err
+	}
+
+	return ks.ActivateKeyshare(version)
+}
+
+// StoreKeyshareVersion stores CMP keyshare generated by keygen or reshare as a new version
+// in the keyshare history without replacing the active keyshare.
+func (ks *CMPKeyshareStore) StoreKeyshareVersion(keyshare CMPKeyshare, metadata Metadata) (int, error) {
+	err := ks.archiveActiveKeyshare()
+	if err != nil {
+		return 0, err
+	}
+
+	kb, err := marshalCMPKeyshare(keyshare)
+	if err != nil {
+		return 0, err
+	}
+
+	return ks.history.store(kb, cmpMetadata(keyshare, metadata))
+}
+
+// ActivateKeyshare replaces the active keyshare with the keyshare version
+func (ks *CMPKeyshareStore) ActivateKeyshare(version int) error {
+	return ks.history.Activate(version)
+}
+
+// KeyshareExists returns true if there is an active keyshare file, even if
+// the keyshare can't be read
+func (ks *CMPKeyshareStore) KeyshareExists() bool {
+	return ks.history.activeFileExists()
+}
+
+// History returns the keyshare history of the store
+func (ks *CMPKeyshareStore) History() *KeyshareHistory {
+	return ks.history
+}
+
+// archiveActiveKeyshare adds keyshare stored before the history was introduced to the
+// history so it is not lost when a new version is activated
+func (ks *CMPKeyshareStore) archiveActiveKeyshare() error {
+	empty, err := ks.history.isEmpty()
+	if err != nil || !empty || !ks.history.activeFileExists() {
+		return err
+	}
+
+	keyshare, err := ks.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	kb, err := marshalCMPKeyshare(keyshare)
+	if err != nil {
+		return err
+	}
+	version, err := ks.history.store(kb, cmpMetadata(keyshare, Metadata{}))
+	if err != nil {
+		return err
+	}
+	return ks.history.Activate(version)
+}
+
+// GetKeyshare fetches current CMP keyshare from file.
+// Can be a blocking call if keygen or resharing are pending.
+func (ks *CMPKeyshareStore) GetKeyshare() (CMPKeyshare, error) {
+	cStore := cmpKeyshareStore{}
+	k := CMPKeyshare{}
+
+	kb, err := readKeyshareFile(ks.path, ks.encrypter, CMPKeyshareType)
+	if err != nil {
+		return k, err
+	}
+
+	err = json.Unmarshal(kb, &cStore)
+	if err != nil {
+		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
+	}
+	k.Threshold = cStore.Threshold
+	k.Peers = cStore.Peers
+
+	// CMP config derives public share of the party from the secret share when unmarshaled
+	key := cmp.EmptyConfig(curve.Secp256k1{})
+	err = key.UnmarshalBinary(cStore.Key)
+	if err != nil {
+		return k, &IntegrityError{Path: ks.path, Reason: err.Error()}
+	}
+	k.Key = key
+	k.ChainCode = cStore.ChainCode
+	// keyshares migrated before chain codes were stored use chain code calculated from the public key
+	if len(k.ChainCode) == 0 {
+		k.ChainCode = legacyCMPChainCode(key)
+	}
+
+	return k, nil
+}
+
+func marshalCMPKeyshare(keyshare CMPKeyshare) ([]byte, error) {
+	keyBytes, err := keyshare.Key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	kb, err := json.Marshal(&cmpKeyshareStore{
+		Key:       keyBytes,
+		Threshold: keyshare.Threshold,
+		Peers:     keyshare.Peers,
+		ChainCode: keyshare.ChainCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return encodeKeyshare(CMPKeyshareType, cmpMetadata(keyshare, Metadata{}).PublicKey, kb)
+}
+
+func cmpMetadata(keyshare CMPKeyshare, metadata Metadata) Metadata {
+	metadata.Threshold = keyshare.Threshold
+	metadata.Peers = keyshare.Peers
+
+	publicKeyBytes, err := keyshare.Key.PublicPoint().MarshalBinary()
+	if err != nil {
+		return metadata
+	}
+	metadata.PublicKey = hex.EncodeToString(publicKeyBytes)
+	publicKey, err := crypto.DecompressPubkey(publicKeyBytes)
+	if err == nil {
+		metadata.Address = crypto.PubkeyToAddress(*publicKey).Hex()
+	}
+	return metadata
+}
+
+// MigrateECDSAKeyshare converts GG18 keyshare into CMP config that can be used
+// only as input to CMP resharing. GG18 shares are Shamir shares evaluated at
+// party keys created from peer IDs, which are the same points CMP uses for
+// party IDs, so the refreshed CMP key keeps the GG18 public key.
+// Auxiliary Paillier and Pedersen parameters are taken from GG18 keyshare
+// so all parties agree on the refreshed config and are regenerated by resharing.
+func MigrateECDSAKeyshare(keyshare ECDSAKeyshare, self peer.ID) (*cmp.Config, error) {
+	key := keyshare.Key
+	if key.Xi == nil || len(key.Ks) == 0 {
+		return nil, fmt.Errorf("invalid ECDSA keyshare")
+	}
+	if len(key.BigXj) != len(key.Ks) ||
+		len(key.PaillierPKs) != len(key.Ks) ||
+		len(key.NTildej) != len(key.Ks) ||
+		len(key.H1j) != len(key.Ks) ||
+		len(key.H2j) != len(key.Ks) {
+		return nil, fmt.Errorf("invalid ECDSA keyshare public data")
+	}
+
+	group := curve.Secp256k1{}
+	public := make(map[party.ID]*config.Public)
+	for j, k := range key.Ks {
+		id := party.ID(k.Bytes())
+		point := group.NewPoint()
+		err := point.UnmarshalBinary(key.BigXj[j].ToBtcecPubKey().SerializeCompressed())
+		if err != nil {
+			return nil, err
+		}
+
+		pedersenModulus := arith.ModulusFromN(saferith.ModulusFromBytes(key.NTildej[j].Bytes()))
+		public[id] = &config.Public{
+			ECDSA:    point,
+			ElGamal:  point,
+			Paillier: paillier.NewPublicKey(saferith.ModulusFromBytes(key.PaillierPKs[j].N.Bytes())),
+			Pedersen: pedersen.New(
+				pedersenModulus,
+				new(saferith.Nat).SetBytes(key.H1j[j].Bytes()),
+				new(saferith.Nat).SetBytes(key.H2j[j].Bytes()),
+			),
+		}
+	}
+
+	id := party.ID(self.String())
+	if _, ok := public[id]; !ok {
+		return nil, fmt.Errorf("peer %s not part of the ECDSA keyshare", self)
+	}
+
+	return &cmp.Config{
+		Group:     group,
+		ID:        id,
+		Threshold: keyshare.Threshold,
+		ECDSA:     group.NewScalar().SetNat(new(saferith.Nat).SetBytes(key.Xi.Bytes())),
+		ElGamal:   group.NewScalar(),
+		RID:       make([]byte, 32),
+		Public:    public,
+	}, nil
+}
@@ -0,0 +1,105 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+type MigrateECDSAKeyshareTestSuite struct {
+	suite.Suite
+}
+
+func TestRunMigrateECDSAKeyshareTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateECDSAKeyshareTestSuite))
+}
+
+func (s *MigrateECDSAKeyshareTestSuite) peerID(i int) peer.ID {
+	privBytes, err := os.ReadFile(fmt.Sprintf("../tss/test/pks/%d.pk", i))
+	s.Nil(err)
+	priv, err := crypto.UnmarshalPrivateKey(privBytes)
+	s.Nil(err)
+	peerID, err := peer.IDFromPrivateKey(priv)
+	s.Nil(err)
+	return peerID
+}
+
+func (s *MigrateECDSAKeyshareTestSuite) Test_InvalidKeyshare() {
+	_, err := keyshare.MigrateECDSAKeyshare(keyshare.ECDSAKeyshare{}, s.peerID(0))
+
+	s.NotNil(err)
+}
+
+func (s *MigrateECDSAKeyshareTestSuite) Test_PeerNotInKeyshare() {
+	ecdsaKeyshare, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+
+	_, err = keyshare.MigrateECDSAKeyshare(ecdsaKeyshare, peer.ID("invalid"))
+
+	s.NotNil(err)
+}
+
+func (s *MigrateECDSAKeyshareTestSuite) Test_ValidMigration() {
+	for i := 0; i < 3; i++ {
+		ecdsaKeyshare, err := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../tss/test/keyshares/%d.keyshare", i)).GetKeyshare()
+		s.Nil(err)
+
+		config, err := keyshare.MigrateECDSAKeyshare(ecdsaKeyshare, s.peerID(i))
+		s.Nil(err)
+
+		expectedPublicKey := ecdsaKeyshare.Key.ECDSAPub.ToBtcecPubKey().SerializeCompressed()
+		publicKey, err := config.PublicPoint().MarshalBinary()
+		s.Nil(err)
+		s.Equal(publicKey, expectedPublicKey)
+		s.True(config.ECDSA.ActOnBase().Equal(config.Public[config.ID].ECDSA))
+		s.Equal(config.Threshold, ecdsaKeyshare.Threshold)
+		s.Len(config.Public, len(ecdsaKeyshare.Peers))
+		s.Equal(config.Group, curve.Secp256k1{})
+	}
+}
+
+type CMPKeyshareStoreTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestRunCMPKeyshareStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(CMPKeyshareStoreTestSuite))
+}
+
+func (s *CMPKeyshareStoreTestSuite) SetupTest() {
+	s.path = fmt.Sprintf("%s/cmp.keyshare", s.T().TempDir())
+}
+
+func (s *CMPKeyshareStoreTestSuite) Test_LegacyKeyshare_ChainCodeFromPublicKey() {
+	key, err := keyshare.NewCMPKeyshareStore("../tss/test/keyshares/0-cmp.keyshare").GetKeyshare()
+	s.Nil(err)
+
+	publicKey, err := key.Key.PublicPoint().MarshalBinary()
+	s.Nil(err)
+	s.Equal(key.ChainCode, keyshare.LegacyChainCode(publicKey))
+}
+
+func (s *CMPKeyshareStoreTestSuite) Test_StoreAndRetrieveChainCode() {
+	key, err := keyshare.NewCMPKeyshareStore("../tss/test/keyshares/0-cmp.keyshare").GetKeyshare()
+	s.Nil(err)
+	chainCode, err := keyshare.NewChainCode()
+	s.Nil(err)
+	store := keyshare.NewCMPKeyshareStore(s.path)
+
+	err = store.StoreKeyshare(keyshare.NewCMPKeyshare(key.Key, key.Threshold, key.Peers, chainCode))
+	s.Nil(err)
+
+	storedKey, err := store.GetKeyshare()
+	s.Nil(err)
+	s.Equal(storedKey.ChainCode, chainCode)
+}
@@ -4,39 +4,86 @@
 package keyshare
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"os"
 	"sync"
 
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// chainCodeDomain separates chain code hashes from other uses of the public key
+const chainCodeDomain = "sygma-chain-code"
+
+// ChainCodeLength is the length of the BIP32 chain code in bytes
+const ChainCodeLength = 32
+
 // Keyshare stores key received from keygen or resharing
 // and treshold and peers from current signing committee
 type ECDSAKeyshare struct {
 	Key       keygen.LocalPartySaveData
 	Threshold int
 	Peers     []peer.ID
+	// ChainCode is used to derive child keys of the MPC key
+	ChainCode []byte `json:",omitempty"`
 }
 
-func NewECDSAKeyshare(key keygen.LocalPartySaveData, threshold int, peers []peer.ID) ECDSAKeyshare {
+func NewECDSAKeyshare(key keygen.LocalPartySaveData, threshold int, peers []peer.ID, chainCode []byte) ECDSAKeyshare {
 	return ECDSAKeyshare{
 		Key:       key,
 		Threshold: threshold,
 		Peers:     peers,
+		ChainCode: chainCode,
+	}
+}
+
+// NewChainCode generates a random chain code for a new MPC key. Chain code is kept
+// private to the relayers so child keys can't be linked to the MPC key from the public key alone.
+func NewChainCode() ([]byte, error) {
+	chainCode := make([]byte, ChainCodeLength)
+	_, err := rand.Read(chainCode)
+	if err != nil {
+		return nil, err
+	}
+	return chainCode, nil
+}
+
+// LegacyChainCode returns chain code calculated from the compressed public key. It is used only
+// for keyshares generated before chain codes were stored in the keyshare so their derived keys don't change.
+func LegacyChainCode(publicKey []byte) []byte {
+	chainCode := sha256.Sum256(append([]byte(chainCodeDomain), publicKey...))
+	return chainCode[:]
+}
+
+func legacyECDSAChainCode(key keygen.LocalPartySaveData) []byte {
+	if key.ECDSAPub == nil {
+		return nil
 	}
+	return LegacyChainCode(key.ECDSAPub.ToBtcecPubKey().SerializeCompressed())
 }
 
 type ECDSAKeyshareStore struct {
-	mu   sync.Mutex
-	path string
+	mu        sync.Mutex
+	path      string
+	encrypter Encrypter
+	history   *KeyshareHistory
 }
 
+// NewECDSAKeyshareStore creates a store that keeps the keyshare unencrypted
 func NewECDSAKeyshareStore(filePath string) *ECDSAKeyshareStore {
+	return NewEncryptedECDSAKeyshareStore(filePath, PlaintextEncrypter{})
+}
+
+// NewEncryptedECDSAKeyshareStore creates a store that encrypts the keyshare at rest
+func NewEncryptedECDSAKeyshareStore(filePath string, encrypter Encrypter) *ECDSAKeyshareStore {
 	return &ECDSAKeyshareStore{
-		path: filePath,
+		path:      filePath,
+		encrypter: encrypter,
+		history:   NewKeyshareHistory(filePath, encrypter),
 	}
 }
 
@@ -52,22 +99,86 @@ func (ks *ECDSAKeyshareStore) UnlockKeyshare() {
 	ks.mu.Unlock()
 }
 
-// StoreKeyshare stores keyshare generated by keygen or reshare into file and truncates
-// old keyshare.
+// StoreKeyshare stores keyshare as a new version in the keyshare history
+// and activates it.
 func (ks *ECDSAKeyshareStore) StoreKeyshare(keyshare ECDSAKeyshare) error {
-	f, err := os.OpenFile(ks.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	version, err := ks.StoreKeyshareVersion(keyshare, Metadata{})
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	kb, err := json.Marshal(&keyshare)
+	return ks.ActivateKeyshare(version)
+}
+
+// StoreKeyshareVersion stores keyshare generated by keygen or reshare as a new version
+// in the keyshare history without replacing the active keyshare.
+func (ks *ECDSAKeyshareStore) StoreKeyshareVersion(keyshare ECDSAKeyshare, metadata Metadata) (int, error) {
+	err := ks.archiveActiveKeyshare()
 	if err != nil {
+		return 0, err
+	}
+
+	kb, err := marshalECDSAKeyshare(keyshare)
+	if err != nil {
+		return 0, err
+	}
+
+	return ks.history.store(kb, ecdsaMetadata(keyshare, metadata))
+}
+
+// ActivateKeyshare replaces the active keyshare with the keyshare version
+func (ks *ECDSAKeyshareStore) ActivateKeyshare(version int) error {
+	return ks.history.Activate(version)
+}
+
+// ActiveVersion returns the active keyshare version, 0 if no version has been activated
+func (ks *ECDSAKeyshareStore) ActiveVersion() (int, error) {
+	_, active, err := ks.history.Versions()
+	return active, err
+}
+
+// ActiveMetadata returns metadata of the active keyshare version.
+// Empty metadata is returned if no version has been activated.
+func (ks *ECDSAKeyshareStore) ActiveMetadata() (Metadata, error) {
+	version, err := ks.ActiveVersion()
+	if err != nil || version == 0 {
+		return Metadata{}, err
+	}
+	return ks.history.Version(version)
+}
+
+// KeyshareExists returns true if there is an active keyshare file, even if
+// the keyshare can't be read
+func (ks *ECDSAKeyshareStore) KeyshareExists() bool {
+	return ks.history.activeFileExists()
+}
+
+// History returns the keyshare history of the store
+func (ks *ECDSAKeyshareStore) History() *KeyshareHistory {
+	return ks.history
+}
+
+// archiveActiveKeyshare adds keyshare stored before the history was introduced to the
+// history so it is not lost when a new version is activated
+func (ks *ECDSAKeyshareStore) archiveActiveKeyshare() error {
+	empty, err := ks.history.isEmpty()
+	if err != nil || !empty || !ks.history.activeFileExists() {
 		return err
 	}
 
-	_, err = f.Write(kb)
-	return err
+	keyshare, err := ks.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	kb, err := marshalECDSAKeyshare(keyshare)
+	if err != nil {
+		return err
+	}
+	version, err := ks.history.store(kb, ecdsaMetadata(keyshare, Metadata{}))
+	if err != nil {
+		return err
+	}
+	return ks.history.Activate(version)
 }
 
 // GetECDSAKeyshare fetches current keyshare from file.
@@ -75,15 +186,42 @@ func (ks *ECDSAKeyshareStore) StoreKeyshare(keyshare ECDSAKeyshare) error {
 func (ks *ECDSAKeyshareStore) GetKeyshare() (ECDSAKeyshare, error) {
 	k := ECDSAKeyshare{}
 
-	kb, err := os.ReadFile(ks.path)
+	kb, err := readKeyshareFile(ks.path, ks.encrypter, ECDSAKeyshareType)
 	if err != nil {
-		return k, fmt.Errorf("error on reading keyshare file: %s", err)
+		return k, err
 	}
 
 	err = json.Unmarshal(kb, &k)
 	if err != nil {
 		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
 	}
+	err = validateECDSAKey(k.Key)
+	if err != nil {
+		return k, &IntegrityError{Path: ks.path, Reason: err.Error()}
+	}
+	// keyshares generated before key derivation was introduced are stored without chain code
+	if len(k.ChainCode) == 0 {
+		k.ChainCode = legacyECDSAChainCode(k.Key)
+	}
 
 	return k, err
 }
+
+func marshalECDSAKeyshare(keyshare ECDSAKeyshare) ([]byte, error) {
+	kb, err := json.Marshal(&keyshare)
+	if err != nil {
+		return nil, err
+	}
+	return encodeKeyshare(ECDSAKeyshareType, ecdsaMetadata(keyshare, Metadata{}).PublicKey, kb)
+}
+
+func ecdsaMetadata(keyshare ECDSAKeyshare, metadata Metadata) Metadata {
+	metadata.Threshold = keyshare.Threshold
+	metadata.Peers = keyshare.Peers
+	if keyshare.Key.ECDSAPub != nil {
+		publicKey := keyshare.Key.ECDSAPub.ToBtcecPubKey()
+		metadata.PublicKey = hex.EncodeToString(publicKey.SerializeCompressed())
+		metadata.Address = crypto.PubkeyToAddress(*publicKey.ToECDSA()).Hex()
+	}
+	return metadata
+}
@@ -8,7 +8,6 @@ import (
 	"testing"
 
 	"github.com/ChainSafe/sygma-relayer/keyshare"
-	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/suite"
 )
@@ -29,6 +28,7 @@ func (s *ECDSAKeyshareStoreTestSuite) SetupTest() {
 }
 func (s *ECDSAKeyshareStoreTestSuite) TearDownTest() {
 	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
 }
 
 func (s *ECDSAKeyshareStoreTestSuite) Test_RetrieveInvalidFile() {
@@ -41,9 +41,13 @@ func (s *ECDSAKeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
 	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
 	peer2, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
 	peers := []peer.ID{peer1, peer2}
-	keyshare := keyshare.NewECDSAKeyshare(keygen.NewLocalPartySaveData(5), threshold, peers)
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	chainCode, err := keyshare.NewChainCode()
+	s.Nil(err)
+	keyshare := keyshare.NewECDSAKeyshare(key.Key, threshold, peers, chainCode)
 
-	err := s.keyshareStore.StoreKeyshare(keyshare)
+	err = s.keyshareStore.StoreKeyshare(keyshare)
 	s.Nil(err)
 
 	storedKeyshare, err := s.keyshareStore.GetKeyshare()
@@ -51,3 +55,20 @@ func (s *ECDSAKeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
 
 	s.Equal(keyshare, storedKeyshare)
 }
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_LegacyKeyshare_ChainCodeFromPublicKey() {
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+
+	s.Equal(key.ChainCode, keyshare.LegacyChainCode(key.Key.ECDSAPub.ToBtcecPubKey().SerializeCompressed()))
+}
+
+func (s *ECDSAKeyshareStoreTestSuite) Test_NewChainCode_Random() {
+	chainCode, err := keyshare.NewChainCode()
+	s.Nil(err)
+	otherChainCode, err := keyshare.NewChainCode()
+	s.Nil(err)
+
+	s.Len(chainCode, keyshare.ChainCodeLength)
+	s.NotEqual(chainCode, otherChainCode)
+}
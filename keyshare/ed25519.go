@@ -0,0 +1,261 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+)
+
+// Ed25519Keyshare stores Ed25519 key generated by FROST keygen or resharing
+// and treshold and peers from current signing committee
+type Ed25519Keyshare struct {
+	Key       *frost.Config
+	Threshold int
+	Peers     []peer.ID
+}
+
+type ed25519Key struct {
+	ID                 party.ID
+	Threshold          int
+	PrivateShare       []byte
+	PublicKey          []byte
+	ChainKey           []byte
+	VerificationShares map[party.ID][]byte
+}
+
+type ed25519KeyshareStore struct {
+	Key       ed25519Key
+	Threshold int
+	Peers     []peer.ID
+}
+
+func NewEd25519Keyshare(key *frost.Config, threshold int, peers []peer.ID) Ed25519Keyshare {
+	return Ed25519Keyshare{
+		Key:       key,
+		Threshold: threshold,
+		Peers:     peers,
+	}
+}
+
+// PublicKey returns the 32 byte Ed25519 group public key
+func (k Ed25519Keyshare) PublicKey() ([]byte, error) {
+	return k.Key.PublicKey.MarshalBinary()
+}
+
+type Ed25519KeyshareStore struct {
+	mu        sync.Mutex
+	path      string
+	encrypter Encrypter
+	history   *KeyshareHistory
+}
+
+// NewEd25519KeyshareStore creates a store that keeps the keyshare unencrypted
+func NewEd25519KeyshareStore(filePath string) *Ed25519KeyshareStore {
+	return NewEncryptedEd25519KeyshareStore(filePath, PlaintextEncrypter{})
+}
+
+// NewEncryptedEd25519KeyshareStore creates a store that encrypts the keyshare at rest
+func NewEncryptedEd25519KeyshareStore(filePath string, encrypter Encrypter) *Ed25519KeyshareStore {
+	return &Ed25519KeyshareStore{
+		path:      filePath,
+		encrypter: encrypter,
+		history:   NewKeyshareHistory(filePath, encrypter),
+	}
+}
+
+// LockKeyshare locks keyshare from reading and writing to
+// prevent keygen or resharing being done in parallel with other
+// tss processes.
+func (ks *Ed25519KeyshareStore) LockKeyshare() {
+	ks.mu.Lock()
+}
+
+// UnlockKeyshare unlocks keyshare to allow for tss processes to continue
+func (ks *Ed25519KeyshareStore) UnlockKeyshare() {
+	ks.mu.Unlock()
+}
+
+// StoreKeyshare stores ed25519 keyshare as a new version in the keyshare history
+// and activates it.
+func (ks *Ed25519KeyshareStore) StoreKeyshare(keyshare Ed25519Keyshare) error {
+	version, err := ks.StoreKeyshareVersion(keyshare, Metadata{})
+	if err != nil {
+		return err
+	}
+
+	return ks.ActivateKeyshare(version)
+}
+
+// StoreKeyshareVersion stores ed25519 keyshare generated by keygen or reshare as a new version
+// in the keyshare history without replacing the active keyshare.
+func (ks *Ed25519KeyshareStore) StoreKeyshareVersion(keyshare Ed25519Keyshare, metadata Metadata) (int, error) {
+	err := ks.archiveActiveKeyshare()
+	if err != nil {
+		return 0, err
+	}
+
+	kb, err := marshalEd25519Keyshare(keyshare)
+	if err != nil {
+		return 0, err
+	}
+
+	return ks.history.store(kb, ed25519Metadata(keyshare, metadata))
+}
+
+// ActivateKeyshare replaces the active keyshare with the keyshare version
+func (ks *Ed25519KeyshareStore) ActivateKeyshare(version int) error {
+	return ks.history.Activate(version)
+}
+
+// ActiveVersion returns the active keyshare version, 0 if no version has been activated
+func (ks *Ed25519KeyshareStore) ActiveVersion() (int, error) {
+	_, active, err := ks.history.Versions()
+	return active, err
+}
+
+// KeyshareExists returns true if there is an active keyshare file, even if
+// the keyshare can't be read
+func (ks *Ed25519KeyshareStore) KeyshareExists() bool {
+	return ks.history.activeFileExists()
+}
+
+// History returns the keyshare history of the store
+func (ks *Ed25519KeyshareStore) History() *KeyshareHistory {
+	return ks.history
+}
+
+// archiveActiveKeyshare adds keyshare stored before the history was introduced to the
+// history so it is not lost when a new version is activated
+func (ks *Ed25519KeyshareStore) archiveActiveKeyshare() error {
+	empty, err := ks.history.isEmpty()
+	if err != nil || !empty || !ks.history.activeFileExists() {
+		return err
+	}
+
+	keyshare, err := ks.GetKeyshare()
+	if err != nil {
+		return err
+	}
+	kb, err := marshalEd25519Keyshare(keyshare)
+	if err != nil {
+		return err
+	}
+	version, err := ks.history.store(kb, ed25519Metadata(keyshare, Metadata{}))
+	if err != nil {
+		return err
+	}
+	return ks.history.Activate(version)
+}
+
+// GetKeyshare fetches current keyshare from file.
+// Can be a blocking call if keygen or resharing are pending.
+func (ks *Ed25519KeyshareStore) GetKeyshare() (Ed25519Keyshare, error) {
+	eStore := ed25519KeyshareStore{}
+	k := Ed25519Keyshare{}
+
+	kb, err := readKeyshareFile(ks.path, ks.encrypter, Ed25519KeyshareType)
+	if err != nil {
+		return k, err
+	}
+
+	err = json.Unmarshal(kb, &eStore)
+	if err != nil {
+		return k, fmt.Errorf("error on unmarshaling keyshare file: %s", err)
+	}
+	k.Threshold = eStore.Threshold
+	k.Peers = eStore.Peers
+
+	group := ed25519.Curve{}
+	privateShare := group.NewScalar()
+	err = privateShare.UnmarshalBinary(eStore.Key.PrivateShare)
+	if err != nil {
+		return k, err
+	}
+	publicKey := group.NewPoint()
+	err = publicKey.UnmarshalBinary(eStore.Key.PublicKey)
+	if err != nil {
+		return k, err
+	}
+	verificationShares := make(map[party.ID]curve.Point)
+	for id, pointBytes := range eStore.Key.VerificationShares {
+		point := group.NewPoint()
+		err := point.UnmarshalBinary(pointBytes)
+		if err != nil {
+			return k, err
+		}
+		verificationShares[id] = point
+	}
+	err = validateEd25519Key(group, eStore.Key.ID, privateShare, verificationShares, publicKey)
+	if err != nil {
+		return k, &IntegrityError{Path: ks.path, Reason: err.Error()}
+	}
+	k.Key = &frost.Config{
+		ID:                 eStore.Key.ID,
+		Threshold:          eStore.Key.Threshold,
+		PrivateShare:       privateShare,
+		PublicKey:          publicKey,
+		ChainKey:           eStore.Key.ChainKey,
+		VerificationShares: party.NewPointMap(verificationShares),
+	}
+
+	return k, err
+}
+
+func marshalEd25519Keyshare(keyshare Ed25519Keyshare) ([]byte, error) {
+	privateShareBytes, err := keyshare.Key.PrivateShare.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	publicKeyBytes, err := keyshare.Key.PublicKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	verificationShares := make(map[party.ID][]byte)
+	for id, point := range keyshare.Key.VerificationShares.Points {
+		pointBytes, err := point.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		verificationShares[id] = pointBytes
+	}
+	eKey := ed25519Key{
+		ID:                 keyshare.Key.ID,
+		Threshold:          keyshare.Key.Threshold,
+		PrivateShare:       privateShareBytes,
+		PublicKey:          publicKeyBytes,
+		ChainKey:           keyshare.Key.ChainKey,
+		VerificationShares: verificationShares,
+	}
+	eStore := ed25519KeyshareStore{
+		Key:       eKey,
+		Threshold: keyshare.Threshold,
+		Peers:     keyshare.Peers,
+	}
+	kb, err := json.Marshal(&eStore)
+	if err != nil {
+		return nil, err
+	}
+	return encodeKeyshare(Ed25519KeyshareType, hex.EncodeToString(publicKeyBytes), kb)
+}
+
+func ed25519Metadata(keyshare Ed25519Keyshare, metadata Metadata) Metadata {
+	metadata.Threshold = keyshare.Threshold
+	metadata.Peers = keyshare.Peers
+
+	publicKey, err := keyshare.PublicKey()
+	if err == nil {
+		metadata.PublicKey = hex.EncodeToString(publicKey)
+	}
+	return metadata
+}
@@ -0,0 +1,107 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+)
+
+type Ed25519KeyshareStoreTestSuite struct {
+	suite.Suite
+	keyshareStore *keyshare.Ed25519KeyshareStore
+	path          string
+}
+
+func TestRunEd25519KeyshareStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(Ed25519KeyshareStoreTestSuite))
+}
+
+func (s *Ed25519KeyshareStoreTestSuite) SetupTest() {
+	s.path = "ed25519-share.json"
+	s.keyshareStore = keyshare.NewEd25519KeyshareStore(s.path)
+}
+func (s *Ed25519KeyshareStoreTestSuite) TearDownTest() {
+	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
+}
+
+func (s *Ed25519KeyshareStoreTestSuite) Test_RetrieveInvalidFile() {
+	_, err := s.keyshareStore.GetKeyshare()
+	s.NotNil(err)
+}
+
+func (s *Ed25519KeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
+	group := ed25519.Curve{}
+	privateShare := group.NewScalar().SetNat(new(saferith.Nat).SetUint64(12345))
+	publicKey := privateShare.ActOnBase()
+
+	threshold := 3
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	peer2, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	peers := []peer.ID{peer1, peer2}
+
+	key := keyshare.NewEd25519Keyshare(&frost.Config{
+		ID:           party.ID(peer1.Pretty()),
+		Threshold:    1,
+		PrivateShare: privateShare,
+		PublicKey:    publicKey,
+		ChainKey:     []byte{1, 2, 3},
+		VerificationShares: party.NewPointMap(map[party.ID]curve.Point{
+			party.ID(peer1.Pretty()): privateShare.ActOnBase(),
+		}),
+	}, threshold, peers)
+
+	err := s.keyshareStore.StoreKeyshare(key)
+	s.Nil(err)
+
+	storedKeyshare, err := s.keyshareStore.GetKeyshare()
+	s.Nil(err)
+
+	s.Equal(key.Threshold, storedKeyshare.Threshold)
+	s.Equal(key.Peers, storedKeyshare.Peers)
+	s.Equal(key.Key.ID, storedKeyshare.Key.ID)
+	s.Equal(key.Key.ChainKey, storedKeyshare.Key.ChainKey)
+	s.True(key.Key.PrivateShare.Equal(storedKeyshare.Key.PrivateShare))
+	s.True(key.Key.PublicKey.Equal(storedKeyshare.Key.PublicKey))
+	s.True(key.Key.VerificationShares.Points[party.ID(peer1.Pretty())].Equal(
+		storedKeyshare.Key.VerificationShares.Points[party.ID(peer1.Pretty())]),
+	)
+}
+
+func (s *Ed25519KeyshareStoreTestSuite) Test_StoreKeyshareVersion_Metadata() {
+	group := ed25519.Curve{}
+	privateShare := group.NewScalar().SetNat(new(saferith.Nat).SetUint64(12345))
+	publicKey := privateShare.ActOnBase()
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+
+	key := keyshare.NewEd25519Keyshare(&frost.Config{
+		ID:                 party.ID(peer1.Pretty()),
+		Threshold:          1,
+		PrivateShare:       privateShare,
+		PublicKey:          publicKey,
+		VerificationShares: party.NewPointMap(map[party.ID]curve.Point{}),
+	}, 1, []peer.ID{peer1})
+
+	version, err := s.keyshareStore.StoreKeyshareVersion(key, keyshare.Metadata{SessionID: "ed25519-keygen"})
+	s.Nil(err)
+
+	versions, active, err := s.keyshareStore.History().Versions()
+	s.Nil(err)
+	s.Equal(active, 0)
+	s.Equal(len(versions), 1)
+	s.Equal(versions[0].Version, version)
+	s.Equal(versions[0].SessionID, "ed25519-keygen")
+	s.Len(versions[0].PublicKey, 64)
+}
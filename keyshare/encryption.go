@@ -0,0 +1,227 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	encryptionVersion = 1
+
+	PassphraseEncryption = "argon2id-aes256gcm"
+	KMSEncryption        = "kms-aes256gcm"
+
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	keyLength     = 32
+	saltLength    = 16
+)
+
+// Encrypter encrypts keyshares before they are written to disk
+// and decrypts them when they are read.
+type Encrypter interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// KeyManagementService generates data keys that are wrapped with a master key
+// kept outside of the relayer and unwraps them on decryption.
+type KeyManagementService interface {
+	GenerateDataKey() (key []byte, encryptedKey []byte, err error)
+	DecryptDataKey(encryptedKey []byte) ([]byte, error)
+}
+
+// encryptedFile is the on disk format of an encrypted keyshare
+type encryptedFile struct {
+	Version      int
+	Encryption   string
+	Salt         []byte `json:",omitempty"`
+	EncryptedKey []byte `json:",omitempty"`
+	Nonce        []byte
+	Ciphertext   []byte
+}
+
+// IsEncrypted checks if keyshare file content is encrypted
+func IsEncrypted(content []byte) bool {
+	f := encryptedFile{}
+	err := json.Unmarshal(content, &f)
+	return err == nil && f.Encryption != "" && len(f.Ciphertext) > 0
+}
+
+// PlaintextEncrypter stores keyshares unencrypted
+type PlaintextEncrypter struct{}
+
+func (e PlaintextEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	return plaintext, nil
+}
+
+func (e PlaintextEncrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	if IsEncrypted(ciphertext) {
+		return nil, fmt.Errorf("keyshare is encrypted but no encryption configured")
+	}
+	return ciphertext, nil
+}
+
+// PassphraseEncrypter encrypts keyshares with AES-GCM using a key
+// derived from the operator passphrase with Argon2id.
+type PassphraseEncrypter struct {
+	passphrase []byte
+
+	keyLock sync.Mutex
+	salt    []byte
+	key     []byte
+}
+
+func NewPassphraseEncrypter(passphrase string) *PassphraseEncrypter {
+	return &PassphraseEncrypter{
+		passphrase: []byte(passphrase),
+	}
+}
+
+func (e *PassphraseEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	nonce, ciphertext, err := seal(e.deriveKey(salt), plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&encryptedFile{
+		Version:    encryptionVersion,
+		Encryption: PassphraseEncryption,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	})
+}
+
+func (e *PassphraseEncrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	f, err := unmarshalEncryptedFile(ciphertext, PassphraseEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(e.deriveKey(f.Salt), f.Nonce, f.Ciphertext)
+}
+
+// deriveKey derives the encryption key for the salt. The last derived key is cached
+// as derivation is intentionally expensive and keyshares are read on every signing.
+func (e *PassphraseEncrypter) deriveKey(salt []byte) []byte {
+	e.keyLock.Lock()
+	defer e.keyLock.Unlock()
+
+	if e.key != nil && string(e.salt) == string(salt) {
+		return e.key
+	}
+
+	e.salt = salt
+	e.key = argon2.IDKey(e.passphrase, salt, argon2Time, argon2Memory, argon2Threads, keyLength)
+	return e.key
+}
+
+// KMSEncrypter encrypts keyshares with AES-GCM using a data key
+// generated by the key management service. Wrapped data key is stored
+// alongside the encrypted keyshare.
+type KMSEncrypter struct {
+	kms KeyManagementService
+}
+
+func NewKMSEncrypter(kms KeyManagementService) *KMSEncrypter {
+	return &KMSEncrypter{
+		kms: kms,
+	}
+}
+
+func (e *KMSEncrypter) Encrypt(plaintext []byte) ([]byte, error) {
+	key, encryptedKey, err := e.kms.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ciphertext, err := seal(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&encryptedFile{
+		Version:      encryptionVersion,
+		Encryption:   KMSEncryption,
+		EncryptedKey: encryptedKey,
+		Nonce:        nonce,
+		Ciphertext:   ciphertext,
+	})
+}
+
+func (e *KMSEncrypter) Decrypt(ciphertext []byte) ([]byte, error) {
+	f, err := unmarshalEncryptedFile(ciphertext, KMSEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.kms.DecryptDataKey(f.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(key, f.Nonce, f.Ciphertext)
+}
+
+func unmarshalEncryptedFile(content []byte, encryption string) (encryptedFile, error) {
+	f := encryptedFile{}
+	if !IsEncrypted(content) {
+		return f, fmt.Errorf("keyshare is not encrypted, migrate it with keyshare migrate command")
+	}
+
+	_ = json.Unmarshal(content, &f)
+	if f.Version != encryptionVersion {
+		return f, fmt.Errorf("unsupported keyshare encryption version %d", f.Version)
+	}
+	if f.Encryption != encryption {
+		return f, fmt.Errorf("keyshare encrypted with %s, expected %s", f.Encryption, encryption)
+	}
+	return f, nil
+}
+
+func seal(key []byte, plaintext []byte) ([]byte, []byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func open(key []byte, nonce []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt keyshare, invalid passphrase or corrupted file")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,104 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockKMS struct {
+	key []byte
+}
+
+func (m *mockKMS) GenerateDataKey() ([]byte, []byte, error) {
+	return m.key, []byte("wrapped"), nil
+}
+
+func (m *mockKMS) DecryptDataKey(encryptedKey []byte) ([]byte, error) {
+	if string(encryptedKey) != "wrapped" {
+		return nil, errors.New("invalid key")
+	}
+	return m.key, nil
+}
+
+type EncryptedKeyshareStoreTestSuite struct {
+	suite.Suite
+	path     string
+	keyshare keyshare.ECDSAKeyshare
+}
+
+func TestRunEncryptedKeyshareStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(EncryptedKeyshareStoreTestSuite))
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) SetupTest() {
+	s.path = "encrypted-share.json"
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.keyshare = keyshare.NewECDSAKeyshare(key.Key, 3, []peer.ID{peer1}, key.ChainCode)
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) TearDownTest() {
+	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) Test_PassphraseEncryption_StoreAndRetrieve() {
+	store := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase"))
+
+	err := store.StoreKeyshare(s.keyshare)
+	s.Nil(err)
+
+	content, _ := os.ReadFile(s.path)
+	s.True(keyshare.IsEncrypted(content))
+	info, _ := os.Stat(s.path)
+	s.Equal(info.Mode().Perm(), os.FileMode(0600))
+
+	storedKeyshare, err := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase")).GetKeyshare()
+	s.Nil(err)
+	s.Equal(s.keyshare, storedKeyshare)
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) Test_PassphraseEncryption_InvalidPassphrase() {
+	err := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase")).StoreKeyshare(s.keyshare)
+	s.Nil(err)
+
+	_, err = keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("invalid")).GetKeyshare()
+	s.NotNil(err)
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) Test_PassphraseEncryption_PlaintextKeyshare() {
+	err := keyshare.NewECDSAKeyshareStore(s.path).StoreKeyshare(s.keyshare)
+	s.Nil(err)
+
+	_, err = keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase")).GetKeyshare()
+	s.NotNil(err)
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) Test_Plaintext_EncryptedKeyshare() {
+	err := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase")).StoreKeyshare(s.keyshare)
+	s.Nil(err)
+
+	_, err = keyshare.NewECDSAKeyshareStore(s.path).GetKeyshare()
+	s.NotNil(err)
+}
+
+func (s *EncryptedKeyshareStoreTestSuite) Test_KMSEncryption_StoreAndRetrieve() {
+	kms := &mockKMS{key: make([]byte, 32)}
+	store := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewKMSEncrypter(kms))
+
+	err := store.StoreKeyshare(s.keyshare)
+	s.Nil(err)
+
+	storedKeyshare, err := store.GetKeyshare()
+	s.Nil(err)
+	s.Equal(s.keyshare, storedKeyshare)
+}
@@ -0,0 +1,92 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const keyshareFileMode = 0600
+
+// WriteFileAtomic writes content to a temporary file in the same directory and renames
+// it to path once it is synced to disk. Existing file at path is either fully
+// replaced or left untouched.
+func WriteFileAtomic(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, fmt.Sprintf(".%s-*.tmp", filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+
+	err = f.Chmod(keyshareFileMode)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	_, err = f.Write(content)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir persists directory entry changes, such as renames, to disk
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+func writeKeyshareFile(path string, content []byte, encrypter Encrypter) error {
+	encrypted, err := encrypter.Encrypt(content)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, encrypted)
+}
+
+// readKeyshareFile reads and decrypts the keyshare file and verifies the checksum of the keyshare
+func readKeyshareFile(path string, encrypter Encrypter, keyshareType string) ([]byte, error) {
+	content, err := readFile(path, encrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	keyshare, _, err := decodeKeyshare(keyshareType, content)
+	if err != nil {
+		return nil, &IntegrityError{Path: path, Reason: err.Error()}
+	}
+	return keyshare, nil
+}
+
+func readFile(path string, encrypter Encrypter) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error on reading keyshare file: %s", err)
+	}
+	return encrypter.Decrypt(content)
+}
@@ -4,9 +4,9 @@
 package keyshare
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"os"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -46,13 +46,23 @@ func NewFrostKeyshare(key *frost.TaprootConfig, threshold int, peers []peer.ID)
 }
 
 type FrostKeyshareStore struct {
-	mu   sync.Mutex
-	path string
+	mu        sync.Mutex
+	path      string
+	encrypter Encrypter
+	history   *KeyshareHistory
 }
 
+// NewFrostKeyshareStore creates a store that keeps the keyshare unencrypted
 func NewFrostKeyshareStore(filePath string) *FrostKeyshareStore {
+	return NewEncryptedFrostKeyshareStore(filePath, PlaintextEncrypter{})
+}
+
+// NewEncryptedFrostKeyshareStore creates a store that encrypts the keyshare at rest
+func NewEncryptedFrostKeyshareStore(filePath string, encrypter Encrypter) *FrostKeyshareStore {
 	return &FrostKeyshareStore{
-		path: filePath,
+		path:      filePath,
+		encrypter: encrypter,
+		history:   NewKeyshareHistory(filePath, encrypter),
 	}
 }
 
@@ -68,47 +78,76 @@ func (ks *FrostKeyshareStore) UnlockKeyshare() {
 	ks.mu.Unlock()
 }
 
-// StoreFrostKeyshare stores frost keyshare generated by keygen or reshare into file and truncates
-// old keyshare.
+// StoreKeyshare stores frost keyshare as a new version in the keyshare history
+// and activates it.
 func (ks *FrostKeyshareStore) StoreKeyshare(keyshare FrostKeyshare) error {
-	f, err := os.OpenFile(ks.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	version, err := ks.StoreKeyshareVersion(keyshare, Metadata{})
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	privateShareBytes, err := keyshare.Key.PrivateShare.MarshalBinary()
+	return ks.ActivateKeyshare(version)
+}
+
+// StoreKeyshareVersion stores frost keyshare generated by keygen or reshare as a new version
+// in the keyshare history without replacing the active keyshare.
+func (ks *FrostKeyshareStore) StoreKeyshareVersion(keyshare FrostKeyshare, metadata Metadata) (int, error) {
+	err := ks.archiveActiveKeyshare()
 	if err != nil {
-		return err
+		return 0, err
 	}
-	verificationShares := make(map[party.ID][]byte)
-	for id, point := range keyshare.Key.VerificationShares {
-		pointBytes, err := point.MarshalBinary()
-		if err != nil {
-			return err
-		}
-		verificationShares[id] = pointBytes
+
+	kb, err := marshalFrostKeyshare(keyshare)
+	if err != nil {
+		return 0, err
 	}
-	fKey := frostKey{
-		ID:                 keyshare.Key.ID,
-		Threshold:          keyshare.Key.Threshold,
-		PrivateShare:       privateShareBytes,
-		PublicKey:          keyshare.Key.PublicKey,
-		ChainKey:           keyshare.Key.ChainKey,
-		VerificationShares: verificationShares,
+
+	return ks.history.store(kb, frostMetadata(keyshare, metadata))
+}
+
+// ActivateKeyshare replaces the active keyshare with the keyshare version
+func (ks *FrostKeyshareStore) ActivateKeyshare(version int) error {
+	return ks.history.Activate(version)
+}
+
+// ActiveVersion returns the active keyshare version, 0 if no version has been activated
+func (ks *FrostKeyshareStore) ActiveVersion() (int, error) {
+	_, active, err := ks.history.Versions()
+	return active, err
+}
+
+// KeyshareExists returns true if there is an active keyshare file, even if
+// the keyshare can't be read
+func (ks *FrostKeyshareStore) KeyshareExists() bool {
+	return ks.history.activeFileExists()
+}
+
+// History returns the keyshare history of the store
+func (ks *FrostKeyshareStore) History() *KeyshareHistory {
+	return ks.history
+}
+
+// archiveActiveKeyshare adds keyshare stored before the history was introduced to the
+// history so it is not lost when a new version is activated
+func (ks *FrostKeyshareStore) archiveActiveKeyshare() error {
+	empty, err := ks.history.isEmpty()
+	if err != nil || !empty || !ks.history.activeFileExists() {
+		return err
 	}
-	fStore := frostKeyshareStore{
-		Key:       fKey,
-		Threshold: keyshare.Threshold,
-		Peers:     keyshare.Peers,
+
+	keyshare, err := ks.GetKeyshare()
+	if err != nil {
+		return err
 	}
-	kb, err := json.Marshal(&fStore)
+	kb, err := marshalFrostKeyshare(keyshare)
 	if err != nil {
 		return err
 	}
-
-	_, err = f.Write(kb)
-	return err
+	version, err := ks.history.store(kb, frostMetadata(keyshare, Metadata{}))
+	if err != nil {
+		return err
+	}
+	return ks.history.Activate(version)
 }
 
 // GetFrostKeyshare fetches current keyshare from file.
@@ -117,9 +156,9 @@ func (ks *FrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
 	fStore := frostKeyshareStore{}
 	k := FrostKeyshare{}
 
-	kb, err := os.ReadFile(ks.path)
+	kb, err := readKeyshareFile(ks.path, ks.encrypter, FrostKeyshareType)
 	if err != nil {
-		return k, fmt.Errorf("error on reading keyshare file: %s", err)
+		return k, err
 	}
 
 	err = json.Unmarshal(kb, &fStore)
@@ -135,6 +174,7 @@ func (ks *FrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
 		return k, err
 	}
 	verificationShares := make(map[party.ID]*curve.Secp256k1Point)
+	verificationPoints := make(map[party.ID]curve.Point)
 	for id, pointBytes := range fStore.Key.VerificationShares {
 		point := &curve.Secp256k1Point{}
 		err := point.UnmarshalBinary(pointBytes)
@@ -142,6 +182,11 @@ func (ks *FrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
 			return k, err
 		}
 		verificationShares[id] = point
+		verificationPoints[id] = point
+	}
+	err = validateTaprootKey(fStore.Key.ID, privateShare, verificationPoints, fStore.Key.PublicKey)
+	if err != nil {
+		return k, &IntegrityError{Path: ks.path, Reason: err.Error()}
 	}
 	key := &frost.TaprootConfig{
 		ID:                 fStore.Key.ID,
@@ -155,3 +200,43 @@ func (ks *FrostKeyshareStore) GetKeyshare() (FrostKeyshare, error) {
 
 	return k, err
 }
+
+func marshalFrostKeyshare(keyshare FrostKeyshare) ([]byte, error) {
+	privateShareBytes, err := keyshare.Key.PrivateShare.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	verificationShares := make(map[party.ID][]byte)
+	for id, point := range keyshare.Key.VerificationShares {
+		pointBytes, err := point.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		verificationShares[id] = pointBytes
+	}
+	fKey := frostKey{
+		ID:                 keyshare.Key.ID,
+		Threshold:          keyshare.Key.Threshold,
+		PrivateShare:       privateShareBytes,
+		PublicKey:          keyshare.Key.PublicKey,
+		ChainKey:           keyshare.Key.ChainKey,
+		VerificationShares: verificationShares,
+	}
+	fStore := frostKeyshareStore{
+		Key:       fKey,
+		Threshold: keyshare.Threshold,
+		Peers:     keyshare.Peers,
+	}
+	kb, err := json.Marshal(&fStore)
+	if err != nil {
+		return nil, err
+	}
+	return encodeKeyshare(FrostKeyshareType, hex.EncodeToString(keyshare.Key.PublicKey), kb)
+}
+
+func frostMetadata(keyshare FrostKeyshare, metadata Metadata) Metadata {
+	metadata.Threshold = keyshare.Threshold
+	metadata.Peers = keyshare.Peers
+	metadata.PublicKey = hex.EncodeToString(keyshare.Key.PublicKey)
+	return metadata
+}
@@ -33,6 +33,7 @@ func (s *FrostKeyshareStoreTestSuite) SetupTest() {
 }
 func (s *FrostKeyshareStoreTestSuite) TearDownTest() {
 	os.Remove(s.path)
+	os.RemoveAll(s.path + ".history")
 }
 
 func (s *FrostKeyshareStoreTestSuite) Test_RetrieveInvalidFile() {
@@ -45,23 +46,21 @@ func (s *FrostKeyshareStoreTestSuite) Test_StoreAndRetrieveShare() {
 	privateShareBytes, _ := base64.StdEncoding.DecodeString("hpUx9M/dN7lAF20Jum3/4sgmfty5W4VNeGoEEB18870=")
 	_ = privateShare.UnmarshalBinary(privateShareBytes)
 
-	verificationShares := make(map[party.ID]*curve.Secp256k1Point)
-	point := &curve.Secp256k1Point{}
-	pointBytes, _ := base64.StdEncoding.DecodeString("Au1e9fpMRj99yTydAjKGGa9H7m/jEpEvySUmTu1xYR9h")
-	_ = point.UnmarshalBinary(pointBytes)
-	verificationShares[party.ID("1")] = point
-
 	threshold := 3
 	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
 	peer2, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
 	peers := []peer.ID{peer1, peer2}
 
+	verificationShares := make(map[party.ID]*curve.Secp256k1Point)
+	point := privateShare.ActOnBase().(*curve.Secp256k1Point)
+	verificationShares[party.ID(peer1.Pretty())] = point
+
 	keyshare := keyshare.NewFrostKeyshare(&frost.TaprootConfig{
 		ID:                 party.ID(peer1.Pretty()),
 		Threshold:          1,
 		PrivateShare:       privateShare,
 		VerificationShares: verificationShares,
-		PublicKey:          taproot.PublicKey{},
+		PublicKey:          taproot.PublicKey(point.XBytes()),
 		ChainKey:           []byte{},
 	}, threshold, peers)
 
@@ -0,0 +1,231 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	historyDirSuffix = ".history"
+	historyIndexFile = "index.json"
+	historyDirMode   = 0700
+	// historyRetention is the number of the latest keyshare versions kept in the
+	// history, the active version is kept even if it is older
+	historyRetention = 3
+)
+
+// Metadata describes a stored keyshare version. It contains only
+// public keyshare parts and is stored unencrypted.
+type Metadata struct {
+	Version      int
+	PublicKey    string
+	Address      string `json:",omitempty"`
+	Threshold    int
+	Peers        []peer.ID
+	SessionID    string
+	TopologyHash string `json:",omitempty"`
+	Timestamp    time.Time
+}
+
+type historyIndex struct {
+	Active   int
+	Versions []Metadata
+}
+
+// KeyshareHistory keeps keyshares generated by keygen or resharing in a history
+// directory next to the active keyshare file. Versions are stored with the same
+// encryption as the active keyshare and have to be explicitly activated to replace it.
+// Only the latest versions and the active version are retained.
+type KeyshareHistory struct {
+	mu         sync.Mutex
+	activePath string
+	dir        string
+	encrypter  Encrypter
+}
+
+// NewKeyshareHistory creates history for the keyshare stored at path
+func NewKeyshareHistory(path string, encrypter Encrypter) *KeyshareHistory {
+	return &KeyshareHistory{
+		activePath: path,
+		dir:        path + historyDirSuffix,
+		encrypter:  encrypter,
+	}
+}
+
+// Versions returns metadata of all stored keyshare versions and the active version.
+// Active version is 0 if no version has been activated.
+func (h *KeyshareHistory) Versions() ([]Metadata, int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	index, err := h.readIndex()
+	if err != nil {
+		return nil, 0, err
+	}
+	return index.Versions, index.Active, nil
+}
+
+// Version returns metadata of the keyshare version
+func (h *KeyshareHistory) Version(version int) (Metadata, error) {
+	versions, _, err := h.Versions()
+	if err != nil {
+		return Metadata{}, err
+	}
+	for _, metadata := range versions {
+		if metadata.Version == version {
+			return metadata, nil
+		}
+	}
+	return Metadata{}, fmt.Errorf("keyshare version %d not found", version)
+}
+
+// Activate replaces the active keyshare with the stored keyshare version
+func (h *KeyshareHistory) Activate(version int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	index, err := h.readIndex()
+	if err != nil {
+		return err
+	}
+	if !index.contains(version) {
+		return fmt.Errorf("keyshare version %d not found", version)
+	}
+
+	content, err := os.ReadFile(h.VersionPath(version))
+	if err != nil {
+		return fmt.Errorf("error on reading keyshare version %d: %s", version, err)
+	}
+	err = WriteFileAtomic(h.activePath, content)
+	if err != nil {
+		return err
+	}
+
+	index.Active = version
+	return h.writeIndex(index)
+}
+
+// store saves keyshare content as a new version without activating it
+func (h *KeyshareHistory) store(content []byte, metadata Metadata) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	index, err := h.readIndex()
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(h.dir, historyDirMode)
+	if err != nil {
+		return 0, err
+	}
+
+	metadata.Version = index.latest() + 1
+	metadata.Timestamp = time.Now().UTC()
+	err = writeKeyshareFile(h.VersionPath(metadata.Version), content, h.encrypter)
+	if err != nil {
+		return 0, err
+	}
+
+	index.Versions = append(index.Versions, metadata)
+	index, err = h.prune(index)
+	if err != nil {
+		return 0, err
+	}
+	return metadata.Version, h.writeIndex(index)
+}
+
+// prune deletes versions superseded by the latest versions so that shares
+// replaced by resharing don't stay on disk indefinitely
+func (h *KeyshareHistory) prune(index historyIndex) (historyIndex, error) {
+	if len(index.Versions) <= historyRetention {
+		return index, nil
+	}
+
+	oldest := index.Versions[len(index.Versions)-historyRetention].Version
+	versions := make([]Metadata, 0, historyRetention+1)
+	for _, metadata := range index.Versions {
+		if metadata.Version >= oldest || metadata.Version == index.Active {
+			versions = append(versions, metadata)
+			continue
+		}
+
+		err := os.Remove(h.VersionPath(metadata.Version))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return index, fmt.Errorf("error on deleting keyshare version %d: %s", metadata.Version, err)
+		}
+	}
+	index.Versions = versions
+	return index, nil
+}
+
+// isEmpty returns true if no keyshare version has been stored
+func (h *KeyshareHistory) isEmpty() (bool, error) {
+	versions, _, err := h.Versions()
+	return len(versions) == 0, err
+}
+
+// activeFileExists checks if there is an active keyshare file, regardless
+// if it can be read
+func (h *KeyshareHistory) activeFileExists() bool {
+	_, err := os.Stat(h.activePath)
+	return err == nil
+}
+
+func (h *KeyshareHistory) readIndex() (historyIndex, error) {
+	index := historyIndex{}
+	content, err := os.ReadFile(filepath.Join(h.dir, historyIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return index, fmt.Errorf("error on reading keyshare history: %s", err)
+	}
+
+	err = json.Unmarshal(content, &index)
+	if err != nil {
+		return index, fmt.Errorf("error on unmarshaling keyshare history: %s", err)
+	}
+	return index, nil
+}
+
+func (h *KeyshareHistory) writeIndex(index historyIndex) error {
+	content, err := json.MarshalIndent(&index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filepath.Join(h.dir, historyIndexFile), content)
+}
+
+// VersionPath returns path of the keyshare version file
+func (h *KeyshareHistory) VersionPath(version int) string {
+	return filepath.Join(h.dir, fmt.Sprintf("%d.keyshare", version))
+}
+
+func (i historyIndex) latest() int {
+	latest := 0
+	for _, metadata := range i.Versions {
+		if metadata.Version > latest {
+			latest = metadata.Version
+		}
+	}
+	return latest
+}
+
+func (i historyIndex) contains(version int) bool {
+	for _, metadata := range i.Versions {
+		if metadata.Version == version {
+			return true
+		}
+	}
+	return false
+}
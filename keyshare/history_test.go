@@ -0,0 +1,178 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyshareHistoryTestSuite struct {
+	suite.Suite
+	path          string
+	keyshareStore *keyshare.ECDSAKeyshareStore
+	oldKeyshare   keyshare.ECDSAKeyshare
+	newKeyshare   keyshare.ECDSAKeyshare
+}
+
+func TestRunKeyshareHistoryTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyshareHistoryTestSuite))
+}
+
+func (s *KeyshareHistoryTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "keyshare")
+	s.keyshareStore = keyshare.NewECDSAKeyshareStore(s.path)
+
+	var err error
+	s.oldKeyshare, err = keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	newKeyshare, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/1.keyshare").GetKeyshare()
+	s.Nil(err)
+	peer1, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.newKeyshare = keyshare.NewECDSAKeyshare(newKeyshare.Key, 2, []peer.ID{peer1}, newKeyshare.ChainCode)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_StoreVersion_DoesNotReplaceActiveKeyshare() {
+	err := s.keyshareStore.StoreKeyshare(s.oldKeyshare)
+	s.Nil(err)
+
+	version, err := s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{
+		SessionID:    "resharing-1",
+		TopologyHash: "hash",
+	})
+	s.Nil(err)
+	s.Equal(version, 2)
+
+	activeKeyshare, err := s.keyshareStore.GetKeyshare()
+	s.Nil(err)
+	s.Equal(activeKeyshare, s.oldKeyshare)
+
+	versions, active, err := s.keyshareStore.History().Versions()
+	s.Nil(err)
+	s.Equal(active, 1)
+	s.Equal(len(versions), 2)
+	s.NotEqual(versions[0].PublicKey, "")
+	s.NotEqual(versions[0].Address, "")
+	s.Equal(versions[1].SessionID, "resharing-1")
+	s.Equal(versions[1].TopologyHash, "hash")
+	s.Equal(versions[1].Threshold, 2)
+	s.Equal(versions[1].Peers, s.newKeyshare.Peers)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_ActivateAndRollback() {
+	err := s.keyshareStore.StoreKeyshare(s.oldKeyshare)
+	s.Nil(err)
+	err = s.keyshareStore.StoreKeyshare(s.newKeyshare)
+	s.Nil(err)
+
+	activeKeyshare, _ := s.keyshareStore.GetKeyshare()
+	s.Equal(activeKeyshare, s.newKeyshare)
+
+	err = keyshare.NewKeyshareHistory(s.path, keyshare.PlaintextEncrypter{}).Activate(1)
+	s.Nil(err)
+
+	activeKeyshare, _ = s.keyshareStore.GetKeyshare()
+	s.Equal(activeKeyshare, s.oldKeyshare)
+	_, active, _ := s.keyshareStore.History().Versions()
+	s.Equal(active, 1)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_ActiveVersion() {
+	active, err := s.keyshareStore.ActiveVersion()
+	s.Nil(err)
+	s.Equal(active, 0)
+
+	err = s.keyshareStore.StoreKeyshare(s.oldKeyshare)
+	s.Nil(err)
+	_, err = s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{})
+	s.Nil(err)
+
+	active, err = s.keyshareStore.ActiveVersion()
+	s.Nil(err)
+	s.Equal(active, 1)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_ActiveMetadata() {
+	metadata, err := s.keyshareStore.ActiveMetadata()
+	s.Nil(err)
+	s.Equal(metadata, keyshare.Metadata{})
+
+	version, err := s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{
+		TopologyHash: "hash",
+	})
+	s.Nil(err)
+	err = s.keyshareStore.ActivateKeyshare(version)
+	s.Nil(err)
+
+	metadata, err = s.keyshareStore.ActiveMetadata()
+	s.Nil(err)
+	s.Equal(metadata.Version, version)
+	s.Equal(metadata.TopologyHash, "hash")
+}
+
+func (s *KeyshareHistoryTestSuite) Test_SupersededVersionsDeleted() {
+	err := s.keyshareStore.StoreKeyshare(s.oldKeyshare)
+	s.Nil(err)
+	for i := 0; i < 4; i++ {
+		_, err = s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{})
+		s.Nil(err)
+	}
+
+	versions, active, err := s.keyshareStore.History().Versions()
+	s.Nil(err)
+	s.Equal(active, 1)
+	s.Equal(len(versions), 4)
+	s.Equal(versions[0].Version, 1)
+	s.Equal(versions[1].Version, 3)
+	_, err = os.Stat(s.keyshareStore.History().VersionPath(2))
+	s.True(os.IsNotExist(err))
+
+	err = s.keyshareStore.ActivateKeyshare(5)
+	s.Nil(err)
+	_, err = s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{})
+	s.Nil(err)
+
+	versions, _, err = s.keyshareStore.History().Versions()
+	s.Nil(err)
+	s.Equal(len(versions), 3)
+	s.Equal(versions[0].Version, 4)
+	_, err = os.Stat(s.keyshareStore.History().VersionPath(1))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *KeyshareHistoryTestSuite) Test_ActivateUnknownVersion() {
+	err := s.keyshareStore.ActivateKeyshare(1)
+
+	s.NotNil(err)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_ExistingKeyshareArchived() {
+	content, _ := os.ReadFile("../tss/test/keyshares/0.keyshare")
+	_ = os.WriteFile(s.path, content, 0600)
+
+	version, err := s.keyshareStore.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{})
+	s.Nil(err)
+	s.Equal(version, 2)
+
+	versions, active, err := s.keyshareStore.History().Versions()
+	s.Nil(err)
+	s.Equal(active, 1)
+	s.Equal(len(versions), 2)
+	s.Equal(versions[0].Threshold, s.oldKeyshare.Threshold)
+}
+
+func (s *KeyshareHistoryTestSuite) Test_VersionsEncrypted() {
+	store := keyshare.NewEncryptedECDSAKeyshareStore(s.path, keyshare.NewPassphraseEncrypter("passphrase"))
+
+	version, err := store.StoreKeyshareVersion(s.newKeyshare, keyshare.Metadata{})
+	s.Nil(err)
+
+	content, _ := os.ReadFile(store.History().VersionPath(version))
+	s.True(keyshare.IsEncrypted(content))
+}
@@ -0,0 +1,238 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/binance-chain/tss-lib/crypto"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+)
+
+const (
+	headerVersion = 1
+
+	ECDSAKeyshareType   = "ecdsa"
+	CMPKeyshareType     = "cmp"
+	FrostKeyshareType   = "frost"
+	Ed25519KeyshareType = "ed25519"
+)
+
+// IntegrityError is returned when a keyshare file is corrupt or
+// the keyshare doesn't match its public parts
+type IntegrityError struct {
+	Path   string
+	Reason string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("keyshare %s failed integrity check: %s", e.Path, e.Reason)
+}
+
+// Header describes the keyshare stored in the file and contains the checksum of the keyshare.
+// Header is stored alongside the keyshare and is encrypted with it.
+type Header struct {
+	Version   int
+	Type      string
+	PublicKey string
+	Checksum  string
+}
+
+// keyshareFile is the format of the keyshare before it is encrypted
+type keyshareFile struct {
+	Header   Header
+	Keyshare json.RawMessage
+}
+
+// encodeKeyshare prepends header with the checksum of the marshaled keyshare
+func encodeKeyshare(keyshareType string, publicKey string, keyshare []byte) ([]byte, error) {
+	return json.Marshal(&keyshareFile{
+		Header: Header{
+			Version:   headerVersion,
+			Type:      keyshareType,
+			PublicKey: publicKey,
+			Checksum:  checksum(keyshare),
+		},
+		Keyshare: keyshare,
+	})
+}
+
+// decodeKeyshare verifies the header and returns the marshaled keyshare. Keyshares stored
+// before the header was introduced are returned unchanged with an empty header.
+func decodeKeyshare(keyshareType string, content []byte) ([]byte, Header, error) {
+	fields := make(map[string]json.RawMessage)
+	err := json.Unmarshal(content, &fields)
+	if err != nil {
+		return nil, Header{}, fmt.Errorf("invalid keyshare format")
+	}
+	if !hasField(fields, "Header") {
+		return content, Header{}, nil
+	}
+
+	f := keyshareFile{}
+	err = json.Unmarshal(content, &f)
+	if err != nil {
+		return nil, Header{}, fmt.Errorf("invalid keyshare header")
+	}
+
+	if f.Header.Version != headerVersion {
+		return nil, f.Header, fmt.Errorf("unsupported keyshare header version %d", f.Header.Version)
+	}
+	if f.Header.Type != keyshareType {
+		return nil, f.Header, fmt.Errorf("keyshare type %s, expected %s", f.Header.Type, keyshareType)
+	}
+	if checksum(f.Keyshare) != f.Header.Checksum {
+		return nil, f.Header, fmt.Errorf("checksum mismatch")
+	}
+	return f.Keyshare, f.Header, nil
+}
+
+// hasField returns true if the JSON object contains the field, matching
+// field names case-insensitively like json.Unmarshal does
+func hasField(fields map[string]json.RawMessage, name string) bool {
+	for field := range fields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func checksum(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// ReadHeader reads the header of the keyshare file.
+// Empty header is returned for keyshares stored without a header.
+func ReadHeader(path string, encrypter Encrypter) (Header, error) {
+	content, err := readFile(path, encrypter)
+	if err != nil {
+		return Header{}, err
+	}
+
+	f := keyshareFile{}
+	_ = json.Unmarshal(content, &f)
+	return f.Header, nil
+}
+
+// validateECDSAKey checks that the secret share matches the public share of the
+// party and that public shares of all parties interpolate to the public key
+func validateECDSAKey(key keygen.LocalPartySaveData) error {
+	if key.Xi == nil || key.ShareID == nil || key.ECDSAPub == nil {
+		return fmt.Errorf("missing secret share or public key")
+	}
+	if len(key.Ks) == 0 || len(key.Ks) != len(key.BigXj) {
+		return fmt.Errorf("invalid public shares")
+	}
+
+	index := -1
+	for i, k := range key.Ks {
+		if k == nil || key.BigXj[i] == nil {
+			return fmt.Errorf("invalid public shares")
+		}
+		if k.Cmp(key.ShareID) == 0 {
+			index = i
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("share ID not found in public shares")
+	}
+	if !crypto.ScalarBaseMult(tss.S256(), key.Xi).Equals(key.BigXj[index]) {
+		return fmt.Errorf("secret share doesn't match public share")
+	}
+
+	n := tss.S256().Params().N
+	var publicKey *crypto.ECPoint
+	for j, kj := range key.Ks {
+		coefficient := big.NewInt(1)
+		for m, km := range key.Ks {
+			if m == j {
+				continue
+			}
+			denominator := new(big.Int).Mod(new(big.Int).Sub(km, kj), n)
+			if denominator.Sign() == 0 {
+				return fmt.Errorf("duplicate share ID")
+			}
+			coefficient.Mul(coefficient, km)
+			coefficient.Mul(coefficient, new(big.Int).ModInverse(denominator, n))
+			coefficient.Mod(coefficient, n)
+		}
+
+		term := key.BigXj[j].ScalarMult(coefficient)
+		if publicKey == nil {
+			publicKey = term
+			continue
+		}
+		var err error
+		publicKey, err = publicKey.Add(term)
+		if err != nil {
+			return err
+		}
+	}
+	if !publicKey.Equals(key.ECDSAPub) {
+		return fmt.Errorf("public shares don't match public key")
+	}
+	return nil
+}
+
+// validateFrostKey checks that the private share matches the verification share of the
+// party and that verification shares of all parties interpolate to the group key
+func validateFrostKey(
+	group curve.Curve,
+	id party.ID,
+	privateShare curve.Scalar,
+	verificationShares map[party.ID]curve.Point,
+) (curve.Point, error) {
+	verificationShare, ok := verificationShares[id]
+	if !ok {
+		return nil, fmt.Errorf("verification share of party %s not found", id)
+	}
+	if !privateShare.ActOnBase().Equal(verificationShare) {
+		return nil, fmt.Errorf("private share doesn't match verification share")
+	}
+
+	ids := make([]party.ID, 0, len(verificationShares))
+	for id := range verificationShares {
+		ids = append(ids, id)
+	}
+	publicKey := group.NewPoint()
+	for id, coefficient := range polynomial.Lagrange(group, ids) {
+		publicKey = publicKey.Add(coefficient.Act(verificationShares[id]))
+	}
+	return publicKey, nil
+}
+
+func validateTaprootKey(id party.ID, privateShare curve.Scalar, verificationShares map[party.ID]curve.Point, publicKey taproot.PublicKey) error {
+	groupKey, err := validateFrostKey(curve.Secp256k1{}, id, privateShare, verificationShares)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(groupKey.(*curve.Secp256k1Point).XBytes(), publicKey) {
+		return fmt.Errorf("verification shares don't match public key")
+	}
+	return nil
+}
+
+func validateEd25519Key(group curve.Curve, id party.ID, privateShare curve.Scalar, verificationShares map[party.ID]curve.Point, publicKey curve.Point) error {
+	groupKey, err := validateFrostKey(group, id, privateShare, verificationShares)
+	if err != nil {
+		return err
+	}
+	if !groupKey.Equal(publicKey) {
+		return fmt.Errorf("verification shares don't match public key")
+	}
+	return nil
+}
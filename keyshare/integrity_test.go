@@ -0,0 +1,184 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keyshare_test
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+)
+
+type KeyshareIntegrityTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestRunKeyshareIntegrityTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyshareIntegrityTestSuite))
+}
+
+func (s *KeyshareIntegrityTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "keyshare")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_HeaderStored() {
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	err = keyshare.NewECDSAKeyshareStore(s.path).StoreKeyshare(key)
+	s.Nil(err)
+
+	header, err := keyshare.ReadHeader(s.path, keyshare.PlaintextEncrypter{})
+
+	s.Nil(err)
+	s.Equal(header.Version, 1)
+	s.Equal(header.Type, keyshare.ECDSAKeyshareType)
+	s.NotEqual(header.PublicKey, "")
+	s.NotEqual(header.Checksum, "")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_LegacyKeyshareWithoutHeader() {
+	header, err := keyshare.ReadHeader("../tss/test/keyshares/0.keyshare", keyshare.PlaintextEncrypter{})
+
+	s.Nil(err)
+	s.Equal(header, keyshare.Header{})
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_CorruptKeyshare() {
+	content, err := os.ReadFile("../tss/test/keyshares/0.keyshare")
+	s.Nil(err)
+	_ = os.WriteFile(s.path, content[:len(content)/2], 0600)
+
+	_, err = keyshare.NewECDSAKeyshareStore(s.path).GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "invalid keyshare format")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_CorruptKeyshareExists() {
+	store := keyshare.NewECDSAKeyshareStore(s.path)
+	s.False(store.KeyshareExists())
+
+	_ = os.WriteFile(s.path, []byte("corrupted"), 0600)
+
+	s.True(store.KeyshareExists())
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_HeaderWithoutVersion() {
+	store := keyshare.NewECDSAKeyshareStore(s.path)
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	content, _ := os.ReadFile(s.path)
+	_ = os.WriteFile(s.path, bytes.Replace(content, []byte(`"Version":1`), []byte(`"Version":0`), 1), 0600)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "unsupported keyshare header version 0")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_ChecksumMismatch() {
+	store := keyshare.NewECDSAKeyshareStore(s.path)
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	key.Threshold = 1
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	content, _ := os.ReadFile(s.path)
+	_ = os.WriteFile(s.path, bytes.Replace(content, []byte(`"Threshold":1`), []byte(`"Threshold":2`), 1), 0600)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "checksum mismatch")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_KeyshareTypeMismatch() {
+	key, err := keyshare.NewFrostKeyshareStore("../tss/test/keyshares/0-frost.keyshare").GetKeyshare()
+	s.Nil(err)
+	err = keyshare.NewFrostKeyshareStore(s.path).StoreKeyshare(key)
+	s.Nil(err)
+
+	_, err = keyshare.NewECDSAKeyshareStore(s.path).GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "keyshare type frost, expected ecdsa")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_InvalidECDSAShare() {
+	store := keyshare.NewECDSAKeyshareStore(s.path)
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	key.Key.Xi = new(big.Int).Add(key.Key.Xi, big.NewInt(1))
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "secret share doesn't match public share")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_InvalidECDSAPublicKey() {
+	store := keyshare.NewECDSAKeyshareStore(s.path)
+	key, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	otherKey, err := keyshare.NewECDSAKeyshareStore("../tss/test/keyshares/1.keyshare").GetKeyshare()
+	s.Nil(err)
+	key.Key.ECDSAPub = otherKey.Key.BigXj[0]
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "public shares don't match public key")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_InvalidFrostShare() {
+	store := keyshare.NewFrostKeyshareStore(s.path)
+	key, err := keyshare.NewFrostKeyshareStore("../tss/test/keyshares/0-frost.keyshare").GetKeyshare()
+	s.Nil(err)
+	otherKey, err := keyshare.NewFrostKeyshareStore("../tss/test/keyshares/1-frost.keyshare").GetKeyshare()
+	s.Nil(err)
+	key.Key.PrivateShare = otherKey.Key.PrivateShare
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "private share doesn't match verification share")
+}
+
+func (s *KeyshareIntegrityTestSuite) Test_InvalidFrostPublicKey() {
+	store := keyshare.NewFrostKeyshareStore(s.path)
+	key, err := keyshare.NewFrostKeyshareStore("../tss/test/keyshares/0-frost.keyshare").GetKeyshare()
+	s.Nil(err)
+	key.Key.PublicKey = key.Key.VerificationShares[key.Key.ID].XBytes()
+	err = store.StoreKeyshare(key)
+	s.Nil(err)
+
+	_, err = store.GetKeyshare()
+
+	var integrityErr *keyshare.IntegrityError
+	s.ErrorAs(err, &integrityErr)
+	s.Equal(integrityErr.Reason, "verification shares don't match public key")
+}
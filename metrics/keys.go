@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type KeyMetrics struct {
+	keyStatusGauge api.Int64ObservableGauge
+	keyStatus      map[uint8]string
+	lock           sync.Mutex
+}
+
+// NewKeyMetrics initializes metrics related to checks of local keys against on-chain keys
+func NewKeyMetrics(ctx context.Context, meter metric.Meter, attributes ...attribute.KeyValue) (*KeyMetrics, error) {
+	m := &KeyMetrics{
+		keyStatus: make(map[uint8]string),
+	}
+
+	keyStatusGauge, err := meter.Int64ObservableGauge(
+		"relayer.KeyStatus",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			for domainID, status := range m.keyStatus {
+				result.Observe(1, api.WithAttributes(append(
+					[]attribute.KeyValue{
+						attribute.String("status", status),
+						attribute.Int64("domainID", int64(domainID)),
+					},
+					attributes...)...,
+				))
+			}
+			return nil
+		}),
+		api.WithDescription("Result of the last check of the local key against the on-chain key per domain"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.keyStatusGauge = keyStatusGauge
+	return m, nil
+}
+
+func (m *KeyMetrics) TrackKeyStatus(domainID uint8, status string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.keyStatus[domainID] = status
+}
@@ -15,6 +15,8 @@ type SygmaMetrics struct {
 	*observability.RelayerMetrics
 	*MpcMetrics
 	*HostMetrics
+	*TssMetrics
+	*KeyMetrics
 }
 
 // NewSygmaMetrics creates an instance of metrics
@@ -36,9 +38,21 @@ func NewSygmaMetrics(ctx context.Context, meter api.Meter, env, relayerID, versi
 		return nil, err
 	}
 
+	tssMetrics, err := NewTssMetrics(ctx, meter, attributes...)
+	if err != nil {
+		return nil, err
+	}
+
+	keyMetrics, err := NewKeyMetrics(ctx, meter, attributes...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SygmaMetrics{
 		RelayerMetrics: relayerMetrics,
 		MpcMetrics:     mpcMetrics,
 		HostMetrics:    hostMetrics,
+		TssMetrics:     tssMetrics,
+		KeyMetrics:     keyMetrics,
 	}, nil
 }
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	api "go.opentelemetry.io/otel/metric"
+)
+
+type queueKey struct {
+	priority string
+	domainID uint8
+}
+
+type TssMetrics struct {
+	queueDepthGauge      api.Int64ObservableGauge
+	runningSessionsGauge api.Int64ObservableGauge
+	queueDepth           map[queueKey]int64
+	runningSessions      int64
+	lock                 sync.Mutex
+}
+
+// NewTssMetrics initializes metrics related to scheduling of tss sessions
+func NewTssMetrics(ctx context.Context, meter metric.Meter, attributes ...attribute.KeyValue) (*TssMetrics, error) {
+	m := &TssMetrics{
+		queueDepth: make(map[queueKey]int64),
+	}
+
+	queueDepthGauge, err := meter.Int64ObservableGauge(
+		"relayer.TssQueueDepth",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			for key, depth := range m.queueDepth {
+				result.Observe(depth, api.WithAttributes(append(
+					[]attribute.KeyValue{
+						attribute.String("priority", key.priority),
+						attribute.Int64("domainID", int64(key.domainID)),
+					},
+					attributes...)...,
+				))
+			}
+			return nil
+		}),
+		api.WithDescription("Number of tss sessions waiting to be started per priority and domain"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	runningSessionsGauge, err := meter.Int64ObservableGauge(
+		"relayer.TssRunningSessions",
+		api.WithInt64Callback(func(context context.Context, result api.Int64Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			result.Observe(m.runningSessions, api.WithAttributes(attributes...))
+			return nil
+		}),
+		api.WithDescription("Number of tss sessions currently running"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.queueDepthGauge = queueDepthGauge
+	m.runningSessionsGauge = runningSessionsGauge
+	return m, nil
+}
+
+func (m *TssMetrics) TrackTssQueueDepth(priority string, domainID uint8, depth int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.queueDepth[queueKey{priority: priority, domainID: domainID}] = int64(depth)
+}
+
+func (m *TssMetrics) TrackTssRunningSessions(running int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.runningSessions = int64(running)
+}
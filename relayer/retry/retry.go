@@ -57,6 +57,7 @@ func FilterDeposits(
 				continue
 			}
 
+			transfer.MarkRetry(deposit)
 			filteredDeposits = append(filteredDeposits, deposit)
 		}
 	}
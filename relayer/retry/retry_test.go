@@ -133,6 +133,7 @@ func (s *FilterDepositsTestSuite) Test_FilterDeposits() {
 			Data: transfer.TransferMessageData{
 				DepositNonce: failedNonce,
 				ResourceId:   validResource,
+				Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 			},
 		},
 		{
@@ -141,6 +142,7 @@ func (s *FilterDepositsTestSuite) Test_FilterDeposits() {
 			Data: transfer.TransferMessageData{
 				DepositNonce: pendingNonce,
 				ResourceId:   validResource,
+				Metadata:     map[string]interface{}{transfer.RetryMetadataKey: true},
 			},
 		},
 	}
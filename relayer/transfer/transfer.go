@@ -31,6 +31,31 @@ const (
 	TransferProposalType proposal.ProposalType = "TransferProposal"
 )
 
+// RetryMetadataKey marks transfers that are retried after a retry event
+const RetryMetadataKey = "retry"
+
+// MarkRetry marks the transfer message as retried
+func MarkRetry(msg *message.Message) {
+	data, ok := msg.Data.(TransferMessageData)
+	if !ok {
+		return
+	}
+
+	metadata := make(map[string]interface{})
+	for k, v := range data.Metadata {
+		metadata[k] = v
+	}
+	metadata[RetryMetadataKey] = true
+	data.Metadata = metadata
+	msg.Data = data
+}
+
+// IsRetry returns true if the transfer metadata marks the transfer as retried
+func IsRetry(metadata map[string]interface{}) bool {
+	retry, ok := metadata[RetryMetadataKey].(bool)
+	return ok && retry
+}
+
 type TransferMessage struct {
 	Source      uint8
 	Destination uint8
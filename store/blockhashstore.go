@@ -0,0 +1,67 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	BLOCK_HASH_KEY = "chain:%d:blockHash"
+	hashLength     = 32
+)
+
+type BlockHashStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewBlockHashStore(db store.KeyValueReaderWriter) *BlockHashStore {
+	return &BlockHashStore{
+		db: db,
+	}
+}
+
+// StoreBlockHash stores number and hash of the last processed block per domainID
+func (bs *BlockHashStore) StoreBlockHash(domainID uint8, block *big.Int, hash [32]byte) error {
+	key := bytes.Buffer{}
+	keyS := fmt.Sprintf(BLOCK_HASH_KEY, domainID)
+	key.WriteString(keyS)
+
+	value := append(hash[:], block.Bytes()...)
+	err := bs.db.SetByKey(key.Bytes(), value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LastBlockHash returns number and hash of the last processed block. Returned
+// block is nil if no block hash has been stored for the domain.
+func (bs *BlockHashStore) LastBlockHash(domainID uint8) (*big.Int, [32]byte, error) {
+	key := bytes.Buffer{}
+	keyS := fmt.Sprintf(BLOCK_HASH_KEY, domainID)
+	key.WriteString(keyS)
+
+	var hash [32]byte
+	v, err := bs.db.GetByKey(key.Bytes())
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, hash, nil
+		}
+		return nil, hash, err
+	}
+	if len(v) < hashLength {
+		return nil, hash, fmt.Errorf("invalid stored block hash for domain %d", domainID)
+	}
+
+	copy(hash[:], v[:hashLength])
+	return new(big.Int).SetBytes(v[hashLength:]), hash, nil
+}
@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/stretchr/testify/suite"
+	mock_store "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type BlockHashStoreTestSuite struct {
+	suite.Suite
+	blockHashStore       *store.BlockHashStore
+	keyValueReaderWriter *mock_store.MockKeyValueReaderWriter
+}
+
+func TestRunBlockHashStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockHashStoreTestSuite))
+}
+
+func (s *BlockHashStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock_store.NewMockKeyValueReaderWriter(gomockController)
+	s.blockHashStore = store.NewBlockHashStore(s.keyValueReaderWriter)
+}
+
+func (s *BlockHashStoreTestSuite) Test_StoreBlockHash_FailedStore() {
+	key := "chain:1:blockHash"
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(errors.New("error"))
+
+	err := s.blockHashStore.StoreBlockHash(1, big.NewInt(5), [32]byte{1})
+
+	s.NotNil(err)
+}
+
+func (s *BlockHashStoreTestSuite) Test_LastBlockHash_NotFound() {
+	key := "chain:1:blockHash"
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	block, _, err := s.blockHashStore.LastBlockHash(1)
+
+	s.Nil(err)
+	s.Nil(block)
+}
+
+func (s *BlockHashStoreTestSuite) Test_LastBlockHash_InvalidValue() {
+	key := "chain:1:blockHash"
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return([]byte{1, 2}, nil)
+
+	_, _, err := s.blockHashStore.LastBlockHash(1)
+
+	s.NotNil(err)
+}
+
+func (s *BlockHashStoreTestSuite) Test_LastBlockHash_SuccessfulFetch() {
+	key := "chain:1:blockHash"
+	var stored []byte
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte(key), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		stored = value
+		return nil
+	})
+	err := s.blockHashStore.StoreBlockHash(1, big.NewInt(500), [32]byte{1, 2, 3})
+	s.Nil(err)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte(key)).Return(stored, nil)
+
+	block, hash, err := s.blockHashStore.LastBlockHash(1)
+
+	s.Nil(err)
+	s.Equal(block, big.NewInt(500))
+	s.Equal(hash, [32]byte{1, 2, 3})
+}
@@ -0,0 +1,61 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sygmaprotocol/sygma-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	REPUTATION_KEY = "reputation"
+)
+
+// PeerScore is a peer penalty score at the time of the last update
+type PeerScore struct {
+	Score     float64   `json:"score"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReputationStore struct {
+	db store.KeyValueReaderWriter
+}
+
+func NewReputationStore(db store.KeyValueReaderWriter) *ReputationStore {
+	return &ReputationStore{
+		db: db,
+	}
+}
+
+// StorePeerScores stores penalty scores of all peers keyed by peer ID
+func (rs *ReputationStore) StorePeerScores(scores map[string]PeerScore) error {
+	value, err := json.Marshal(scores)
+	if err != nil {
+		return err
+	}
+
+	return rs.db.SetByKey([]byte(REPUTATION_KEY), value)
+}
+
+// PeerScores returns stored penalty scores of all peers keyed by peer ID
+func (rs *ReputationStore) PeerScores() (map[string]PeerScore, error) {
+	scores := make(map[string]PeerScore)
+	v, err := rs.db.GetByKey([]byte(REPUTATION_KEY))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return scores, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(v, &scores)
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/stretchr/testify/suite"
+	mock_store "github.com/sygmaprotocol/sygma-core/mock"
+	"github.com/syndtr/goleveldb/leveldb"
+	"go.uber.org/mock/gomock"
+)
+
+type ReputationStoreTestSuite struct {
+	suite.Suite
+	reputationStore      *store.ReputationStore
+	keyValueReaderWriter *mock_store.MockKeyValueReaderWriter
+}
+
+func TestRunReputationStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(ReputationStoreTestSuite))
+}
+
+func (s *ReputationStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueReaderWriter = mock_store.NewMockKeyValueReaderWriter(gomockController)
+	s.reputationStore = store.NewReputationStore(s.keyValueReaderWriter)
+}
+
+func (s *ReputationStoreTestSuite) Test_StorePeerScores_FailedStore() {
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("reputation"), gomock.Any()).Return(errors.New("error"))
+
+	err := s.reputationStore.StorePeerScores(map[string]store.PeerScore{"peer": {Score: 1}})
+
+	s.NotNil(err)
+}
+
+func (s *ReputationStoreTestSuite) Test_PeerScores_NotFound() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("reputation")).Return(nil, leveldb.ErrNotFound)
+
+	scores, err := s.reputationStore.PeerScores()
+
+	s.Nil(err)
+	s.Equal(scores, map[string]store.PeerScore{})
+}
+
+func (s *ReputationStoreTestSuite) Test_PeerScores_FailedFetch() {
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("reputation")).Return(nil, errors.New("error"))
+
+	_, err := s.reputationStore.PeerScores()
+
+	s.NotNil(err)
+}
+
+func (s *ReputationStoreTestSuite) Test_PeerScores_SuccessfulFetch() {
+	var stored []byte
+	s.keyValueReaderWriter.EXPECT().SetByKey([]byte("reputation"), gomock.Any()).DoAndReturn(func(key []byte, value []byte) error {
+		stored = value
+		return nil
+	})
+	updatedAt := time.Unix(1700000000, 0).UTC()
+	expectedScores := map[string]store.PeerScore{
+		"peer": {Score: 2.5, UpdatedAt: updatedAt},
+	}
+	err := s.reputationStore.StorePeerScores(expectedScores)
+	s.Nil(err)
+	s.keyValueReaderWriter.EXPECT().GetByKey([]byte("reputation")).Return(stored, nil)
+
+	scores, err := s.reputationStore.PeerScores()
+
+	s.Nil(err)
+	s.Equal(scores, expectedScores)
+}
@@ -0,0 +1,171 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package confirmation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Commitment is a commitment of a party to the public key and peer set
+// generated in a tss session signed with the libp2p key of the party
+type Commitment struct {
+	PublicKey  []byte
+	Commitment []byte
+	Signature  []byte
+}
+
+// Confirmation exchanges signed commitments to the result of a tss process between all
+// parties so the result is stored only if all parties generated the same public key
+// with the same peer set.
+type Confirmation struct {
+	host           host.Host
+	communication  comm.Communication
+	sessionID      string
+	peers          []peer.ID
+	msgChn         chan *comm.WrappedMessage
+	subscriptionID comm.SubscriptionID
+}
+
+func NewConfirmation(
+	host host.Host,
+	communication comm.Communication,
+	sessionID string,
+	peers []peer.ID,
+) *Confirmation {
+	return &Confirmation{
+		host:          host,
+		communication: communication,
+		sessionID:     sessionID,
+		peers:         peers,
+		msgChn:        make(chan *comm.WrappedMessage, len(peers)),
+	}
+}
+
+// Subscribe subscribes to commitments of other parties. It should be called when the
+// tss process starts so commitments of parties that finish first are not missed.
+func (c *Confirmation) Subscribe() {
+	c.subscriptionID = c.communication.Subscribe(c.sessionID, comm.TssConfirmationMsg, c.msgChn)
+}
+
+// UnSubscribe ends the subscription to commitments of other parties
+func (c *Confirmation) UnSubscribe() {
+	c.communication.UnSubscribe(c.subscriptionID)
+}
+
+// Confirm sends the signed commitment to the public key and the peer set to all parties
+// and waits for commitments of all parties. Error is returned if any of the parties
+// committed to a different public key or peer set.
+func (c *Confirmation) Confirm(ctx context.Context, publicKey []byte) error {
+	commitment := c.commitment(publicKey)
+	privateKey := c.host.Peerstore().PrivKey(c.host.ID())
+	signature, err := privateKey.Sign(commitment)
+	if err != nil {
+		return err
+	}
+	hostKey, err := crypto.MarshalPublicKey(privateKey.GetPublic())
+	if err != nil {
+		return err
+	}
+	msgBytes, err := json.Marshal(&Commitment{
+		PublicKey:  hostKey,
+		Commitment: commitment,
+		Signature:  signature,
+	})
+	if err != nil {
+		return err
+	}
+	err = c.communication.Broadcast(c.peers, msgBytes, comm.TssConfirmationMsg, c.sessionID)
+	if err != nil {
+		return err
+	}
+
+	confirmed := make(map[peer.ID]bool)
+	for _, p := range c.peers {
+		if p != c.host.ID() {
+			confirmed[p] = false
+		}
+	}
+	pending := len(confirmed)
+	for pending > 0 {
+		select {
+		case wMsg := <-c.msgChn:
+			{
+				done, ok := confirmed[wMsg.From]
+				if !ok || done {
+					continue
+				}
+
+				err := verifyCommitment(wMsg, commitment)
+				if err != nil {
+					return err
+				}
+				confirmed[wMsg.From] = true
+				pending--
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("confirmation of session %s not finished", c.sessionID)
+		}
+	}
+	return nil
+}
+
+// commitment hashes the session ID, public key and sorted peer set
+func (c *Confirmation) commitment(publicKey []byte) []byte {
+	peers := make([]string, len(c.peers))
+	for i, p := range c.peers {
+		peers[i] = p.Pretty()
+	}
+	sort.Strings(peers)
+
+	hash := sha256.New()
+	hash.Write([]byte(c.sessionID))
+	hash.Write(publicKey)
+	for _, p := range peers {
+		hash.Write([]byte(p))
+	}
+	return hash.Sum(nil)
+}
+
+// verifyCommitment checks that the commitment is signed by the sender and matches the expected commitment
+func verifyCommitment(wMsg *comm.WrappedMessage, expected []byte) error {
+	msg := &Commitment{}
+	err := json.Unmarshal(wMsg.Payload, msg)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := crypto.UnmarshalPublicKey(msg.PublicKey)
+	if err != nil {
+		return err
+	}
+	id, err := peer.IDFromPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	if id != wMsg.From {
+		return fmt.Errorf("commitment of peer %s signed by %s", wMsg.From, id)
+	}
+	valid, err := publicKey.Verify(msg.Commitment, msg.Signature)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("invalid commitment signature of peer %s", wMsg.From)
+	}
+
+	if !bytes.Equal(msg.Commitment, expected) {
+		return fmt.Errorf("peer %s committed to a different public key or peer set", wMsg.From)
+	}
+	return nil
+}
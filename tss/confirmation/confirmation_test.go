@@ -0,0 +1,98 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package confirmation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+)
+
+type ConfirmationTestSuite struct {
+	suite.Suite
+	hosts         []host.Host
+	confirmations []*confirmation.Confirmation
+}
+
+func TestRunConfirmationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfirmationTestSuite))
+}
+
+func (s *ConfirmationTestSuite) SetupTest() {
+	s.hosts = []host.Host{}
+	for i := 0; i < 3; i++ {
+		host, err := tsstest.NewHost(i)
+		s.Nil(err)
+		s.hosts = append(s.hosts, host)
+	}
+	for _, host := range s.hosts {
+		for _, peer := range s.hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	s.confirmations = []*confirmation.Confirmation{}
+	for _, host := range s.hosts {
+		communication := &tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = communication
+		confirmation := confirmation.NewConfirmation(host, communication, "keygen", host.Peerstore().Peers())
+		confirmation.Subscribe()
+		s.confirmations = append(s.confirmations, confirmation)
+	}
+	tsstest.SetupCommunication(communicationMap)
+}
+
+func (s *ConfirmationTestSuite) confirm(publicKeys [][]byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := pool.New().WithErrors()
+	for i, c := range s.confirmations {
+		c := c
+		publicKey := publicKeys[i]
+		p.Go(func() error { return c.Confirm(ctx, publicKey) })
+	}
+	return p.Wait()
+}
+
+func (s *ConfirmationTestSuite) Test_SamePublicKey() {
+	publicKey := []byte{1, 2, 3}
+
+	err := s.confirm([][]byte{publicKey, publicKey, publicKey})
+
+	s.Nil(err)
+}
+
+func (s *ConfirmationTestSuite) Test_DifferentPublicKey() {
+	publicKey := []byte{1, 2, 3}
+
+	err := s.confirm([][]byte{publicKey, publicKey, {3, 2, 1}})
+
+	s.NotNil(err)
+	s.ErrorContains(err, "committed to a different public key or peer set")
+}
+
+func (s *ConfirmationTestSuite) Test_MissingConfirmation() {
+	publicKey := []byte{1, 2, 3}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := s.confirmations[0].Confirm(ctx, publicKey)
+
+	s.NotNil(err)
+}
@@ -6,18 +6,21 @@ package tss
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
 	"github.com/ChainSafe/sygma-relayer/tss/message"
+	"github.com/ChainSafe/sygma-relayer/tss/reputation"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
 	"github.com/binance-chain/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 	"golang.org/x/exp/slices"
 )
 
@@ -37,13 +40,24 @@ type TssProcess interface {
 	ValidCoordinators() []peer.ID
 }
 
+// ReputationAwareProcess is implemented by tss processes that
+// prefer well-behaved peers when selecting participants
+type ReputationAwareProcess interface {
+	SetPeerTiers(peerTiers util.PeerTiers)
+}
+
+type PeerReputation interface {
+	Penalize(p peer.ID, offence reputation.Offence)
+	Scores(peers []peer.ID) map[peer.ID]float64
+}
+
 type Coordinator struct {
 	host           host.Host
 	communication  comm.Communication
 	electorFactory *elector.CoordinatorElectorFactory
+	reputation     PeerReputation
 
-	pendingProcesses map[string]bool
-	processLock      sync.Mutex
+	sessions *SessionRegistry
 
 	CoordinatorTimeout time.Duration
 	TssTimeout         time.Duration
@@ -54,13 +68,15 @@ func NewCoordinator(
 	host host.Host,
 	communication comm.Communication,
 	electorFactory *elector.CoordinatorElectorFactory,
+	reputation PeerReputation,
 ) *Coordinator {
 	return &Coordinator{
 		host:           host,
 		communication:  communication,
 		electorFactory: electorFactory,
+		reputation:     reputation,
 
-		pendingProcesses: make(map[string]bool),
+		sessions: NewSessionRegistry(),
 
 		CoordinatorTimeout: coordinatorTimeout,
 		TssTimeout:         tssTimeout,
@@ -68,36 +84,35 @@ func NewCoordinator(
 	}
 }
 
+// SessionRegistry returns registry of tss sessions pending on the relayer
+func (c *Coordinator) SessionRegistry() *SessionRegistry {
+	return c.sessions
+}
+
 // Execute calculates process leader and coordinates party readiness and start the tss processes.
 // Array of processes can be passed if all the processes have to have the same peer subset and
 // the result of all of them is needed. The processes should have an unique session ID for each one.
 func (c *Coordinator) Execute(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}) error {
 	sessionID := tssProcesses[0].SessionID()
-	value, ok := c.pendingProcesses[sessionID]
-	if ok && value {
+	err := c.sessions.register(sessionID, tssProcesses)
+	if err != nil {
 		log.Warn().Str("SessionID", sessionID).Msgf("Process already pending")
-		return fmt.Errorf("process already pending")
+		return err
 	}
 
-	c.processLock.Lock()
-	c.pendingProcesses[sessionID] = true
-	c.processLock.Unlock()
-
 	ctx, cancel := context.WithCancel(ctx)
 	p := pool.New().WithContext(ctx).WithCancelOnError()
 	defer func() {
 		cancel()
 		c.communication.CloseSession(sessionID)
-		c.processLock.Lock()
-		c.pendingProcesses[sessionID] = false
-		c.processLock.Unlock()
+		c.sessions.unregister(sessionID)
 		for _, process := range tssProcesses {
 			process.Stop()
 		}
 	}()
 
-	coordinatorElector := c.electorFactory.CoordinatorElector(sessionID, elector.Static)
-	coordinator, _ := coordinatorElector.Coordinator(ctx, tssProcesses[0].ValidCoordinators())
+	coordinator := c.staticCoordinator(ctx, tssProcesses[0])
+	c.sessions.setCoordinator(sessionID, coordinator)
 
 	log.Info().Str("SessionID", sessionID).Msgf("Starting process with coordinator %s", coordinator.Pretty())
 
@@ -111,7 +126,7 @@ func (c *Coordinator) Execute(ctx context.Context, tssProcesses []TssProcess, re
 	p.Go(func(ctx context.Context) error {
 		return c.watchExecution(ctx, tssProcesses[0], coordinator)
 	})
-	err := p.Wait()
+	err = p.Wait()
 	if err == nil {
 		return nil
 	}
@@ -123,6 +138,17 @@ func (c *Coordinator) Execute(ctx context.Context, tssProcesses []TssProcess, re
 	return c.handleError(ctx, err, tssProcesses, resultChn)
 }
 
+// IsCoordinator returns true if the relayer is the initial coordinator of the tss processes
+func (c *Coordinator) IsCoordinator(ctx context.Context, tssProcesses []TssProcess) bool {
+	return c.staticCoordinator(ctx, tssProcesses[0]).Pretty() == c.host.ID().Pretty()
+}
+
+func (c *Coordinator) staticCoordinator(ctx context.Context, tssProcess TssProcess) peer.ID {
+	coordinatorElector := c.electorFactory.CoordinatorElector(tssProcess.SessionID(), elector.Static, nil)
+	coordinator, _ := coordinatorElector.Coordinator(ctx, tssProcess.ValidCoordinators())
+	return coordinator
+}
+
 func (c *Coordinator) handleError(ctx context.Context, err error, tssProcesses []TssProcess, resultChn chan interface{}) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -136,6 +162,7 @@ func (c *Coordinator) handleError(ctx context.Context, err error, tssProcesses [
 	case *CoordinatorError:
 		{
 			log.Warn().Str("SessionID", sessionID).Msgf("Tss process failed with error %+v", err)
+			c.reputation.Penalize(err.Peer, reputation.Timeout)
 
 			excludedPeers := []peer.ID{err.Peer}
 			rp.Go(func(ctx context.Context) error { return c.retry(ctx, tssProcesses, resultChn, excludedPeers) })
@@ -143,6 +170,7 @@ func (c *Coordinator) handleError(ctx context.Context, err error, tssProcesses [
 	case *comm.CommunicationError:
 		{
 			log.Err(err).Str("SessionID", sessionID).Msgf("Tss process failed with error %+v", err)
+			c.reputation.Penalize(err.Peer, reputation.CommunicationFailure)
 			rp.Go(func(ctx context.Context) error { return c.retry(ctx, tssProcesses, resultChn, []peer.ID{}) })
 		}
 	case *tss.Error:
@@ -152,6 +180,21 @@ func (c *Coordinator) handleError(ctx context.Context, err error, tssProcesses [
 			if err != nil {
 				return err
 			}
+			for _, culprit := range excludedPeers {
+				c.reputation.Penalize(culprit, reputation.Culprit)
+			}
+			rp.Go(func(ctx context.Context) error { return c.retry(ctx, tssProcesses, resultChn, excludedPeers) })
+		}
+	case protocol.Error:
+		{
+			log.Err(err).Str("SessionID", sessionID).Msgf("Tss process failed with error %+v", err)
+			excludedPeers, err := peersFromPartyIDs(err.Culprits)
+			if err != nil {
+				return err
+			}
+			for _, culprit := range excludedPeers {
+				c.reputation.Penalize(culprit, reputation.Culprit)
+			}
 			rp.Go(func(ctx context.Context) error { return c.retry(ctx, tssProcesses, resultChn, excludedPeers) })
 		}
 	case *SubsetError:
@@ -211,13 +254,18 @@ func (c *Coordinator) start(ctx context.Context, tssProcesses []TssProcess, coor
 }
 
 // retry initiates full bully process to calculate coordinator and starts a new tss process after
-// an expected error ocurred during regular tss execution
+// an expected error ocurred during regular tss execution. Bully process prefers peers with better
+// reputation tiers agreed in the start message of the failed process.
 func (c *Coordinator) retry(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}, excludedPeers []peer.ID) error {
-	coordinatorElector := c.electorFactory.CoordinatorElector(tssProcesses[0].SessionID(), elector.Bully)
+	sessionID := tssProcesses[0].SessionID()
+	c.sessions.retry(sessionID, excludedPeers)
+
+	coordinatorElector := c.electorFactory.CoordinatorElector(sessionID, elector.Bully, c.sessions.peerTiers(sessionID))
 	coordinator, err := coordinatorElector.Coordinator(ctx, common.ExcludePeers(tssProcesses[0].ValidCoordinators(), excludedPeers))
 	if err != nil {
 		return err
 	}
+	c.sessions.setCoordinator(sessionID, coordinator)
 
 	return c.start(ctx, tssProcesses, coordinator, resultChn, excludedPeers)
 }
@@ -233,12 +281,19 @@ func (c *Coordinator) broadcastInitiateMsg(sessionID string) {
 // initiate sends initiate message to all peers and waits
 // for ready response. After tss process declares that enough
 // peers are ready, start message is broadcasted and tss process is started.
+// Peer scores reported in ready messages are aggregated into peer tiers
+// that are shared with the tss process and other peers in the start message.
 func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}, excludedPeers []peer.ID) error {
 	readyChan := make(chan *comm.WrappedMessage)
 	readyPeers := make([]peer.ID, 0)
 	readyPeers = append(readyPeers, c.host.ID())
 
+	peers := c.host.Peerstore().Peers()
+	scoreReports := make(map[peer.ID]map[peer.ID]float64)
+	scoreReports[c.host.ID()] = c.reputation.Scores(peers)
+
 	tssProcess := tssProcesses[0]
+	c.sessions.setReadyPeers(tssProcess.SessionID(), readyPeers)
 	subID := c.communication.Subscribe(tssProcess.SessionID(), comm.TssReadyMsg, readyChan)
 	defer c.communication.UnSubscribe(subID)
 
@@ -252,7 +307,24 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 				log.Debug().Str("SessionID", tssProcess.SessionID()).Msgf("received ready message from %s", wMsg.From)
 				if !slices.Contains(excludedPeers, wMsg.From) && !slices.Contains(readyPeers, wMsg.From) {
 					readyPeers = append(readyPeers, wMsg.From)
+					c.sessions.setReadyPeers(tssProcess.SessionID(), readyPeers)
+				}
+				if !slices.Contains(excludedPeers, wMsg.From) {
+					scores, err := unmarshalPeerScores(wMsg.Payload)
+					if err != nil {
+						log.Debug().Str("SessionID", tssProcess.SessionID()).Msgf("Ready message from %s without peer scores", wMsg.From)
+					} else {
+						scoreReports[wMsg.From] = scores
+					}
+				}
+
+				peerTiers := aggregatePeerTiers(scoreReports, readyPeers, peers)
+				for _, process := range tssProcesses {
+					if process, ok := process.(ReputationAwareProcess); ok {
+						process.SetPeerTiers(peerTiers)
+					}
 				}
+
 				ready, err := tssProcess.Ready(readyPeers, excludedPeers)
 				if err != nil {
 					return err
@@ -262,10 +334,11 @@ func (c *Coordinator) initiate(ctx context.Context, tssProcesses []TssProcess, r
 				}
 
 				startParams := tssProcess.StartParams(readyPeers)
-				startMsgBytes, err := message.MarshalStartMessage(startParams)
+				startMsgBytes, err := message.MarshalStartMessage(startParams, prettyPeerTiers(peerTiers))
 				if err != nil {
 					return err
 				}
+				c.sessions.setPeerTiers(tssProcess.SessionID(), peerTiers)
 
 				_ = c.communication.Broadcast(c.host.Peerstore().Peers(), startMsgBytes, comm.TssStartMsg, tssProcess.SessionID())
 				p := pool.New().WithContext(ctx).WithCancelOnError()
@@ -320,9 +393,14 @@ func (c *Coordinator) waitForStart(
 
 				coordinatorTimeoutTicker.Reset(timeout)
 
+				readyMsgBytes, err := message.MarshalReadyMessage(prettyPeerScores(c.reputation.Scores(c.host.Peerstore().Peers())))
+				if err != nil {
+					return err
+				}
+
 				log.Debug().Str("SessionID", tssProcess.SessionID()).Msgf("sent ready message to %s", wMsg.From)
 				_ = c.communication.Broadcast(
-					peer.IDSlice{wMsg.From}, []byte{}, comm.TssReadyMsg, tssProcess.SessionID(),
+					peer.IDSlice{wMsg.From}, readyMsgBytes, comm.TssReadyMsg, tssProcess.SessionID(),
 				)
 			}
 		case startMsg := <-startMsgChn:
@@ -340,6 +418,7 @@ func (c *Coordinator) waitForStart(
 				if err != nil {
 					return err
 				}
+				c.sessions.setPeerTiers(tssProcess.SessionID(), parsePeerTiers(msg.PeerTiers))
 
 				p := pool.New().WithContext(ctx).WithCancelOnError()
 				for _, process := range tssProcesses {
@@ -361,3 +440,69 @@ func (c *Coordinator) waitForStart(
 		}
 	}
 }
+
+// aggregatePeerTiers calculates peer tiers from scores reported by ready peers
+func aggregatePeerTiers(scoreReports map[peer.ID]map[peer.ID]float64, readyPeers []peer.ID, peers []peer.ID) util.PeerTiers {
+	reports := make([]map[peer.ID]float64, 0)
+	for _, readyPeer := range readyPeers {
+		if report, ok := scoreReports[readyPeer]; ok {
+			reports = append(reports, report)
+		}
+	}
+	return reputation.AggregateTiers(reports, peers)
+}
+
+func unmarshalPeerScores(payload []byte) (map[peer.ID]float64, error) {
+	msg, err := message.UnmarshalReadyMessage(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[peer.ID]float64)
+	for peerID, score := range msg.PeerScores {
+		p, err := peer.Decode(peerID)
+		if err != nil {
+			return nil, err
+		}
+		scores[p] = score
+	}
+	return scores, nil
+}
+
+func prettyPeerScores(scores map[peer.ID]float64) map[string]float64 {
+	prettyScores := make(map[string]float64)
+	for p, score := range scores {
+		prettyScores[p.Pretty()] = score
+	}
+	return prettyScores
+}
+
+func prettyPeerTiers(peerTiers util.PeerTiers) map[string]int {
+	prettyTiers := make(map[string]int)
+	for p, tier := range peerTiers {
+		prettyTiers[p.Pretty()] = tier
+	}
+	return prettyTiers
+}
+
+func parsePeerTiers(prettyTiers map[string]int) util.PeerTiers {
+	peerTiers := make(util.PeerTiers)
+	for peerID, tier := range prettyTiers {
+		p, err := peer.Decode(peerID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Invalid peer %s in peer tiers", peerID)
+			continue
+		}
+		peerTiers[p] = tier
+	}
+	return peerTiers
+}
+
+// peersFromPartyIDs converts culprits of identifiable aborts into peer IDs
+func peersFromPartyIDs(ids []party.ID) ([]peer.ID, error) {
+	peerIDS := make([]string, len(ids))
+	for i, id := range ids {
+		peerIDS[i] = string(id)
+	}
+	return common.PeersFromIDS(peerIDS)
+}
@@ -0,0 +1,128 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keygen_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/keygen"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeygenTestSuite struct {
+	tsstest.CoordinatorTestSuite
+}
+
+func TestRunKeygenTestSuite(t *testing.T) {
+	suite.Run(t, new(KeygenTestSuite))
+}
+
+func (s *KeygenTestSuite) Test_ValidKeygenProcess() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	storers := []*keyshare.CMPKeyshareStore{}
+
+	dir := s.T().TempDir()
+	for i, host := range s.CoordinatorTestSuite.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		storer := keyshare.NewCMPKeyshareStore(filepath.Join(dir, fmt.Sprintf("%d-cmp.keyshare", i)))
+		storers = append(storers, storer)
+		keygen := keygen.NewKeygen("keygen", s.Threshold, host, &communication, storer)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, keygen)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{process}, nil) })
+	}
+
+	err := pool.Wait()
+	s.Nil(err)
+
+	publicKeys := [][]byte{}
+	chainCodes := [][]byte{}
+	for _, storer := range storers {
+		key, err := storer.GetKeyshare()
+		s.Nil(err)
+		publicKey, err := key.Key.PublicPoint().MarshalBinary()
+		s.Nil(err)
+		publicKeys = append(publicKeys, publicKey)
+		chainCodes = append(chainCodes, key.ChainCode)
+	}
+	s.Equal(publicKeys[0], publicKeys[1])
+	s.Equal(publicKeys[0], publicKeys[2])
+	s.Len(chainCodes[0], keyshare.ChainCodeLength)
+	s.Equal(chainCodes[0], chainCodes[1])
+	s.Equal(chainCodes[0], chainCodes[2])
+}
+
+func (s *KeygenTestSuite) Test_ValidKeygenProcess_ExistingKeyshareNotActivated() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	storers := []*keyshare.CMPKeyshareStore{}
+
+	dir := s.T().TempDir()
+	for i, host := range s.CoordinatorTestSuite.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		key, err := keyshare.NewCMPKeyshareStore(fmt.Sprintf("../../../test/keyshares/%d-cmp.keyshare", i)).GetKeyshare()
+		s.Nil(err)
+		path := filepath.Join(dir, fmt.Sprintf("%d-cmp.keyshare", i))
+		storer := keyshare.NewCMPKeyshareStore(path)
+		err = storer.StoreKeyshare(key)
+		s.Nil(err)
+		err = os.WriteFile(path, []byte("corrupted"), 0600)
+		s.Nil(err)
+		storers = append(storers, storer)
+		keygen := keygen.NewKeygen("keygen2", s.Threshold, host, &communication, storer)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, keygen)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{process}, nil) })
+	}
+
+	err := pool.Wait()
+	s.Nil(err)
+
+	for _, storer := range storers {
+		_, err := storer.GetKeyshare()
+		s.NotNil(err)
+		versions, active, err := storer.History().Versions()
+		s.Nil(err)
+		s.Equal(active, 1)
+		s.Len(versions, 2)
+	}
+}
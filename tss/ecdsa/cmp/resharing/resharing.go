@@ -0,0 +1,242 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package resharing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+	"golang.org/x/exp/slices"
+)
+
+type startParams struct {
+	ChainCode []byte `json:"chainCode"`
+}
+
+type CMPKeyshareStorer interface {
+	GetKeyshare() (keyshare.CMPKeyshare, error)
+	StoreKeyshareVersion(keyshare keyshare.CMPKeyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
+	LockKeyshare()
+	UnlockKeyshare()
+}
+
+type ECDSAKeyshareFetcher interface {
+	GetKeyshare() (keyshare.ECDSAKeyshare, error)
+	LockKeyshare()
+	UnlockKeyshare()
+}
+
+// Resharing refreshes CMP keyshares of the signing committee. Relayers that don't
+// have a CMP keyshare of the current committee migrate their GG18 keyshare, which makes
+// resharing the ceremony that moves the network from GG18 to CMP while keeping the public key.
+// CMP refresh keeps the committee and threshold, so topology changes are handled by
+// GG18 resharing before migrating the reshared GG18 keyshare.
+type Resharing struct {
+	common.BaseFrostTss
+	key            *keyshare.CMPKeyshare
+	subscriptionID comm.SubscriptionID
+	storer         CMPKeyshareStorer
+	newThreshold   int
+	topologyHash   string
+}
+
+func NewResharing(
+	sessionID string,
+	threshold int,
+	host host.Host,
+	comm comm.Communication,
+	storer CMPKeyshareStorer,
+	ecdsaFetcher ECDSAKeyshareFetcher,
+	topologyHash string,
+) *Resharing {
+	logger := log.With().Str("SessionID", sessionID).Str("Process", "cmp-resharing").Logger()
+
+	storer.LockKeyshare()
+	var key *keyshare.CMPKeyshare
+	cmpKey, err := storer.GetKeyshare()
+	if err == nil && cmpKey.Threshold == threshold && samePeers(cmpKey.Peers, host.Peerstore().Peers()) {
+		key = &cmpKey
+	} else {
+		key, err = migrateKeyshare(host.ID(), ecdsaFetcher)
+		if err != nil {
+			logger.Warn().Err(err).Msgf("Failed migrating ECDSA keyshare")
+		}
+	}
+
+	return &Resharing{
+		BaseFrostTss: common.BaseFrostTss{
+			Host:          host,
+			Communication: comm,
+			Peers:         host.Peerstore().Peers(),
+			SID:           sessionID,
+			Log:           logger,
+			Cancel:        func() {},
+			Done:          make(chan bool),
+		},
+		key:          key,
+		storer:       storer,
+		newThreshold: threshold,
+		topologyHash: topologyHash,
+	}
+}
+
+// Run initializes the resharing party and runs the resharing tss process.
+func (r *Resharing) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	ctx, r.Cancel = context.WithCancel(ctx)
+
+	if r.key == nil {
+		return errors.New("missing keyshare to reshare")
+	}
+	if r.newThreshold != r.key.Threshold || !samePeers(r.key.Peers, r.Peers) {
+		return errors.New("CMP resharing does not support changing the signing committee")
+	}
+	var startParams startParams
+	err := json.Unmarshal(params, &startParams)
+	if err != nil {
+		return err
+	}
+	// chain code has to stay the same so the derived keys don't change with resharing
+	if !bytes.Equal(startParams.ChainCode, r.key.ChainCode) {
+		return errors.New("invalid chain code in start params")
+	}
+
+	outChn := make(chan tss.Message)
+	msgChn := make(chan *comm.WrappedMessage)
+	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
+
+	r.Handler, err = protocol.NewMultiHandler(cmp.Refresh(r.key.Key, nil), []byte(r.SessionID()))
+	if err != nil {
+		return err
+	}
+
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error { return r.ProcessInboundMessages(ctx, msgChn) })
+	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx) })
+	p.Go(func(ctx context.Context) error { return r.ProcessOutboundMessages(ctx, outChn, comm.TssReshareMsg) })
+
+	r.Log.Info().Msgf("Started resharing process")
+	return p.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process and unlocks keyshare.
+func (r *Resharing) Stop() {
+	r.Log.Info().Msgf("Stopping tss process.")
+	r.Communication.UnSubscribe(r.subscriptionID)
+	r.storer.UnlockKeyshare()
+	r.Cancel()
+}
+
+// Ready returns true if all parties from peerstore are ready
+func (r *Resharing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	return len(readyPeers) == len(r.Host.Peerstore().Peers()), nil
+}
+
+func (r *Resharing) ValidCoordinators() []peer.ID {
+	if r.key == nil {
+		return []peer.ID{}
+	}
+	return r.key.Peers
+}
+
+// StartParams returns chain code of the key so parties can check they reshare the same key.
+func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
+	if r.key == nil {
+		return []byte{}
+	}
+	paramBytes, _ := json.Marshal(&startParams{ChainCode: r.key.ChainCode})
+	return paramBytes
+}
+
+func (r *Resharing) Retryable() bool {
+	return false
+}
+
+// processEndMessage waits for the final message with refreshed key share and stores it locally.
+func (r *Resharing) processEndMessage(ctx context.Context) error {
+	for {
+		select {
+		case <-r.Done:
+			{
+				result, err := r.Handler.Result()
+				if err != nil {
+					return err
+				}
+				config := result.(*cmp.Config)
+				if !config.PublicPoint().Equal(r.key.Key.PublicPoint()) {
+					return fmt.Errorf("public key changed during resharing")
+				}
+
+				version, err := r.storer.StoreKeyshareVersion(
+					keyshare.NewCMPKeyshare(config, r.newThreshold, r.key.Peers, r.key.ChainCode),
+					keyshare.Metadata{SessionID: r.SessionID(), TopologyHash: r.topologyHash},
+				)
+				if err != nil {
+					return err
+				}
+				err = r.storer.ActivateKeyshare(version)
+				if err != nil {
+					return err
+				}
+
+				r.Log.Info().Msgf("Refreshed key")
+				r.Cancel()
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// migrateKeyshare converts GG18 keyshare into CMP keyshare used as resharing input
+func migrateKeyshare(self peer.ID, ecdsaFetcher ECDSAKeyshareFetcher) (*keyshare.CMPKeyshare, error) {
+	if ecdsaFetcher == nil {
+		return nil, errors.New("missing ECDSA keyshare store")
+	}
+
+	ecdsaFetcher.LockKeyshare()
+	defer ecdsaFetcher.UnlockKeyshare()
+	ecdsaKey, err := ecdsaFetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := keyshare.MigrateECDSAKeyshare(ecdsaKey, self)
+	if err != nil {
+		return nil, err
+	}
+	key := keyshare.NewCMPKeyshare(config, ecdsaKey.Threshold, ecdsaKey.Peers, ecdsaKey.ChainCode)
+	return &key, nil
+}
+
+func samePeers(peers []peer.ID, otherPeers []peer.ID) bool {
+	if len(peers) != len(otherPeers) {
+		return false
+	}
+	for _, p := range peers {
+		if !slices.Contains(otherPeers, p) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package resharing_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/resharing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResharingTestSuite struct {
+	tsstest.CoordinatorTestSuite
+}
+
+func TestRunResharingTestSuite(t *testing.T) {
+	suite.Run(t, new(ResharingTestSuite))
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_MigratesECDSAKeyshare() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	storers := []*keyshare.CMPKeyshareStore{}
+	dir := s.T().TempDir()
+
+	ecdsaKeyshare, err := keyshare.NewECDSAKeyshareStore("../../../test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		ecdsaStorer := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../../test/keyshares/%d.keyshare", i))
+		storer := keyshare.NewCMPKeyshareStore(filepath.Join(dir, fmt.Sprintf("%d-cmp.keyshare", i)))
+		storers = append(storers, storer)
+		resharing := resharing.NewResharing("resharing", s.Threshold, host, &communication, storer, ecdsaStorer, "")
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{})
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	err = pool.Wait()
+	s.Nil(err)
+	for _, storer := range storers {
+		key, err := storer.GetKeyshare()
+		s.Nil(err)
+		publicKey, err := key.Key.PublicPoint().MarshalBinary()
+		s.Nil(err)
+		s.Equal(publicKey, ecdsaKeyshare.Key.ECDSAPub.ToBtcecPubKey().SerializeCompressed())
+		s.Equal(key.Threshold, ecdsaKeyshare.Threshold)
+		s.Equal(key.ChainCode, ecdsaKeyshare.ChainCode)
+	}
+}
+
+func (s *ResharingTestSuite) Test_InvalidResharingProcess_ChangedCommittee() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	dir := s.T().TempDir()
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		ecdsaStorer := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../../test/keyshares/%d.keyshare", i))
+		storer := keyshare.NewCMPKeyshareStore(filepath.Join(dir, fmt.Sprintf("%d-cmp.keyshare", i)))
+		resharing := resharing.NewResharing("resharing", s.Threshold+1, host, &communication, storer, ecdsaStorer, "")
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{})
+	pool := pool.New().WithContext(context.Background())
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	err := pool.Wait()
+	s.NotNil(err)
+}
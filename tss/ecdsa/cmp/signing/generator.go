@@ -0,0 +1,83 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/rs/zerolog/log"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/tss"
+)
+
+// presignDomainID is the scheduler queue domain of presigning sessions
+// as they are not related to a destination domain
+const presignDomainID uint8 = 0
+
+type SessionScheduler interface {
+	Execute(ctx context.Context, priority tss.Priority, domainID uint8, tssProcesses []tss.TssProcess, resultChn chan interface{}) error
+}
+
+// PresignatureGenerator runs presigning sessions with idle priority
+// until the presignature pool is full.
+type PresignatureGenerator struct {
+	scheduler     SessionScheduler
+	host          host.Host
+	comm          comm.Communication
+	fetcher       SaveDataFetcher
+	presignatures *PresignaturePool
+	interval      time.Duration
+}
+
+func NewPresignatureGenerator(
+	scheduler SessionScheduler,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+	interval time.Duration,
+) *PresignatureGenerator {
+	return &PresignatureGenerator{
+		scheduler:     scheduler,
+		host:          host,
+		comm:          comm,
+		fetcher:       fetcher,
+		presignatures: presignatures,
+		interval:      interval,
+	}
+}
+
+// Start runs a presigning session at the start of each interval until the context is cancelled.
+// Session ID is calculated from the interval start so all relayers join the same session.
+func (g *PresignatureGenerator) Start(ctx context.Context) {
+	for {
+		slot := time.Now().Truncate(g.interval).Add(g.interval)
+		select {
+		case <-time.After(time.Until(slot)):
+			g.presign(ctx, slot)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *PresignatureGenerator) presign(ctx context.Context, slot time.Time) {
+	presigning, err := NewPresigning(fmt.Sprintf("presign-%d", slot.Unix()), g.host, g.comm, g.fetcher, g.presignatures)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Skipping presigning")
+		return
+	}
+	if g.presignatures.Full(presigning.key.ShareID()) {
+		return
+	}
+
+	err = g.scheduler.Execute(ctx, tss.PriorityIdle, presignDomainID, []tss.TssProcess{presigning}, make(chan interface{}, 1))
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed executing presigning")
+	}
+}
@@ -0,0 +1,127 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"golang.org/x/exp/slices"
+)
+
+type presignature struct {
+	presignature *ecdsa.PreSignature
+	signers      []peer.ID
+	key          string
+}
+
+// PresignaturePool stores presignatures generated in idle time so signing
+// can be finished with a single online round. Presignatures are bound to the
+// keyshare they were generated with and to the peers that generated them.
+type PresignaturePool struct {
+	size          int
+	presignatures map[string]*presignature
+	lock          sync.Mutex
+}
+
+func NewPresignaturePool(size int) *PresignaturePool {
+	return &PresignaturePool{
+		size:          size,
+		presignatures: make(map[string]*presignature),
+	}
+}
+
+// Add stores presignature generated in presigning session with id
+func (p *PresignaturePool) Add(id string, key string, preSignature *ecdsa.PreSignature, signers []peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.presignatures[id] = &presignature{
+		presignature: preSignature,
+		signers:      signers,
+		key:          key,
+	}
+}
+
+// Take removes the presignature from the pool and returns it.
+// Presignatures generated with a different keyshare are not returned.
+func (p *PresignaturePool) Take(id string, key string) (*ecdsa.PreSignature, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	presig, ok := p.presignatures[id]
+	if !ok {
+		return nil, false
+	}
+	delete(p.presignatures, id)
+	if presig.key != key {
+		return nil, false
+	}
+	return presig.presignature, true
+}
+
+// Find returns ids of count presignatures generated by the same ready peers with
+// the keyshare, which are returned as the signing subset.
+func (p *PresignaturePool) Find(count int, key string, readyPeers []peer.ID) ([]string, []peer.ID, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	ids := make([]string, 0, len(p.presignatures))
+	for id := range p.presignatures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	signerSets := make(map[string][]string)
+	for _, id := range ids {
+		presig := p.presignatures[id]
+		if presig.key != key || !containsAll(readyPeers, presig.signers) {
+			continue
+		}
+
+		signers := signersKey(presig.signers)
+		signerSets[signers] = append(signerSets[signers], id)
+		if len(signerSets[signers]) == count {
+			return signerSets[signers], presig.signers, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Full returns true if the pool contains enough presignatures of the keyshare
+func (p *PresignaturePool) Full(key string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	count := 0
+	for id, presig := range p.presignatures {
+		if presig.key != key {
+			delete(p.presignatures, id)
+			continue
+		}
+		count++
+	}
+	return count >= p.size
+}
+
+func signersKey(signers []peer.ID) string {
+	sorted := make([]string, len(signers))
+	for i, signer := range signers {
+		sorted[i] = signer.String()
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func containsAll(peers []peer.ID, subset []peer.ID) bool {
+	for _, p := range subset {
+		if !slices.Contains(peers, p) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,180 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+	"golang.org/x/exp/slices"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+)
+
+// Presigning generates a presignature that does not depend on the message being signed
+// and stores it in the presignature pool of each participant.
+type Presigning struct {
+	common.BaseFrostTss
+	util.TieredReadiness
+	key            keyshare.CMPKeyshare
+	presignatures  *PresignaturePool
+	subscriptionID comm.SubscriptionID
+}
+
+func NewPresigning(
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+) (*Presigning, error) {
+	fetcher.LockKeyshare()
+	defer fetcher.UnlockKeyshare()
+	key, err := fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Presigning{
+		BaseFrostTss: common.BaseFrostTss{
+			Host:          host,
+			Communication: comm,
+			Peers:         key.Peers,
+			SID:           sessionID,
+			Log:           log.With().Str("SessionID", sessionID).Str("Process", "presigning").Logger(),
+			Cancel:        func() {},
+			Done:          make(chan bool),
+		},
+		key:           key,
+		presignatures: presignatures,
+	}, nil
+}
+
+// Run initializes the presigning party and runs the presigning tss process.
+// Params contains peer subset that leaders sends with start message.
+// Peers outside of the subset do not participate in the process.
+func (p *Presigning) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	ctx, p.Cancel = context.WithCancel(ctx)
+
+	var peerSubset []peer.ID
+	err := json.Unmarshal(params, &peerSubset)
+	if err != nil {
+		return err
+	}
+	if !util.IsParticipant(p.Host.ID(), peerSubset) {
+		p.Log.Debug().Msgf("Not part of the presigning subset")
+		return nil
+	}
+	p.Peers = peerSubset
+
+	msgChn := make(chan *comm.WrappedMessage)
+	p.subscriptionID = p.Communication.Subscribe(p.SessionID(), comm.TssKeySignMsg, msgChn)
+	p.Handler, err = protocol.NewMultiHandler(
+		cmp.Presign(p.key.Key, common.PartyIDSFromPeers(peerSubset), nil),
+		[]byte(p.SessionID()))
+	if err != nil {
+		return err
+	}
+
+	outChn := make(chan tss.Message)
+	pl := pool.New().WithContext(ctx).WithCancelOnError()
+	pl.Go(func(ctx context.Context) error { return p.ProcessInboundMessages(ctx, msgChn) })
+	pl.Go(func(ctx context.Context) error { return p.processEndMessage(ctx) })
+	pl.Go(func(ctx context.Context) error { return p.ProcessOutboundMessages(ctx, outChn, comm.TssKeySignMsg) })
+
+	p.Log.Info().Msgf("Started presigning process")
+	return pl.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process.
+func (p *Presigning) Stop() {
+	p.Communication.UnSubscribe(p.subscriptionID)
+	p.Cancel()
+}
+
+// Ready returns true if threshold+1 parties with a valid keyshare are ready
+func (p *Presigning) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	return len(p.readyParticipants(readyPeers)) >= p.key.Threshold+1, nil
+}
+
+// ValidCoordinators returns only peers that have a valid keyshare
+func (p *Presigning) ValidCoordinators() []peer.ID {
+	return p.key.Peers
+}
+
+// StartParams returns peer subset of threshold+1 ready peers sorted by reputation tier
+func (p *Presigning) StartParams(readyPeers []peer.ID) []byte {
+	sortedPeers := util.SortPeersByTier(p.readyParticipants(readyPeers), p.SessionID(), p.PeerTiers())
+	peerSubset := []peer.ID{}
+	for _, peer := range sortedPeers {
+		peerSubset = append(peerSubset, peer.ID)
+		if len(peerSubset) == p.key.Threshold+1 {
+			break
+		}
+	}
+
+	paramBytes, _ := json.Marshal(peerSubset)
+	return paramBytes
+}
+
+func (p *Presigning) Retryable() bool {
+	return false
+}
+
+// processEndMessage stores the generated presignature to the presignature pool.
+func (p *Presigning) processEndMessage(ctx context.Context) error {
+	for {
+		select {
+		case <-p.Done:
+			{
+				result, err := p.Handler.Result()
+				if err != nil {
+					return err
+				}
+				preSignature, ok := result.(*ecdsa.PreSignature)
+				if !ok {
+					return fmt.Errorf("invalid presigning result %T", result)
+				}
+
+				p.presignatures.Add(p.SessionID(), p.key.ShareID(), preSignature, p.Peers)
+				p.Log.Info().Msgf("Generated presignature")
+				p.Cancel()
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// readyParticipants returns all ready peers that contain a valid key share
+func (p *Presigning) readyParticipants(readyPeers []peer.ID) []peer.ID {
+	readyParticipants := make([]peer.ID, 0)
+	for _, peer := range readyPeers {
+		if !slices.Contains(p.key.Peers, peer) {
+			continue
+		}
+
+		readyParticipants = append(readyParticipants, peer)
+	}
+
+	return readyParticipants
+}
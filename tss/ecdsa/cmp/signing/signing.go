@@ -0,0 +1,513 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	tssCommon "github.com/binance-chain/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cronokirby/saferith"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+	"golang.org/x/exp/slices"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	errors "github.com/ChainSafe/sygma-relayer/tss"
+	ecdsaCommon "github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+	ecdsaSigning "github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+)
+
+type SaveDataFetcher interface {
+	GetKeyshare() (keyshare.CMPKeyshare, error)
+	LockKeyshare()
+	UnlockKeyshare()
+}
+
+type startParams struct {
+	Peers         []peer.ID
+	Presignatures []string
+}
+
+// Signing signs messages with the CMP keyshare. If the coordinator has enough presignatures
+// generated by ready peers, signatures are generated with a single online round and
+// with the full CMP signing protocol otherwise. Multiple messages are signed in a single
+// session by running a protocol handler for each message.
+type Signing struct {
+	Host          host.Host
+	SID           string
+	Communication comm.Communication
+	Log           zerolog.Logger
+	Peers         []peer.ID
+	Cancel        context.CancelFunc
+
+	coordinator    bool
+	batch          bool
+	key            keyshare.CMPKeyshare
+	msgs           []*big.Int
+	presignatures  *PresignaturePool
+	resultChn      chan interface{}
+	subscriptionID comm.SubscriptionID
+	util.TieredReadiness
+
+	handlers     []*protocol.MultiHandler
+	handlersLock sync.RWMutex
+}
+
+func NewSigning(
+	msg *big.Int,
+	derivationPath []uint32,
+	messageID string,
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+) (*Signing, error) {
+	return newSigning([]*big.Int{msg}, false, derivationPath, messageID, sessionID, host, comm, fetcher, presignatures)
+}
+
+// NewBatchSigning creates signing process that signs multiple messages in a single
+// session and sends ecdsa signing.BatchSignature for each signed message
+func NewBatchSigning(
+	msgs []*big.Int,
+	derivationPath []uint32,
+	messageID string,
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+) (*Signing, error) {
+	return newSigning(msgs, true, derivationPath, messageID, sessionID, host, comm, fetcher, presignatures)
+}
+
+func newSigning(
+	msgs []*big.Int,
+	batch bool,
+	derivationPath []uint32,
+	messageID string,
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+	presignatures *PresignaturePool,
+) (*Signing, error) {
+	fetcher.LockKeyshare()
+	defer fetcher.UnlockKeyshare()
+	key, err := fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+	if len(derivationPath) != 0 {
+		key.Key, err = deriveKey(key, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		// presignatures are generated for the MPC key and can't be used
+		// to sign with the derived key
+		presignatures = NewPresignaturePool(0)
+	}
+
+	return &Signing{
+		Host:          host,
+		Communication: comm,
+		Peers:         key.Peers,
+		SID:           sessionID,
+		Log:           log.With().Str("SessionID", sessionID).Str("messageID", messageID).Str("Process", "cmp-signing").Logger(),
+		Cancel:        func() {},
+		key:           key,
+		msgs:          msgs,
+		batch:         batch,
+		presignatures: presignatures,
+	}, nil
+}
+
+// Run initializes a protocol handler for each message and runs the signing tss process.
+// Params contains peer subset and presignatures that leader sends with start message.
+func (s *Signing) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	s.coordinator = coordinator
+	s.resultChn = resultChn
+	ctx, s.Cancel = context.WithCancel(ctx)
+
+	startParams, err := s.unmarshallStartParams(params)
+	if err != nil {
+		return err
+	}
+	s.Peers = startParams.Peers
+	if !util.IsParticipant(s.Host.ID(), startParams.Peers) {
+		return &errors.SubsetError{Peer: s.Host.ID()}
+	}
+
+	handlers, err := s.newHandlers(startParams)
+	if err != nil {
+		return err
+	}
+	s.setHandlers(handlers)
+
+	msgChn := make(chan *comm.WrappedMessage)
+	s.subscriptionID = s.Communication.Subscribe(s.SessionID(), comm.TssKeySignMsg, msgChn)
+
+	endChn := make(chan *ecdsaSigning.BatchSignature)
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error { return s.processInboundMessages(ctx, msgChn) })
+	for i, handler := range handlers {
+		index := i
+		handler := handler
+		p.Go(func(ctx context.Context) error { return s.processOutboundMessages(ctx, index, handler, endChn) })
+	}
+	p.Go(func(ctx context.Context) error { return s.processEndMessages(ctx, endChn) })
+
+	s.Log.Info().Msgf("Started signing process for %d messages with %d presignatures", len(s.msgs), len(startParams.Presignatures))
+	return p.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process.
+func (s *Signing) Stop() {
+	s.Log.Info().Msgf("Stopping tss process.")
+	s.Communication.UnSubscribe(s.subscriptionID)
+	s.Cancel()
+}
+
+// Ready returns true if threshold+1 well-behaved parties are ready to start the signing process.
+// Parties with worse reputation are accepted if all parties are ready or if not enough
+// well-behaved parties got ready during the grace period. If there are presignatures
+// generated by peers that are not ready yet, coordinator waits for them during the grace
+// period so signing can be finished in a single round.
+func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	readyPeers = s.readyParticipants(readyPeers)
+	if !s.EnoughReady(readyPeers, s.key.Threshold) {
+		return false, nil
+	}
+	if s.WaitOver(readyPeers, s.key.Peers) {
+		return true, nil
+	}
+	if _, _, ok := s.presignatures.Find(len(s.msgs), s.key.ShareID(), readyPeers); ok {
+		return true, nil
+	}
+	if _, _, ok := s.presignatures.Find(len(s.msgs), s.key.ShareID(), s.key.Peers); ok {
+		return false, nil
+	}
+	return s.WellBehavedReady(readyPeers, s.key.Threshold), nil
+}
+
+// ValidCoordinators returns only peers that have a valid keyshare
+func (s *Signing) ValidCoordinators() []peer.ID {
+	return s.key.Peers
+}
+
+// StartParams returns peer subset and presignatures for this tss process. If there are enough
+// presignatures generated by ready peers, peers that generated them are used as the subset.
+// Otherwise the subset is calculated by sorting ready peers by reputation tier and hashes of
+// peer IDs and session ID and chosing ready peers in order until threshold is satisfied.
+func (s *Signing) StartParams(readyPeers []peer.ID) []byte {
+	readyPeers = s.readyParticipants(readyPeers)
+	ids, signers, ok := s.presignatures.Find(len(s.msgs), s.key.ShareID(), s.PeerTiers().WellBehaved(readyPeers))
+	if !ok {
+		ids, signers, ok = s.presignatures.Find(len(s.msgs), s.key.ShareID(), readyPeers)
+	}
+	if ok {
+		paramBytes, _ := json.Marshal(&startParams{
+			Peers:         signers,
+			Presignatures: ids,
+		})
+		return paramBytes
+	}
+
+	peers := []peer.ID{}
+	peers = append(peers, readyPeers...)
+	sortedPeers := util.SortPeersByTier(peers, s.SessionID(), s.PeerTiers())
+	peerSubset := []peer.ID{}
+	for _, peer := range sortedPeers {
+		peerSubset = append(peerSubset, peer.ID)
+		if len(peerSubset) == s.key.Threshold+1 {
+			break
+		}
+	}
+
+	paramBytes, _ := json.Marshal(&startParams{
+		Peers: peerSubset,
+	})
+	return paramBytes
+}
+
+func (s *Signing) Retryable() bool {
+	return true
+}
+
+func (s *Signing) SessionID() string {
+	return s.SID
+}
+
+func (s *Signing) unmarshallStartParams(paramBytes []byte) (startParams, error) {
+	var params startParams
+	err := json.Unmarshal(paramBytes, &params)
+	if err != nil {
+		return params, err
+	}
+	if len(params.Presignatures) != 0 && len(params.Presignatures) != len(s.msgs) {
+		return params, fmt.Errorf("invalid number of presignatures %d", len(params.Presignatures))
+	}
+
+	return params, nil
+}
+
+// newHandlers creates signing protocol handler for each message. Presignatures
+// are taken from the pool so they can not be reused even if signing fails.
+func (s *Signing) newHandlers(params startParams) ([]*protocol.MultiHandler, error) {
+	handlers := make([]*protocol.MultiHandler, len(s.msgs))
+	for i, msg := range s.msgs {
+		var startFunc protocol.StartFunc
+		if len(params.Presignatures) == 0 {
+			startFunc = cmp.Sign(s.key.Key, common.PartyIDSFromPeers(params.Peers), messageHash(msg), nil)
+		} else {
+			preSignature, ok := s.presignatures.Take(params.Presignatures[i], s.key.ShareID())
+			if !ok {
+				return nil, fmt.Errorf("presignature %s not found", params.Presignatures[i])
+			}
+			startFunc = cmp.PresignOnline(s.key.Key, preSignature, messageHash(msg), nil)
+		}
+
+		handler, err := protocol.NewMultiHandler(startFunc, s.ssid(i))
+		if err != nil {
+			return nil, err
+		}
+		handlers[i] = handler
+	}
+	return handlers, nil
+}
+
+func (s *Signing) setHandlers(handlers []*protocol.MultiHandler) {
+	s.handlersLock.Lock()
+	defer s.handlersLock.Unlock()
+	s.handlers = handlers
+}
+
+// ssid returns unique protocol session ID of the handler signing the message at index
+func (s *Signing) ssid(index int) []byte {
+	return []byte(fmt.Sprintf("%s-%d", s.SID, index))
+}
+
+// processInboundMessages routes messages from tss parties to the handler of the message session.
+func (s *Signing) processInboundMessages(ctx context.Context, msgChan chan *comm.WrappedMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", string(debug.Stack()))
+		}
+	}()
+
+	for {
+		select {
+		case wMsg := <-msgChan:
+			{
+				s.Log.Debug().Msgf("processed inbound message from %s", wMsg.From)
+
+				msg := &protocol.Message{}
+				err := msg.UnmarshalBinary(wMsg.Payload)
+				if err != nil {
+					return err
+				}
+
+				s.handlersLock.RLock()
+				for _, handler := range s.handlers {
+					if handler.CanAccept(msg) {
+						go handler.Accept(msg)
+						break
+					}
+				}
+				s.handlersLock.RUnlock()
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// processOutboundMessages sends messages of the handler signing the message at index
+// to target peers and sends the signature when the handler is finished.
+func (s *Signing) processOutboundMessages(
+	ctx context.Context,
+	index int,
+	handler *protocol.MultiHandler,
+	endChn chan *ecdsaSigning.BatchSignature,
+) error {
+	// delay sending messages until everyone is ready to accept them
+	time.Sleep(common.STARTUP_PAUSE)
+
+	for {
+		select {
+		case msg, ok := <-handler.Listen():
+			{
+				if !ok {
+					signature, err := s.signature(index, handler)
+					if err != nil {
+						return err
+					}
+
+					select {
+					case endChn <- signature:
+					case <-ctx.Done():
+					}
+					return nil
+				}
+
+				msgBytes, err := msg.MarshalBinary()
+				if err != nil {
+					return err
+				}
+
+				peers, err := s.broadcastPeers(msg)
+				if err != nil {
+					return err
+				}
+
+				s.Log.Debug().Msgf("sending message %s to %s", msg, peers)
+				err = s.Communication.Broadcast(peers, msgBytes, comm.TssKeySignMsg, s.SessionID())
+				if err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// processEndMessages routes signatures to result channel and ends
+// the process when all messages are signed.
+func (s *Signing) processEndMessages(ctx context.Context, endChn chan *ecdsaSigning.BatchSignature) error {
+	defer s.Cancel()
+	signed := 0
+	for {
+		select {
+		case sig := <-endChn:
+			{
+				s.Log.Info().Msgf("Successfully generated signature for message %d", sig.Index)
+
+				signed++
+				if s.coordinator {
+					if s.batch {
+						s.resultChn <- sig
+					} else {
+						s.resultChn <- sig.Signature
+					}
+				} else if signed == len(s.msgs) {
+					s.resultChn <- nil
+				}
+
+				if signed == len(s.msgs) {
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// signature converts the result of the handler into signature data
+// in the format of GG18 signatures
+func (s *Signing) signature(index int, handler *protocol.MultiHandler) (*ecdsaSigning.BatchSignature, error) {
+	result, err := handler.Result()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, ok := result.(*ecdsa.Signature)
+	if !ok {
+		return nil, fmt.Errorf("invalid signing result %T", result)
+	}
+	sigBytes, err := signature.SigEthereum()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsaSigning.BatchSignature{
+		Index: index,
+		Signature: &tssCommon.SignatureData{
+			Signature:         sigBytes[:64],
+			SignatureRecovery: sigBytes[64:],
+			R:                 sigBytes[:32],
+			S:                 sigBytes[32:64],
+			M:                 s.msgs[index].Bytes(),
+		},
+	}, nil
+}
+
+func (s *Signing) broadcastPeers(msg *protocol.Message) ([]peer.ID, error) {
+	if msg.Broadcast || string(msg.To) == "" {
+		return s.Peers, nil
+	}
+
+	p, err := peer.Decode(string(msg.To))
+	if err != nil {
+		return nil, err
+	}
+	return []peer.ID{p}, nil
+}
+
+// readyParticipants returns all ready peers that contain a valid key share
+func (s *Signing) readyParticipants(readyPeers []peer.ID) []peer.ID {
+	readyParticipants := make([]peer.ID, 0)
+	for _, peer := range readyPeers {
+		if !slices.Contains(s.key.Peers, peer) {
+			continue
+		}
+
+		readyParticipants = append(readyParticipants, peer)
+	}
+
+	return readyParticipants
+}
+
+// deriveKey adjusts the CMP config so it signs with the child key derived
+// from the MPC key with the derivation path
+func deriveKey(key keyshare.CMPKeyshare, derivationPath []uint32) (*cmp.Config, error) {
+	publicPoint, err := key.Key.PublicPoint().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := btcec.ParsePubKey(publicPoint)
+	if err != nil {
+		return nil, err
+	}
+	delta, _, err := ecdsaCommon.DeriveKey(publicKey, key.ChainCode, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	adjust := curve.Secp256k1{}.NewScalar().SetNat(new(saferith.Nat).SetBig(delta, 256))
+	return key.Key.Derive(adjust, key.ChainCode)
+}
+
+// messageHash returns message as 32 byte hash
+func messageHash(msg *big.Int) []byte {
+	return ethCommon.LeftPadBytes(msg.Bytes(), 32)
+}
@@ -0,0 +1,229 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	tssCommon "github.com/binance-chain/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/signing"
+	ecdsaCommon "github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+	ecdsaSigning "github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+)
+
+type SigningTestSuite struct {
+	tsstest.CoordinatorTestSuite
+	presignatures []*signing.PresignaturePool
+	fetchers      []*keyshare.CMPKeyshareStore
+	publicKey     []byte
+}
+
+func TestRunSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningTestSuite))
+}
+
+func (s *SigningTestSuite) SetupTest() {
+	s.CoordinatorTestSuite.SetupTest()
+	s.presignatures = []*signing.PresignaturePool{}
+	s.fetchers = []*keyshare.CMPKeyshareStore{}
+	for i := range s.Hosts {
+		s.presignatures = append(s.presignatures, signing.NewPresignaturePool(1))
+		s.fetchers = append(s.fetchers, keyshare.NewCMPKeyshareStore(fmt.Sprintf("../../../test/keyshares/%d-cmp.keyshare", i)))
+	}
+
+	key, err := s.fetchers[0].GetKeyshare()
+	s.Nil(err)
+	s.publicKey, err = key.Key.PublicPoint().MarshalBinary()
+	s.Nil(err)
+}
+
+// execute runs tss processes created for each host and returns results that are not nil
+func (s *SigningTestSuite) execute(newProcess func(i int, communication comm.Communication) tss.TssProcess, results int) []interface{} {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, newProcess(i, &communication))
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(s.Hosts)*results)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	signatures := []interface{}{}
+	for len(signatures) < results {
+		result := <-resultChn
+		if result != nil {
+			signatures = append(signatures, result)
+		}
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+	return signatures
+}
+
+func (s *SigningTestSuite) presign(sessionID string) {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		presigning, err := signing.NewPresigning(sessionID, host, &communication, s.fetchers[i], s.presignatures[i])
+		s.Nil(err)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, presigning)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, nil)
+		})
+	}
+	err := pool.Wait()
+	s.Nil(err)
+}
+
+func (s *SigningTestSuite) verifySignature(msg *big.Int, signature *tssCommon.SignatureData, publicKey []byte) {
+	s.Equal(signature.M, msg.Bytes())
+
+	sig := append([]byte{}, signature.Signature...)
+	sig = append(sig, signature.SignatureRecovery...)
+	recoveredKey, err := crypto.Ecrecover(ethCommon.LeftPadBytes(msg.Bytes(), 32), sig)
+	s.Nil(err)
+	unmarshalledKey, err := crypto.UnmarshalPubkey(recoveredKey)
+	s.Nil(err)
+	s.Equal(crypto.CompressPubkey(unmarshalledKey), publicKey)
+}
+
+func (s *SigningTestSuite) Test_ValidSigningProcess() {
+	msg := new(big.Int).SetBytes([]byte("Message"))
+
+	results := s.execute(func(i int, communication comm.Communication) tss.TssProcess {
+		signing, err := signing.NewSigning(msg, nil, "signing1", "signing1", s.Hosts[i], communication, s.fetchers[i], s.presignatures[i])
+		s.Nil(err)
+		return signing
+	}, 1)
+
+	s.verifySignature(msg, results[0].(*tssCommon.SignatureData), s.publicKey)
+}
+
+func (s *SigningTestSuite) Test_ValidBatchSigningProcess() {
+	msgs := []*big.Int{
+		new(big.Int).SetBytes([]byte("Message1")),
+		new(big.Int).SetBytes([]byte("Message2")),
+	}
+
+	results := s.execute(func(i int, communication comm.Communication) tss.TssProcess {
+		signing, err := signing.NewBatchSigning(msgs, nil, "batch1", "batch1", s.Hosts[i], communication, s.fetchers[i], s.presignatures[i])
+		s.Nil(err)
+		return signing
+	}, len(msgs))
+
+	for _, result := range results {
+		signature := result.(*ecdsaSigning.BatchSignature)
+		s.verifySignature(msgs[signature.Index], signature.Signature, s.publicKey)
+	}
+}
+
+func (s *SigningTestSuite) Test_ValidSigningProcess_WithPresignature() {
+	s.presign("presign1")
+	presigned := 0
+	for i, pool := range s.presignatures {
+		if pool.Full(s.shareID(i)) {
+			presigned++
+		}
+	}
+	s.Equal(presigned, s.Threshold+1)
+
+	// session IDs are chosen so the presigning coordinator also coordinates signing
+	msg := new(big.Int).SetBytes([]byte("Message"))
+	results := s.execute(func(i int, communication comm.Communication) tss.TssProcess {
+		signing, err := signing.NewSigning(msg, nil, "signing6", "signing6", s.Hosts[i], communication, s.fetchers[i], s.presignatures[i])
+		s.Nil(err)
+		return signing
+	}, 1)
+
+	s.verifySignature(msg, results[0].(*tssCommon.SignatureData), s.publicKey)
+	for i, pool := range s.presignatures {
+		s.False(pool.Full(s.shareID(i)))
+	}
+}
+
+func (s *SigningTestSuite) Test_ValidSigningProcess_WithDerivedKey() {
+	s.presign("presign1")
+
+	key, err := s.fetchers[0].GetKeyshare()
+	s.Nil(err)
+	publicKey, err := btcec.ParsePubKey(s.publicKey)
+	s.Nil(err)
+	derivationPath := ecdsaCommon.DomainDerivationPath(1, 1)
+	_, childKey, err := ecdsaCommon.DeriveKey(publicKey, key.ChainCode, derivationPath)
+	s.Nil(err)
+
+	msg := new(big.Int).SetBytes([]byte("Message"))
+	results := s.execute(func(i int, communication comm.Communication) tss.TssProcess {
+		signing, err := signing.NewSigning(msg, derivationPath, "signing6", "signing6", s.Hosts[i], communication, s.fetchers[i], s.presignatures[i])
+		s.Nil(err)
+		return signing
+	}, 1)
+
+	s.verifySignature(msg, results[0].(*tssCommon.SignatureData), childKey.SerializeCompressed())
+	// presignatures of the MPC key are kept for signing with the MPC key
+	presigned := 0
+	for i, pool := range s.presignatures {
+		if pool.Full(s.shareID(i)) {
+			presigned++
+		}
+	}
+	s.Equal(presigned, s.Threshold+1)
+}
+
+func (s *SigningTestSuite) shareID(i int) string {
+	key, err := s.fetchers[i].GetKeyshare()
+	s.Nil(err)
+	return key.ShareID()
+}
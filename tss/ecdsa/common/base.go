@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"runtime/debug"
+	"sync"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/tss/message"
@@ -35,6 +36,31 @@ type BaseTss struct {
 	Log           zerolog.Logger
 
 	Cancel context.CancelFunc
+
+	partyLock sync.RWMutex
+}
+
+// SetParty sets local party of the tss process
+func (b *BaseTss) SetParty(party Party) {
+	b.partyLock.Lock()
+	defer b.partyLock.Unlock()
+	b.Party = party
+}
+
+// WaitingFor returns peers the current tss round is waiting on
+func (b *BaseTss) WaitingFor() []peer.ID {
+	b.partyLock.RLock()
+	defer b.partyLock.RUnlock()
+	if b.Party == nil {
+		return []peer.ID{}
+	}
+
+	peers, err := PeersFromParties(b.Party.WaitingFor())
+	if err != nil {
+		b.Log.Warn().Err(err).Msg("Failed to parse parties the tss round is waiting for")
+		return []peer.ID{}
+	}
+	return peers
 }
 
 // PopulatePartyStore populates party store map with sorted parties for
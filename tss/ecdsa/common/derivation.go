@@ -0,0 +1,46 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common
+
+import (
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/crypto/ckd"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// HardenedKeyStart is the first index of hardened child keys that can't
+// be derived from the public key
+const HardenedKeyStart uint32 = 0x80000000
+
+// DomainDerivationPath returns non-hardened derivation path of the
+// signing key for the domain and bridge version
+func DomainDerivationPath(domainID uint8, bridgeVersion uint32) []uint32 {
+	return []uint32{uint32(domainID), bridgeVersion}
+}
+
+// DeriveKey derives child public key of the MPC public key with non-hardened BIP-32 derivation
+// and returns the key derivation delta that has to be added to private shares when signing
+// with the child key. Empty derivation path returns zero delta and the MPC public key.
+func DeriveKey(publicKey *btcec.PublicKey, chainCode []byte, derivationPath []uint32) (*big.Int, *btcec.PublicKey, error) {
+	if len(derivationPath) == 0 {
+		return big.NewInt(0), publicKey, nil
+	}
+
+	extendedKey := &ckd.ExtendedKey{
+		PublicKey:  publicKey,
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    chaincfg.MainNetParams.HDPrivateKeyID[:],
+	}
+	delta, childKey, err := ckd.DeriveChildKeyFromHierarchy(derivationPath, extendedKey, tss.S256().Params().N, tss.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+	return delta, childKey.PublicKey, nil
+}
@@ -0,0 +1,72 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+)
+
+type DeriveKeyTestSuite struct {
+	suite.Suite
+	publicKey *btcec.PublicKey
+	chainCode []byte
+}
+
+func TestRunDeriveKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(DeriveKeyTestSuite))
+}
+
+func (s *DeriveKeyTestSuite) SetupTest() {
+	privateKey, err := btcec.NewPrivateKey()
+	s.Nil(err)
+	s.publicKey = privateKey.PubKey()
+	s.chainCode = keyshare.LegacyChainCode(s.publicKey.SerializeCompressed())
+}
+
+func (s *DeriveKeyTestSuite) Test_EmptyPath() {
+	delta, childKey, err := common.DeriveKey(s.publicKey, s.chainCode, []uint32{})
+
+	s.Nil(err)
+	s.Equal(delta.Int64(), int64(0))
+	s.True(childKey.IsEqual(s.publicKey))
+}
+
+func (s *DeriveKeyTestSuite) Test_ChildKeyMatchesDelta() {
+	delta, childKey, err := common.DeriveKey(s.publicKey, s.chainCode, common.DomainDerivationPath(1, 2))
+	s.Nil(err)
+
+	var deltaScalar btcec.ModNScalar
+	deltaScalar.SetByteSlice(delta.Bytes())
+	var deltaPoint, parentPoint, childPoint btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&deltaScalar, &deltaPoint)
+	s.publicKey.AsJacobian(&parentPoint)
+	btcec.AddNonConst(&parentPoint, &deltaPoint, &childPoint)
+	childPoint.ToAffine()
+	s.True(btcec.NewPublicKey(&childPoint.X, &childPoint.Y).IsEqual(childKey))
+}
+
+func (s *DeriveKeyTestSuite) Test_DomainsDeriveDifferentKeys() {
+	_, firstKey, err := common.DeriveKey(s.publicKey, s.chainCode, common.DomainDerivationPath(1, 1))
+	s.Nil(err)
+	_, secondKey, err := common.DeriveKey(s.publicKey, s.chainCode, common.DomainDerivationPath(2, 1))
+	s.Nil(err)
+	_, thirdKey, err := common.DeriveKey(s.publicKey, s.chainCode, common.DomainDerivationPath(1, 2))
+	s.Nil(err)
+
+	s.False(firstKey.IsEqual(secondKey))
+	s.False(firstKey.IsEqual(thirdKey))
+	s.False(firstKey.IsEqual(s.publicKey))
+}
+
+func (s *DeriveKeyTestSuite) Test_HardenedIndex() {
+	_, _, err := common.DeriveKey(s.publicKey, s.chainCode, []uint32{common.HardenedKeyStart})
+
+	s.NotNil(err)
+}
@@ -5,11 +5,14 @@ package keygen
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
 	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/tss"
@@ -20,11 +23,17 @@ import (
 	"github.com/sourcegraph/conc/pool"
 )
 
+type startParams struct {
+	ChainCode []byte `json:"chainCode"`
+}
+
 type ECDSAKeyshareStorer interface {
-	StoreKeyshare(keyshare keyshare.ECDSAKeyshare) error
+	StoreKeyshareVersion(keyshare keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
 	LockKeyshare()
 	UnlockKeyshare()
 	GetKeyshare() (keyshare.ECDSAKeyshare, error)
+	KeyshareExists() bool
 }
 
 type Keygen struct {
@@ -32,6 +41,8 @@ type Keygen struct {
 	storer         ECDSAKeyshareStorer
 	threshold      int
 	subscriptionID comm.SubscriptionID
+	confirmation   *confirmation.Confirmation
+	chainCode      []byte
 }
 
 func NewKeygen(
@@ -42,22 +53,25 @@ func NewKeygen(
 	storer ECDSAKeyshareStorer,
 ) *Keygen {
 	partyStore := make(map[string]*tss.PartyID)
+	peers := host.Peerstore().Peers()
 	return &Keygen{
 		BaseTss: common.BaseTss{
 			PartyStore:    partyStore,
 			Host:          host,
 			Communication: comm,
-			Peers:         host.Peerstore().Peers(),
+			Peers:         peers,
 			SID:           sessionID,
 			Log:           log.With().Str("SessionID", sessionID).Str("Process", "keygen").Logger(),
 			Cancel:        func() {},
 		},
-		storer:    storer,
-		threshold: threshold,
+		storer:       storer,
+		threshold:    threshold,
+		confirmation: confirmation.NewConfirmation(host, comm, sessionID, peers),
 	}
 }
 
 // Run initializes the keygen party and runs the keygen tss process.
+// Params contain the chain code of the new key that the coordinator sends with start message.
 //
 // Should be run only after all the participating parties are ready.
 func (k *Keygen) Run(
@@ -69,6 +83,12 @@ func (k *Keygen) Run(
 	ctx, k.Cancel = context.WithCancel(ctx)
 
 	k.storer.LockKeyshare()
+	startParams, err := unmarshallStartParams(params)
+	if err != nil {
+		return err
+	}
+	k.chainCode = startParams.ChainCode
+
 	parties := common.PartiesFromPeers(k.Host.Peerstore().Peers())
 	k.PopulatePartyStore(parties)
 
@@ -82,12 +102,13 @@ func (k *Keygen) Run(
 	msgChn := make(chan *comm.WrappedMessage)
 	endChn := make(chan keygen.LocalPartySaveData)
 	k.subscriptionID = k.Communication.Subscribe(k.SessionID(), comm.TssKeyGenMsg, msgChn)
+	k.confirmation.Subscribe()
 
 	party, err := keygen.NewLocalParty(tssParams, outChn, endChn, new(big.Int).SetBytes([]byte(k.SessionID())))
 	if err != nil {
 		return err
 	}
-	k.Party = party
+	k.SetParty(party)
 
 	k.Log.Info().Msgf("Started keygen process")
 
@@ -107,6 +128,7 @@ func (k *Keygen) Run(
 // Stop ends all subscriptions created when starting the tss process and unlocks keyshare.
 func (k *Keygen) Stop() {
 	k.Communication.UnSubscribe(k.subscriptionID)
+	k.confirmation.UnSubscribe()
 	k.storer.UnlockKeyshare()
 	k.Cancel()
 }
@@ -127,26 +149,59 @@ func (k *Keygen) ValidCoordinators() []peer.ID {
 	return k.Host.Peerstore().Peers()
 }
 
+// StartParams returns a random chain code of the new key to share with other parties.
 func (k *Keygen) StartParams(readyPeers []peer.ID) []byte {
-	return []byte{}
+	chainCode, err := keyshare.NewChainCode()
+	if err != nil {
+		k.Log.Error().Err(err).Msgf("Failed generating chain code")
+		return []byte{}
+	}
+	paramBytes, _ := json.Marshal(&startParams{ChainCode: chainCode})
+	return paramBytes
 }
 
-// processEndMessage waits for the final message with generated key share and stores it locally.
+func unmarshallStartParams(paramBytes []byte) (startParams, error) {
+	var startParams startParams
+	err := json.Unmarshal(paramBytes, &startParams)
+	if err != nil {
+		return startParams, err
+	}
+	if len(startParams.ChainCode) != keyshare.ChainCodeLength {
+		return startParams, fmt.Errorf("invalid chain code length %d", len(startParams.ChainCode))
+	}
+	return startParams, nil
+}
+
+// processEndMessage waits for the final message with generated key share, confirms the public key
+// and chain code with other parties and stores the key share locally. Existing key share is never replaced,
+// the generated key share has to be activated by the operator in that case.
 func (k *Keygen) processEndMessage(ctx context.Context, endChn chan keygen.LocalPartySaveData) error {
 	defer k.Cancel()
 	for {
 		select {
 		case key := <-endChn:
 			{
-				k.Log.Info().Msgf("Generated key share for address: %s", crypto.PubkeyToAddress(*key.ECDSAPub.ToBtcecPubKey().ToECDSA()))
+				publicKey := key.ECDSAPub.ToBtcecPubKey().ToECDSA()
+				k.Log.Info().Msgf("Generated key share for address: %s", crypto.PubkeyToAddress(*publicKey))
 
-				keyshare := keyshare.NewECDSAKeyshare(key, k.threshold, k.Peers)
-				err := k.storer.StoreKeyshare(keyshare)
+				err := k.confirmation.Confirm(ctx, append(crypto.FromECDSAPub(publicKey), k.chainCode...))
 				if err != nil {
 					return err
 				}
 
-				return nil
+				version, err := k.storer.StoreKeyshareVersion(
+					keyshare.NewECDSAKeyshare(key, k.threshold, k.Peers, k.chainCode),
+					keyshare.Metadata{SessionID: k.SessionID()},
+				)
+				if err != nil {
+					return err
+				}
+
+				if k.storer.KeyshareExists() {
+					k.Log.Warn().Msgf("Keyshare already exists, generated keyshare version %d has to be activated manually", version)
+					return nil
+				}
+				return k.storer.ActivateKeyshare(version)
 			}
 		case <-ctx.Done():
 			{
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/tss"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/keygen"
 	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
@@ -40,19 +41,63 @@ func (s *KeygenTestSuite) Test_ValidKeygenProcess() {
 		communicationMap[host.ID()] = &communication
 		keygen := keygen.NewKeygen("keygen", s.Threshold, host, &communication, s.MockECDSAStorer)
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, keygen)
 	}
 	tsstest.SetupCommunication(communicationMap)
 
 	s.MockECDSAStorer.EXPECT().LockKeyshare().Times(3)
 	s.MockECDSAStorer.EXPECT().UnlockKeyshare().Times(3)
-	s.MockECDSAStorer.EXPECT().StoreKeyshare(gomock.Any()).Times(3)
+	chainCodes := make(chan []byte, 3)
+	s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(key keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error) {
+			chainCodes <- key.ChainCode
+			return 1, nil
+		}).Times(3)
+	s.MockECDSAStorer.EXPECT().KeyshareExists().Return(false).Times(3)
+	s.MockECDSAStorer.EXPECT().ActivateKeyshare(1).Times(3)
 	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
 	for i, coordinator := range coordinators {
 		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, nil) })
 	}
 
+	err := pool.Wait()
+	s.Nil(err)
+	chainCode := <-chainCodes
+	s.Len(chainCode, keyshare.ChainCodeLength)
+	s.Equal(<-chainCodes, chainCode)
+	s.Equal(<-chainCodes, chainCode)
+}
+
+func (s *KeygenTestSuite) Test_ValidKeygenProcess_ExistingKeyshareNotActivated() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for _, host := range s.CoordinatorTestSuite.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		keygen := keygen.NewKeygen("keygen3", s.Threshold, host, &communication, s.MockECDSAStorer)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, keygen)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	s.MockECDSAStorer.EXPECT().LockKeyshare().Times(3)
+	s.MockECDSAStorer.EXPECT().UnlockKeyshare().Times(3)
+	s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(2, nil).Times(3)
+	s.MockECDSAStorer.EXPECT().KeyshareExists().Return(true).Times(3)
+	s.MockECDSAStorer.EXPECT().ActivateKeyshare(gomock.Any()).Times(0)
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		i, coordinator := i, coordinator
+		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, nil) })
+	}
+
 	err := pool.Wait()
 	s.Nil(err)
 }
@@ -69,7 +114,7 @@ func (s *KeygenTestSuite) Test_KeygenTimeout() {
 		communicationMap[host.ID()] = &communication
 		keygen := keygen.NewKeygen("keygen2", s.Threshold, host, &communication, s.MockECDSAStorer)
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
 		coordinator.TssTimeout = time.Millisecond
 		coordinators = append(coordinators, coordinator)
 		processes = append(processes, keygen)
@@ -78,7 +123,7 @@ func (s *KeygenTestSuite) Test_KeygenTimeout() {
 
 	s.MockECDSAStorer.EXPECT().LockKeyshare().AnyTimes()
 	s.MockECDSAStorer.EXPECT().UnlockKeyshare().AnyTimes()
-	s.MockECDSAStorer.EXPECT().StoreKeyshare(gomock.Any()).Times(0)
+	s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Times(0)
 	pool := pool.New().WithContext(context.Background())
 	for i, coordinator := range coordinators {
 		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, nil) })
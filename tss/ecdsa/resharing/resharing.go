@@ -4,6 +4,7 @@
 package resharing
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -26,11 +27,13 @@ import (
 type startParams struct {
 	OldThreshold int       `json:"oldThreshold"`
 	OldSubset    []peer.ID `json:"oldSubset"`
+	ChainCode    []byte    `json:"chainCode"`
 }
 
 type SaveDataStorer interface {
 	GetKeyshare() (keyshare.ECDSAKeyshare, error)
-	StoreKeyshare(keyshare keyshare.ECDSAKeyshare) error
+	StoreKeyshareVersion(keyshare keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
 	LockKeyshare()
 	UnlockKeyshare()
 }
@@ -41,6 +44,10 @@ type Resharing struct {
 	subscriptionID comm.SubscriptionID
 	storer         SaveDataStorer
 	newThreshold   int
+	topologyHash   string
+	chainCode      []byte
+	// deferActivation is set if the reshared keyshare is activated by the caller
+	deferActivation bool
 }
 
 func NewResharing(
@@ -49,6 +56,7 @@ func NewResharing(
 	host host.Host,
 	comm comm.Communication,
 	storer SaveDataStorer,
+	topologyHash string,
 ) *Resharing {
 	storer.LockKeyshare()
 	var key keyshare.ECDSAKeyshare
@@ -72,9 +80,16 @@ func NewResharing(
 		key:          key,
 		storer:       storer,
 		newThreshold: threshold,
+		topologyHash: topologyHash,
 	}
 }
 
+// DeferActivation stores the reshared keyshare without activating it. Stored version is sent
+// to the result channel so it can be activated together with keyshares reshared in other sessions.
+func (r *Resharing) DeferActivation() {
+	r.deferActivation = true
+}
+
 // Run initializes the signing party and runs the resharing tss process.
 // Params contains peer subset that leaders sends with start message.
 func (r *Resharing) Run(
@@ -89,6 +104,7 @@ func (r *Resharing) Run(
 	if err != nil {
 		return err
 	}
+	r.chainCode = startParams.ChainCode
 
 	oldParties := common.PartiesFromPeers(startParams.OldSubset)
 	oldCtx := tss.NewPeerContext(oldParties)
@@ -114,15 +130,16 @@ func (r *Resharing) Run(
 	msgChn := make(chan *comm.WrappedMessage)
 	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
 
-	r.Party, err = resharing.NewLocalParty(tssParams, r.key.Key, outChn, endChn, new(big.Int).SetBytes([]byte(r.SID)))
+	party, err := resharing.NewLocalParty(tssParams, r.key.Key, outChn, endChn, new(big.Int).SetBytes([]byte(r.SID)))
 	if err != nil {
 		return err
 	}
+	r.SetParty(party)
 
 	p := pool.New().WithContext(ctx).WithCancelOnError()
 	p.Go(func(ctx context.Context) error { return r.ProcessOutboundMessages(ctx, outChn, comm.TssReshareMsg) })
 	p.Go(func(ctx context.Context) error { return r.ProcessInboundMessages(ctx, msgChn) })
-	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx, endChn) })
+	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx, endChn, resultChn) })
 
 	r.Log.Info().Msgf("Started resharing process")
 
@@ -163,12 +180,13 @@ func (r *Resharing) ValidCoordinators() []peer.ID {
 	return validCoordinators
 }
 
-// StartParams returns threshold and peer subset from the old key to share with new parties.
+// StartParams returns threshold, peer subset and chain code from the old key to share with new parties.
 func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
 	oldSubset := common.PeersIntersection(r.key.Peers, r.Host.Peerstore().Peers())
 	startParams := &startParams{
 		OldThreshold: r.key.Threshold,
 		OldSubset:    oldSubset,
+		ChainCode:    r.key.ChainCode,
 	}
 	paramBytes, _ := json.Marshal(startParams)
 	return paramBytes
@@ -196,6 +214,13 @@ func (r *Resharing) validateStartParams(params startParams) error {
 	if len(params.OldSubset) < params.OldThreshold {
 		return errors.New("threshold bigger then subset")
 	}
+	if len(params.ChainCode) != keyshare.ChainCodeLength {
+		return errors.New("invalid chain code")
+	}
+	// chain code has to stay the same so the derived keys don't change with resharing
+	if len(r.key.ChainCode) != 0 && !bytes.Equal(params.ChainCode, r.key.ChainCode) {
+		return errors.New("invalid chain code in start params")
+	}
 
 	slices.Sort(params.OldSubset)
 	slices.Sort(r.key.Peers)
@@ -208,8 +233,8 @@ func (r *Resharing) validateStartParams(params startParams) error {
 	return nil
 }
 
-// processEndMessage routes signature to result channel.
-func (r *Resharing) processEndMessage(ctx context.Context, endChn chan keygen.LocalPartySaveData) error {
+// processEndMessage stores the reshared keyshare and activates it unless activation is deferred.
+func (r *Resharing) processEndMessage(ctx context.Context, endChn chan keygen.LocalPartySaveData, resultChn chan interface{}) error {
 	defer r.Cancel()
 	for {
 		select {
@@ -217,9 +242,19 @@ func (r *Resharing) processEndMessage(ctx context.Context, endChn chan keygen.Lo
 			{
 				r.Log.Info().Msg("Successfully reshared key")
 
-				keyshare := keyshare.NewECDSAKeyshare(key, r.newThreshold, r.Peers)
-				err := r.storer.StoreKeyshare(keyshare)
-				return err
+				version, err := r.storer.StoreKeyshareVersion(
+					keyshare.NewECDSAKeyshare(key, r.newThreshold, r.Peers, r.chainCode),
+					keyshare.Metadata{SessionID: r.SessionID(), TopologyHash: r.topologyHash},
+				)
+				if err != nil {
+					return err
+				}
+
+				if r.deferActivation {
+					resultChn <- version
+					return nil
+				}
+				return r.storer.ActivateKeyshare(version)
 			}
 		case <-ctx.Done():
 			{
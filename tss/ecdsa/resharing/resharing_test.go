@@ -46,6 +46,7 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 		}
 	}
 
+	chainCodes := make(chan []byte, len(hosts))
 	for i, host := range hosts {
 		communication := tsstest.TestCommunication{
 			Host:          host,
@@ -57,10 +58,15 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 		s.MockECDSAStorer.EXPECT().LockKeyshare()
 		s.MockECDSAStorer.EXPECT().UnlockKeyshare()
 		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
-		s.MockECDSAStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockECDSAStorer)
+		s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(key keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error) {
+				chainCodes <- key.ChainCode
+				return 1, nil
+			})
+		s.MockECDSAStorer.EXPECT().ActivateKeyshare(1)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockECDSAStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -73,6 +79,60 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 		})
 	}
 
+	err := pool.Wait()
+	s.Nil(err)
+	oldKey, err := keyshare.NewECDSAKeyshareStore("../../test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	for range hosts {
+		s.Equal(<-chainCodes, oldKey.ChainCode)
+	}
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_SamePeers() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	hosts := []host.Host{}
+	for i := 0; i < s.PartyNumber; i++ {
+		host, _ := tsstest.NewHost(i)
+		hosts = append(hosts, host)
+	}
+	for _, host := range hosts {
+		for _, peer := range hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	for i, host := range hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		storer := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+		share, _ := storer.GetKeyshare()
+		s.MockECDSAStorer.EXPECT().LockKeyshare()
+		s.MockECDSAStorer.EXPECT().UnlockKeyshare()
+		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
+		s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(1, nil)
+		s.MockECDSAStorer.EXPECT().ActivateKeyshare(1)
+		resharing := resharing.NewResharing("resharing-proactive", 1, host, &communication, s.MockECDSAStorer, "")
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{})
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		i, coordinator := i, coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+		})
+	}
+
 	err := pool.Wait()
 	s.Nil(err)
 }
@@ -104,10 +164,11 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_RemovePeer() {
 		s.MockECDSAStorer.EXPECT().LockKeyshare()
 		s.MockECDSAStorer.EXPECT().UnlockKeyshare()
 		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
-		s.MockECDSAStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockECDSAStorer)
+		s.MockECDSAStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(1, nil)
+		s.MockECDSAStorer.EXPECT().ActivateKeyshare(1)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockECDSAStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -155,9 +216,9 @@ func (s *ResharingTestSuite) Test_InvalidResharingProcess_InvalidOldThreshold_Le
 		s.MockECDSAStorer.EXPECT().LockKeyshare().AnyTimes()
 		s.MockECDSAStorer.EXPECT().UnlockKeyshare().AnyTimes()
 		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
-		resharing := resharing.NewResharing("resharing3", 1, host, &communication, s.MockECDSAStorer)
+		resharing := resharing.NewResharing("resharing3", 1, host, &communication, s.MockECDSAStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -204,9 +265,9 @@ func (s *ResharingTestSuite) Test_InvalidResharingProcess_InvalidOldThreshold_Bi
 		s.MockECDSAStorer.EXPECT().LockKeyshare()
 		s.MockECDSAStorer.EXPECT().UnlockKeyshare().AnyTimes()
 		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
-		resharing := resharing.NewResharing("resharing4", 1, host, &communication, s.MockECDSAStorer)
+		resharing := resharing.NewResharing("resharing4", 1, host, &communication, s.MockECDSAStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -222,3 +283,52 @@ func (s *ResharingTestSuite) Test_InvalidResharingProcess_InvalidOldThreshold_Bi
 	err := pool.Wait()
 	s.NotNil(err)
 }
+
+func (s *ResharingTestSuite) Test_InvalidResharingProcess_InvalidChainCode() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	hosts := []host.Host{}
+	for i := 0; i < s.PartyNumber+1; i++ {
+		host, _ := tsstest.NewHost(i)
+		hosts = append(hosts, host)
+	}
+	for _, host := range hosts {
+		for _, peer := range hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	for i, host := range hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		storer := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+		share, _ := storer.GetKeyshare()
+
+		// set chain code to invalid value
+		share.ChainCode = []byte{1}
+
+		s.MockECDSAStorer.EXPECT().LockKeyshare().AnyTimes()
+		s.MockECDSAStorer.EXPECT().UnlockKeyshare().AnyTimes()
+		s.MockECDSAStorer.EXPECT().GetKeyshare().Return(share, nil)
+		resharing := resharing.NewResharing("resharing-chain-code", 1, host, &communication, s.MockECDSAStorer, "")
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{})
+	pool := pool.New().WithContext(context.Background())
+	for i, coordinator := range coordinators {
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+		})
+	}
+	err := pool.Wait()
+	s.NotNil(err)
+}
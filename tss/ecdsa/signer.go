@@ -0,0 +1,117 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/libp2p/go-libp2p/core/host"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	cmpSigning "github.com/ChainSafe/sygma-relayer/tss/ecdsa/cmp/signing"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+)
+
+// SigningFactory creates ECDSA signing processes with the configured ECDSA protocol.
+// Processes sign with the child key of the derivation path or with the MPC key if it is empty.
+type SigningFactory interface {
+	NewSigning(msg *big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error)
+	NewBatchSigning(msgs []*big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error)
+	// PublicKey returns public key that signs with the derivation path
+	PublicKey(derivationPath []uint32) (*btcec.PublicKey, error)
+}
+
+// GG18SigningFactory creates signing processes with GG18 keyshares
+type GG18SigningFactory struct {
+	host    host.Host
+	comm    comm.Communication
+	fetcher signing.SaveDataFetcher
+}
+
+func NewGG18SigningFactory(host host.Host, comm comm.Communication, fetcher signing.SaveDataFetcher) *GG18SigningFactory {
+	return &GG18SigningFactory{
+		host:    host,
+		comm:    comm,
+		fetcher: fetcher,
+	}
+}
+
+func (f *GG18SigningFactory) NewSigning(msg *big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error) {
+	return signing.NewSigning(msg, derivationPath, messageID, sessionID, f.host, f.comm, f.fetcher)
+}
+
+func (f *GG18SigningFactory) NewBatchSigning(msgs []*big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error) {
+	return signing.NewBatchSigning(msgs, derivationPath, messageID, sessionID, f.host, f.comm, f.fetcher)
+}
+
+func (f *GG18SigningFactory) PublicKey(derivationPath []uint32) (*btcec.PublicKey, error) {
+	f.fetcher.LockKeyshare()
+	defer f.fetcher.UnlockKeyshare()
+	key, err := f.fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+	if key.Key.ECDSAPub == nil {
+		return nil, fmt.Errorf("missing public key in keyshare")
+	}
+
+	_, publicKey, err := common.DeriveKey(key.Key.ECDSAPub.ToBtcecPubKey(), key.ChainCode, derivationPath)
+	return publicKey, err
+}
+
+// CMPSigningFactory creates signing processes with CMP keyshares. It is used only after
+// all relayers migrated their GG18 keyshares and switched signing to CMP in the configuration,
+// so that relayers never choose different protocols for the same session.
+type CMPSigningFactory struct {
+	host          host.Host
+	comm          comm.Communication
+	fetcher       cmpSigning.SaveDataFetcher
+	presignatures *cmpSigning.PresignaturePool
+}
+
+func NewCMPSigningFactory(
+	host host.Host,
+	comm comm.Communication,
+	fetcher cmpSigning.SaveDataFetcher,
+	presignatures *cmpSigning.PresignaturePool,
+) *CMPSigningFactory {
+	return &CMPSigningFactory{
+		host:          host,
+		comm:          comm,
+		fetcher:       fetcher,
+		presignatures: presignatures,
+	}
+}
+
+func (f *CMPSigningFactory) NewSigning(msg *big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error) {
+	return cmpSigning.NewSigning(msg, derivationPath, messageID, sessionID, f.host, f.comm, f.fetcher, f.presignatures)
+}
+
+func (f *CMPSigningFactory) NewBatchSigning(msgs []*big.Int, derivationPath []uint32, messageID string, sessionID string) (tss.TssProcess, error) {
+	return cmpSigning.NewBatchSigning(msgs, derivationPath, messageID, sessionID, f.host, f.comm, f.fetcher, f.presignatures)
+}
+
+func (f *CMPSigningFactory) PublicKey(derivationPath []uint32) (*btcec.PublicKey, error) {
+	f.fetcher.LockKeyshare()
+	defer f.fetcher.UnlockKeyshare()
+	key, err := f.fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+	publicPoint, err := key.Key.PublicPoint().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := btcec.ParsePubKey(publicPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	_, publicKey, err = common.DeriveKey(publicKey, key.ChainCode, derivationPath)
+	return publicKey, err
+}
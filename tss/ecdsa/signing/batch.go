@@ -0,0 +1,343 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	tssCommon "github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"golang.org/x/exp/slices"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	errors "github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
+	"github.com/ChainSafe/sygma-relayer/tss/message"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+)
+
+// BatchSignature is the signature of the message at index of the signing batch
+type BatchSignature struct {
+	Index     int
+	Signature *tssCommon.SignatureData
+}
+
+// BatchSigning signs multiple messages with the same peer subset in a single tss session.
+// Local party is started for each message and party messages are multiplexed over the
+// session subscription.
+type BatchSigning struct {
+	*Signing
+	msgs []*big.Int
+
+	parties     []common.Party
+	partiesLock sync.RWMutex
+}
+
+func NewBatchSigning(
+	msgs []*big.Int,
+	derivationPath []uint32,
+	messageID string,
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+) (*BatchSigning, error) {
+	signing, err := NewSigning(nil, derivationPath, messageID, sessionID, host, comm, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	signing.Log = log.With().Str("SessionID", sessionID).Str("messageID", messageID).Str("Process", "batch-signing").Logger()
+
+	return &BatchSigning{
+		Signing: signing,
+		msgs:    msgs,
+		parties: []common.Party{},
+	}, nil
+}
+
+// Run initializes a signing party for each message and runs the signing tss processes.
+// Params contains peer subset that leaders sends with start message.
+func (s *BatchSigning) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	s.coordinator = coordinator
+	s.resultChn = resultChn
+	ctx, s.Cancel = context.WithCancel(ctx)
+
+	peerSubset, err := s.unmarshallStartParams(params)
+	if err != nil {
+		return err
+	}
+
+	if !util.IsParticipant(s.Host.ID(), peerSubset) {
+		return &errors.SubsetError{Peer: s.Host.ID()}
+	}
+
+	s.Peers = peerSubset
+	parties := common.PartiesFromPeers(s.Peers)
+	s.PopulatePartyStore(parties)
+	pCtx := tss.NewPeerContext(parties)
+	tssParams, err := tss.NewParameters(tss.S256(), pCtx, s.PartyStore[s.Host.ID().Pretty()], len(parties), s.key.Threshold)
+	if err != nil {
+		return err
+	}
+
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	endChn := make(chan *BatchSignature)
+	localParties := make([]common.Party, len(s.msgs))
+	for i, msg := range s.msgs {
+		index := i
+		sigChn := make(chan tssCommon.SignatureData)
+		outChn := make(chan tss.Message)
+		party, err := signing.NewLocalParty(
+			msg,
+			tssParams,
+			s.key.Key,
+			s.keyDerivationDelta,
+			outChn,
+			sigChn,
+			s.ssid(index))
+		if err != nil {
+			return err
+		}
+		localParties[index] = party
+
+		p.Go(func(ctx context.Context) error { return s.processOutboundMessages(ctx, index, outChn) })
+		p.Go(func(ctx context.Context) error {
+			sig, ok := receiveSignature(ctx, sigChn)
+			if !ok {
+				return nil
+			}
+
+			select {
+			case endChn <- &BatchSignature{Index: index, Signature: sig}:
+			case <-ctx.Done():
+			}
+			return nil
+		})
+	}
+	s.setParties(localParties)
+
+	msgChn := make(chan *comm.WrappedMessage)
+	s.subscriptionID = s.Communication.Subscribe(s.SessionID(), comm.TssKeySignMsg, msgChn)
+
+	p.Go(func(ctx context.Context) error { return s.processInboundMessages(ctx, msgChn) })
+	p.Go(func(ctx context.Context) error { return s.processEndMessages(ctx, endChn) })
+	p.Go(func(ctx context.Context) error { return s.monitorSigning(ctx) })
+
+	s.Log.Info().Msgf("Started batch signing process for %d messages", len(s.msgs))
+
+	for _, party := range localParties {
+		tssError := party.Start()
+		if tssError != nil {
+			return tssError
+		}
+	}
+
+	return p.Wait()
+}
+
+// receiveSignature waits for the signature of a local party. Signature data contains
+// a lock, so the received signature is stored through a pointer instead of being copied.
+func receiveSignature(ctx context.Context, sigChn chan tssCommon.SignatureData) (*tssCommon.SignatureData, bool) {
+	chosen, sig, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sigChn)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	})
+	if chosen != 0 || !ok {
+		return nil, false
+	}
+
+	signature := reflect.New(sig.Type())
+	signature.Elem().Set(sig)
+	return signature.Interface().(*tssCommon.SignatureData), true
+}
+
+// WaitingFor returns peers the current tss round of any of the local parties is waiting on
+func (s *BatchSigning) WaitingFor() []peer.ID {
+	s.partiesLock.RLock()
+	defer s.partiesLock.RUnlock()
+
+	waitingFor := make([]peer.ID, 0)
+	for _, party := range s.parties {
+		peers, err := common.PeersFromParties(party.WaitingFor())
+		if err != nil {
+			s.Log.Warn().Err(err).Msg("Failed to parse parties the tss round is waiting for")
+			continue
+		}
+		for _, peer := range peers {
+			if !slices.Contains(waitingFor, peer) {
+				waitingFor = append(waitingFor, peer)
+			}
+		}
+	}
+	return waitingFor
+}
+
+func (s *BatchSigning) setParties(parties []common.Party) {
+	s.partiesLock.Lock()
+	defer s.partiesLock.Unlock()
+	s.parties = parties
+}
+
+func (s *BatchSigning) party(index int) (common.Party, error) {
+	s.partiesLock.RLock()
+	defer s.partiesLock.RUnlock()
+	if index < 0 || index >= len(s.parties) {
+		return nil, fmt.Errorf("invalid batch index %d", index)
+	}
+	return s.parties[index], nil
+}
+
+// ssid returns unique tss session ID of the local party signing the message at index
+func (s *BatchSigning) ssid(index int) *big.Int {
+	return new(big.Int).SetBytes([]byte(fmt.Sprintf("%s-%d", s.SID, index)))
+}
+
+// processInboundMessages routes batch messages from tss parties to the local party of the message index.
+func (s *BatchSigning) processInboundMessages(ctx context.Context, msgChan chan *comm.WrappedMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", string(debug.Stack()))
+		}
+	}()
+
+	for {
+		select {
+		case wMsg := <-msgChan:
+			{
+				s.Log.Debug().Msgf("processed inbound message from %s", wMsg.From)
+
+				msg, err := message.UnmarshalBatchTssMessage(wMsg.Payload)
+				if err != nil {
+					return err
+				}
+				party, err := s.party(msg.Index)
+				if err != nil {
+					return err
+				}
+
+				ok, err := party.UpdateFromBytes(
+					msg.MsgBytes,
+					s.PartyStore[wMsg.From.Pretty()],
+					msg.IsBroadcast,
+					s.ssid(msg.Index))
+				if !ok {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// processOutboundMessages sends messages of the local party signing the message at index to target peers.
+func (s *BatchSigning) processOutboundMessages(ctx context.Context, index int, outChn chan tss.Message) error {
+	for {
+		select {
+		case msg := <-outChn:
+			{
+				s.Log.Debug().Msg(msg.String())
+				wireBytes, routing, err := msg.WireBytes()
+				if err != nil {
+					return err
+				}
+
+				msgBytes, err := message.MarshalBatchTssMessage(index, wireBytes, routing.IsBroadcast)
+				if err != nil {
+					return err
+				}
+
+				peers, err := s.BroadcastPeers(msg)
+				if err != nil {
+					return err
+				}
+
+				s.Log.Debug().Msgf("sending message to %s", peers)
+				err = s.Communication.Broadcast(peers, msgBytes, comm.TssKeySignMsg, s.SessionID())
+				if err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// processEndMessages routes batch signatures to result channel
+// and ends the process when all messages are signed.
+func (s *BatchSigning) processEndMessages(ctx context.Context, endChn chan *BatchSignature) error {
+	defer s.Cancel()
+	signed := 0
+	for {
+		select {
+		case sig := <-endChn:
+			{
+				s.Log.Info().Msgf("Successfully generated signature for message %d", sig.Index)
+
+				signed++
+				if s.coordinator {
+					s.resultChn <- sig
+				} else if signed == len(s.msgs) {
+					s.resultChn <- nil
+				}
+
+				if signed == len(s.msgs) {
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
+
+// monitorSigning checks if the process is stuck and waiting for peers and sends an error
+// if it is
+func (s *BatchSigning) monitorSigning(ctx context.Context) error {
+	defer s.Cancel()
+	waitingFor := make([]peer.ID, 0)
+	ticker := time.NewTicker(time.Minute * 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			{
+				if len(waitingFor) != 0 && reflect.DeepEqual(s.WaitingFor(), waitingFor) {
+					err := &comm.CommunicationError{
+						Err: fmt.Errorf("waiting for peers %s", waitingFor),
+					}
+					return err
+				}
+
+				waitingFor = s.WaitingFor()
+			}
+		case <-ctx.Done():
+			{
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchSigningTestSuite struct {
+	tsstest.CoordinatorTestSuite
+}
+
+func TestRunBatchSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchSigningTestSuite))
+}
+
+func (s *BatchSigningTestSuite) Test_ValidBatchSigningProcess() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	msgs := []*big.Int{
+		new(big.Int).SetBytes([]byte("Message1")),
+		new(big.Int).SetBytes([]byte("Message2")),
+		new(big.Int).SetBytes([]byte("Message3")),
+	}
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+
+		batchSigning, err := signing.NewBatchSigning(msgs, nil, "batch1", "batch1", host, &communication, fetcher)
+		if err != nil {
+			panic(err)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, batchSigning)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(msgs)+1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+		})
+	}
+
+	signatures := make(map[int][]byte)
+	for i := 0; i < len(msgs)+1; i++ {
+		result := <-resultChn
+		if result == nil {
+			continue
+		}
+
+		sig := result.(*signing.BatchSignature)
+		signatures[sig.Index] = sig.Signature.M
+	}
+	s.Equal(signatures, map[int][]byte{
+		0: msgs[0].Bytes(),
+		1: msgs[1].Bytes(),
+		2: msgs[2].Bytes(),
+	})
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	tssCommon "github.com/binance-chain/tss-lib/common"
+	"github.com/binance-chain/tss-lib/ecdsa/keygen"
 	"github.com/binance-chain/tss-lib/ecdsa/signing"
 	"github.com/binance-chain/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -35,15 +36,20 @@ type SaveDataFetcher interface {
 
 type Signing struct {
 	common.BaseTss
-	coordinator    bool
-	key            keyshare.ECDSAKeyshare
-	msg            *big.Int
-	resultChn      chan interface{}
-	subscriptionID comm.SubscriptionID
+	util.TieredReadiness
+	coordinator        bool
+	key                keyshare.ECDSAKeyshare
+	keyDerivationDelta *big.Int
+	msg                *big.Int
+	resultChn          chan interface{}
+	subscriptionID     comm.SubscriptionID
 }
 
+// NewSigning creates signing process that signs the message with the child key
+// of the derivation path. Empty derivation path signs with the MPC key.
 func NewSigning(
 	msg *big.Int,
+	derivationPath []uint32,
 	messageID string,
 	sessionID string,
 	host host.Host,
@@ -56,6 +62,10 @@ func NewSigning(
 	if err != nil {
 		return nil, err
 	}
+	keyDerivationDelta, err := deriveKey(&key, derivationPath)
+	if err != nil {
+		return nil, err
+	}
 
 	partyStore := make(map[string]*tss.PartyID)
 	return &Signing{
@@ -68,8 +78,9 @@ func NewSigning(
 			Log:           log.With().Str("SessionID", sessionID).Str("messageID", messageID).Str("Process", "signing").Logger(),
 			Cancel:        func() {},
 		},
-		key: key,
-		msg: msg,
+		key:                key,
+		keyDerivationDelta: keyDerivationDelta,
+		msg:                msg,
 	}, nil
 }
 
@@ -105,18 +116,18 @@ func (s *Signing) Run(
 
 	sigChn := make(chan tssCommon.SignatureData)
 	outChn := make(chan tss.Message)
-	kdd := big.NewInt(0)
-	s.Party, err = signing.NewLocalParty(
+	party, err := signing.NewLocalParty(
 		s.msg,
 		tssParams,
 		s.key.Key,
-		kdd,
+		s.keyDerivationDelta,
 		outChn,
 		sigChn,
 		new(big.Int).SetBytes([]byte(s.SID)))
 	if err != nil {
 		return err
 	}
+	s.SetParty(party)
 
 	msgChn := make(chan *comm.WrappedMessage)
 	s.subscriptionID = s.Communication.Subscribe(s.SessionID(), comm.TssKeySignMsg, msgChn)
@@ -144,10 +155,11 @@ func (s *Signing) Stop() {
 	s.Cancel()
 }
 
-// Ready returns true if threshold+1 parties are ready to start the signing process.
+// Ready returns true if threshold+1 well-behaved parties are ready to start the signing process.
+// Parties with worse reputation are accepted if all parties are ready or if not enough
+// well-behaved parties got ready during the grace period.
 func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
-	readyPeers = s.readyParticipants(readyPeers)
-	return len(readyPeers) == s.key.Threshold+1, nil
+	return s.TiersReady(s.readyParticipants(readyPeers), s.key.Peers, s.key.Threshold), nil
 }
 
 // ValidCoordinators returns only peers that have a valid keyshare
@@ -156,14 +168,14 @@ func (s *Signing) ValidCoordinators() []peer.ID {
 }
 
 // StartParams returns peer subset for this tss process. It is calculated
-// by sorting hashes of peer IDs and session ID and chosing ready peers alphabetically
-// until threshold is satisfied.
+// by sorting ready peers by reputation tier and hashes of peer IDs and session ID
+// and chosing ready peers in order until threshold is satisfied.
 func (s *Signing) StartParams(readyPeers []peer.ID) []byte {
 	readyPeers = s.readyParticipants(readyPeers)
 	peers := []peer.ID{}
 	peers = append(peers, readyPeers...)
 
-	sortedPeers := util.SortPeersForSession(peers, s.SessionID())
+	sortedPeers := util.SortPeersByTier(peers, s.SessionID(), s.PeerTiers())
 	peerSubset := []peer.ID{}
 	for _, peer := range sortedPeers {
 		peerSubset = append(peerSubset, peer.ID)
@@ -227,6 +239,29 @@ func (s *Signing) readyParticipants(readyPeers []peer.ID) []peer.ID {
 	return readyParticipants
 }
 
+// deriveKey adjusts public key and public shares of the keyshare to the child key of the
+// derivation path and returns the key derivation delta added to the private share when signing
+func deriveKey(key *keyshare.ECDSAKeyshare, derivationPath []uint32) (*big.Int, error) {
+	if len(derivationPath) == 0 {
+		return big.NewInt(0), nil
+	}
+	if key.Key.ECDSAPub == nil {
+		return nil, fmt.Errorf("missing public key for derivation path %v", derivationPath)
+	}
+
+	keyDerivationDelta, childKey, err := common.DeriveKey(key.Key.ECDSAPub.ToBtcecPubKey(), key.ChainCode, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+	keys := []keygen.LocalPartySaveData{key.Key}
+	err = signing.UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, childKey, tss.S256())
+	if err != nil {
+		return nil, err
+	}
+	key.Key = keys[0]
+	return keyDerivationDelta, nil
+}
+
 func (s *Signing) Retryable() bool {
 	return true
 }
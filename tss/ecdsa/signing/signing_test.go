@@ -5,6 +5,7 @@ package signing_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -14,9 +15,14 @@ import (
 	"github.com/ChainSafe/sygma-relayer/comm/elector"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
 	"github.com/ChainSafe/sygma-relayer/tss"
+	ecdsaCommon "github.com/ChainSafe/sygma-relayer/tss/ecdsa/common"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/keygen"
 	"github.com/ChainSafe/sygma-relayer/tss/ecdsa/signing"
 	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+	tssCommon "github.com/binance-chain/tss-lib/common"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/stretchr/testify/suite"
@@ -46,12 +52,12 @@ func (s *SigningTestSuite) Test_ValidSigningProcess() {
 		msgBytes := []byte("Message")
 		msg := big.NewInt(0)
 		msg.SetBytes(msgBytes)
-		signing, err := signing.NewSigning(msg, "signing1", "signing1", host, &communication, fetcher)
+		signing, err := signing.NewSigning(msg, nil, "signing1", "signing1", host, &communication, fetcher)
 		if err != nil {
 			panic(err)
 		}
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, signing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -80,6 +86,63 @@ func (s *SigningTestSuite) Test_ValidSigningProcess() {
 	s.Nil(err)
 }
 
+func (s *SigningTestSuite) Test_ValidSigningProcess_WithDerivedKey() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	key, err := keyshare.NewECDSAKeyshareStore("../../test/keyshares/0.keyshare").GetKeyshare()
+	s.Nil(err)
+	derivationPath := ecdsaCommon.DomainDerivationPath(1, 1)
+	_, childKey, err := ecdsaCommon.DeriveKey(key.Key.ECDSAPub.ToBtcecPubKey(), key.ChainCode, derivationPath)
+	s.Nil(err)
+
+	msg := new(big.Int).SetBytes([]byte("Message"))
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+		signing, err := signing.NewSigning(msg, derivationPath, "signing1", "signing1", host, &communication, fetcher)
+		s.Nil(err)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(s.Hosts))
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	var signature *tssCommon.SignatureData
+	for signature == nil {
+		result := <-resultChn
+		if result != nil {
+			signature = result.(*tssCommon.SignatureData)
+		}
+	}
+	sig := append([]byte{}, signature.Signature...)
+	sig = append(sig, signature.SignatureRecovery...)
+	publicKey, err := crypto.SigToPub(ethCommon.LeftPadBytes(msg.Bytes(), 32), sig)
+	s.Nil(err)
+	s.Equal(crypto.PubkeyToAddress(*publicKey), crypto.PubkeyToAddress(*childKey.ToECDSA()))
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err = pool.Wait()
+	s.Nil(err)
+}
+
 func (s *SigningTestSuite) Test_SigningTimeout() {
 	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
 	coordinators := []*tss.Coordinator{}
@@ -96,12 +159,12 @@ func (s *SigningTestSuite) Test_SigningTimeout() {
 		msgBytes := []byte("Message")
 		msg := big.NewInt(0)
 		msg.SetBytes(msgBytes)
-		signing, err := signing.NewSigning(msg, "signing2", "signing2", host, &communication, fetcher)
+		signing, err := signing.NewSigning(msg, nil, "signing2", "signing2", host, &communication, fetcher)
 		if err != nil {
 			panic(err)
 		}
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
 		coordinator.TssTimeout = time.Nanosecond
 		coordinators = append(coordinators, coordinator)
 		processes = append(processes, signing)
@@ -133,7 +196,7 @@ func (s *SigningTestSuite) Test_PendingProcessExists() {
 		communicationMap[host.ID()] = &communication
 		keygen := keygen.NewKeygen("keygen3", s.Threshold, host, &communication, s.MockECDSAStorer)
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, keygen)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -149,3 +212,99 @@ func (s *SigningTestSuite) Test_PendingProcessExists() {
 	err := pool.Wait()
 	s.NotNil(err)
 }
+
+func (s *SigningTestSuite) Test_StartParams_PrefersWellBehavedPeers() {
+	host := s.Hosts[0]
+	communication := tsstest.TestCommunication{
+		Host:          host,
+		Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+	}
+	fetcher := keyshare.NewECDSAKeyshareStore("../../test/keyshares/0.keyshare")
+	signing, err := signing.NewSigning(big.NewInt(1), nil, "signing1", "signing1", host, &communication, fetcher)
+	s.Nil(err)
+	faultyPeer := s.Hosts[1].ID()
+	signing.SetPeerTiers(util.PeerTiers{faultyPeer: 2})
+
+	ready, err := signing.Ready([]peer.ID{s.Hosts[0].ID(), faultyPeer}, []peer.ID{})
+	s.Nil(err)
+	s.False(ready)
+
+	readyPeers := []peer.ID{s.Hosts[0].ID(), faultyPeer, s.Hosts[2].ID()}
+	ready, err = signing.Ready(readyPeers, []peer.ID{})
+	s.Nil(err)
+	s.True(ready)
+
+	var peerSubset []peer.ID
+	err = json.Unmarshal(signing.StartParams(readyPeers), &peerSubset)
+	s.Nil(err)
+	s.ElementsMatch(peerSubset, []peer.ID{s.Hosts[0].ID(), s.Hosts[2].ID()})
+}
+
+type noopQueueMeter struct{}
+
+func (m noopQueueMeter) TrackTssQueueDepth(priority string, domainID uint8, depth int) {}
+func (m noopQueueMeter) TrackTssRunningSessions(running int)                           {}
+
+func (s *SigningTestSuite) Test_MultipleSessions_MaxConcurrentSessionsBelowSessionCount() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	schedulers := []*tss.Scheduler{}
+	sessionIDs := []string{"session1", "session2", "session3", "session4"}
+	processes := make(map[string][]tss.TssProcess)
+	coordinators := make(map[peer.ID]bool)
+
+	msg := new(big.Int).SetBytes([]byte("Message"))
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		fetcher := keyshare.NewECDSAKeyshareStore(fmt.Sprintf("../../test/keyshares/%d.keyshare", i))
+		for _, sessionID := range sessionIDs {
+			signing, err := signing.NewSigning(msg, nil, sessionID, sessionID, host, &communication, fetcher)
+			s.Nil(err)
+			processes[sessionID] = append(processes[sessionID], signing)
+		}
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
+		schedulers = append(schedulers, tss.NewScheduler(coordinator, 1, noopQueueMeter{}))
+	}
+	tsstest.SetupCommunication(communicationMap)
+	for _, sessionID := range sessionIDs {
+		coordinators[util.SortPeersForSession(processes[sessionID][0].ValidCoordinators(), sessionID)[0].ID] = true
+	}
+	// sessions have to be coordinated by different relayers to compete for slots
+	s.Greater(len(coordinators), 1)
+
+	resultChns := make(map[string]chan interface{})
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for _, sessionID := range sessionIDs {
+		resultChn := make(chan interface{}, len(s.Hosts))
+		resultChns[sessionID] = resultChn
+		for i, scheduler := range schedulers {
+			scheduler := scheduler
+			process := processes[sessionID][i]
+			pool.Go(func(ctx context.Context) error {
+				return scheduler.Execute(ctx, tss.PriorityNormal, 1, []tss.TssProcess{process}, resultChn)
+			})
+		}
+	}
+
+	timeout := time.After(time.Minute)
+	for _, sessionID := range sessionIDs {
+		var signature interface{}
+		for signature == nil {
+			select {
+			case signature = <-resultChns[sessionID]:
+			case <-timeout:
+				s.FailNow("sessions not executed", sessionID)
+			}
+		}
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+}
@@ -0,0 +1,42 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ecdsa
+
+import (
+	"math/big"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ChainSafe/sygma-relayer/tss"
+)
+
+// VerifySignature checks that the signature of the message recovers to the address
+// of the expected public key and returns tss.SignatureError otherwise
+func VerifySignature(msg *big.Int, signature *common.SignatureData, publicKey *btcec.PublicKey) error {
+	msgHash := ethCommon.LeftPadBytes(msg.Bytes(), 32)
+	sig := []byte{}
+	sig = append(sig, ethCommon.LeftPadBytes(signature.R, 32)...)
+	sig = append(sig, ethCommon.LeftPadBytes(signature.S, 32)...)
+	sig = append(sig, signature.SignatureRecovery...)
+	expectedAddress := crypto.PubkeyToAddress(*publicKey.ToECDSA())
+	signatureErr := &tss.SignatureError{
+		Message:   msgHash,
+		Signature: sig,
+		PublicKey: expectedAddress.Hex(),
+	}
+
+	recoveredKey, err := crypto.SigToPub(msgHash, sig)
+	if err != nil {
+		return signatureErr
+	}
+	recoveredAddress := crypto.PubkeyToAddress(*recoveredKey)
+	if recoveredAddress != expectedAddress {
+		signatureErr.Signer = recoveredAddress.Hex()
+		return signatureErr
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ecdsa_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/binance-chain/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/ecdsa"
+)
+
+type VerifySignatureTestSuite struct {
+	suite.Suite
+	privateKey *btcec.PrivateKey
+	msg        *big.Int
+	signature  *common.SignatureData
+}
+
+func TestRunVerifySignatureTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifySignatureTestSuite))
+}
+
+func (s *VerifySignatureTestSuite) SetupTest() {
+	var err error
+	s.privateKey, err = btcec.NewPrivateKey()
+	s.Nil(err)
+
+	s.msg = new(big.Int).SetBytes(crypto.Keccak256([]byte("Message")))
+	sig, err := crypto.Sign(s.msg.Bytes(), s.privateKey.ToECDSA())
+	s.Nil(err)
+	s.signature = &common.SignatureData{
+		R:                 sig[:32],
+		S:                 sig[32:64],
+		SignatureRecovery: sig[64:],
+		M:                 s.msg.Bytes(),
+	}
+}
+
+func (s *VerifySignatureTestSuite) Test_ValidSignature() {
+	err := ecdsa.VerifySignature(s.msg, s.signature, s.privateKey.PubKey())
+
+	s.Nil(err)
+}
+
+func (s *VerifySignatureTestSuite) Test_UnexpectedPublicKey() {
+	otherKey, err := btcec.NewPrivateKey()
+	s.Nil(err)
+
+	err = ecdsa.VerifySignature(s.msg, s.signature, otherKey.PubKey())
+
+	signatureErr, ok := err.(*tss.SignatureError)
+	s.True(ok)
+	s.Equal(signatureErr.PublicKey, crypto.PubkeyToAddress(*otherKey.PubKey().ToECDSA()).Hex())
+	s.Equal(signatureErr.Signer, crypto.PubkeyToAddress(*s.privateKey.PubKey().ToECDSA()).Hex())
+}
+
+func (s *VerifySignatureTestSuite) Test_DifferentMessage() {
+	err := ecdsa.VerifySignature(big.NewInt(1), s.signature, s.privateKey.PubKey())
+
+	s.NotNil(err)
+}
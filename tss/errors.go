@@ -24,3 +24,20 @@ type SubsetError struct {
 func (se *SubsetError) Error() string {
 	return fmt.Sprintf("party %s not in signing subset", se.Peer)
 }
+
+// SignatureError is returned when the generated signature doesn't verify
+// against the expected public key and is not submitted on-chain
+type SignatureError struct {
+	Message   []byte
+	Signature []byte
+	PublicKey string
+	Signer    string
+}
+
+func (se *SignatureError) Error() string {
+	err := fmt.Sprintf("signature %x of message %x doesn't verify against public key %s", se.Signature, se.Message, se.PublicKey)
+	if se.Signer != "" {
+		err = fmt.Sprintf("%s, recovered signer %s", err, se.Signer)
+	}
+	return err
+}
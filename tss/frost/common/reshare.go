@@ -0,0 +1,301 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// ReshareMessage is sent by a party holding a share of the key to each party
+// of the new set. It contains commitments to the polynomial the sender used to
+// share its part of the key and the evaluation of the polynomial for the receiver.
+type ReshareMessage struct {
+	Commitments []byte
+	Share       []byte
+}
+
+// Reshare distributes an existing FROST key to a new set of parties with a new threshold.
+// Every party that holds a share of the key shares its Lagrange weighted share with
+// a new polynomial and each party sums received shares into its new share, so the
+// group key stays the same while new parties get valid shares.
+type Reshare struct {
+	mu sync.Mutex
+
+	group              curve.Curve
+	id                 party.ID
+	threshold          int
+	participants       []party.ID
+	dealers            []party.ID
+	privateShare       curve.Scalar
+	verificationShares map[party.ID]curve.Point
+
+	commitments map[party.ID]*polynomial.Exponent
+	shares      map[party.ID]curve.Scalar
+}
+
+// NewReshare creates resharing of the key defined by the existing verification shares.
+// Parties with a verification share that are part of the new set share their part of the key.
+func NewReshare(
+	group curve.Curve,
+	id party.ID,
+	threshold int,
+	participants []party.ID,
+	privateShare curve.Scalar,
+	verificationShares map[party.ID]curve.Point,
+) *Reshare {
+	dealers := make([]party.ID, 0)
+	for _, participant := range participants {
+		if _, ok := verificationShares[participant]; ok {
+			dealers = append(dealers, participant)
+		}
+	}
+
+	return &Reshare{
+		group:              group,
+		id:                 id,
+		threshold:          threshold,
+		participants:       participants,
+		dealers:            dealers,
+		privateShare:       privateShare,
+		verificationShares: verificationShares,
+		commitments:        make(map[party.ID]*polynomial.Exponent),
+		shares:             make(map[party.ID]curve.Scalar),
+	}
+}
+
+// IsDealer returns true if the party holds a share of the key that is shared with the new set
+func (r *Reshare) IsDealer() bool {
+	for _, dealer := range r.dealers {
+		if dealer == r.id {
+			return true
+		}
+	}
+	return false
+}
+
+// Deal shares the Lagrange weighted private share of the party with a new polynomial
+// of the threshold degree and returns messages for each party of the new set.
+func (r *Reshare) Deal() (map[party.ID]*ReshareMessage, error) {
+	if r.privateShare == nil || r.privateShare.IsZero() {
+		return nil, fmt.Errorf("missing private share of party %s", r.id)
+	}
+
+	lagrange := polynomial.Lagrange(r.group, r.dealers)
+	secret := r.group.NewScalar().Set(lagrange[r.id]).Mul(r.privateShare)
+	poly := polynomial.NewPolynomial(r.group, r.threshold, secret)
+	commitments, err := polynomial.NewPolynomialExponent(poly).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[party.ID]*ReshareMessage)
+	for _, participant := range r.participants {
+		share, err := poly.Evaluate(participant.Scalar(r.group)).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		messages[participant] = &ReshareMessage{
+			Commitments: commitments,
+			Share:       share,
+		}
+	}
+	return messages, nil
+}
+
+// Receive verifies the share dealt by the party against its commitments and the existing
+// verification share of the dealer. Returns true when shares from all dealers are received.
+func (r *Reshare) Receive(from party.ID, msg *ReshareMessage) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !party.NewIDSlice(r.dealers).Contains(from) {
+		return false, fmt.Errorf("party %s doesn't hold a share of the key", from)
+	}
+	if _, ok := r.commitments[from]; ok {
+		return false, fmt.Errorf("duplicate share from party %s", from)
+	}
+
+	commitments := polynomial.EmptyExponent(r.group)
+	err := commitments.UnmarshalBinary(msg.Commitments)
+	if err != nil {
+		return false, err
+	}
+	if commitments.Degree() != r.threshold {
+		return false, fmt.Errorf("invalid polynomial degree %d from party %s", commitments.Degree(), from)
+	}
+	lagrange := polynomial.Lagrange(r.group, r.dealers)
+	if !commitments.Constant().Equal(lagrange[from].Act(r.verificationShares[from])) {
+		return false, fmt.Errorf("commitment of party %s doesn't match its verification share", from)
+	}
+
+	share := r.group.NewScalar()
+	err = share.UnmarshalBinary(msg.Share)
+	if err != nil {
+		return false, err
+	}
+	if !share.ActOnBase().Equal(commitments.Evaluate(r.id.Scalar(r.group))) {
+		return false, fmt.Errorf("share from party %s doesn't match its commitments", from)
+	}
+
+	r.commitments[from] = commitments
+	r.shares[from] = share
+	return len(r.shares) == len(r.dealers), nil
+}
+
+// Result sums received shares into the new private share and computes new verification
+// shares of all parties and the group key.
+func (r *Reshare) Result() (curve.Scalar, map[party.ID]curve.Point, curve.Point, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.shares) != len(r.dealers) {
+		return nil, nil, nil, fmt.Errorf("received %d of %d shares", len(r.shares), len(r.dealers))
+	}
+
+	privateShare := r.group.NewScalar()
+	publicKey := r.group.NewPoint()
+	for _, dealer := range r.dealers {
+		privateShare.Add(r.shares[dealer])
+		publicKey = publicKey.Add(r.commitments[dealer].Constant())
+	}
+
+	verificationShares := make(map[party.ID]curve.Point)
+	for _, participant := range r.participants {
+		verificationShare := r.group.NewPoint()
+		for _, dealer := range r.dealers {
+			verificationShare = verificationShare.Add(r.commitments[dealer].Evaluate(participant.Scalar(r.group)))
+		}
+		verificationShares[participant] = verificationShare
+	}
+	if !privateShare.ActOnBase().Equal(verificationShares[r.id]) {
+		return nil, nil, nil, fmt.Errorf("private share doesn't match verification share")
+	}
+
+	return privateShare, verificationShares, publicKey, nil
+}
+
+// Transcript hashes commitments of all dealers and the group key so parties can confirm
+// they received the same commitments before the reshared key is used. Commitments are sent
+// point to point, so a dealer sending different commitments to different parties is detected
+// only by comparing transcripts.
+func (r *Reshare) Transcript(publicKey curve.Point) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := sha256.New()
+	for _, dealer := range party.NewIDSlice(r.dealers) {
+		commitments, ok := r.commitments[dealer]
+		if !ok {
+			return nil, fmt.Errorf("missing commitments of party %s", dealer)
+		}
+		commitmentBytes, err := commitments.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		hash.Write([]byte(dealer))
+		hash.Write(commitmentBytes)
+	}
+	publicKeyBytes, err := publicKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	hash.Write(publicKeyBytes)
+	return hash.Sum(nil), nil
+}
+
+// ProcessReshareMessages receives shares from dealers and signals
+// the end of the process when shares from all dealers are received.
+func (k *BaseFrostTss) ProcessReshareMessages(ctx context.Context, reshare *Reshare, msgChan chan *comm.WrappedMessage) error {
+	for {
+		select {
+		case wMsg := <-msgChan:
+			{
+				k.Log.Debug().Msgf("processed inbound message from %s", wMsg.From)
+
+				msg := &ReshareMessage{}
+				err := json.Unmarshal(wMsg.Payload, msg)
+				if err != nil {
+					return err
+				}
+				done, err := reshare.Receive(party.ID(wMsg.From.Pretty()), msg)
+				if err != nil {
+					return err
+				}
+				if done {
+					k.signalDone(ctx)
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// DealReshare sends the share of the key to each party of the new set.
+// Parties that don't hold a share of the key only receive shares.
+func (k *BaseFrostTss) DealReshare(ctx context.Context, reshare *Reshare) error {
+	if !reshare.IsDealer() {
+		return nil
+	}
+
+	messages, err := reshare.Deal()
+	if err != nil {
+		return err
+	}
+
+	// delay sending messages until everyone is ready to accept them
+	select {
+	case <-time.After(STARTUP_PAUSE):
+	case <-ctx.Done():
+		return nil
+	}
+
+	for id, msg := range messages {
+		if id == reshare.id {
+			done, err := reshare.Receive(id, msg)
+			if err != nil {
+				return err
+			}
+			if done {
+				k.signalDone(ctx)
+			}
+			continue
+		}
+
+		msgBytes, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		p, err := peer.Decode(string(id))
+		if err != nil {
+			return err
+		}
+
+		k.Log.Debug().Msgf("sending reshare message to %s", p)
+		err = k.Communication.Broadcast([]peer.ID{p}, msgBytes, comm.TssReshareMsg, k.SessionID())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *BaseFrostTss) signalDone(ctx context.Context) {
+	select {
+	case k.Done <- true:
+	case <-ctx.Done():
+	}
+}
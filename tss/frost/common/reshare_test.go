@@ -0,0 +1,101 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/stretchr/testify/suite"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type ReshareTestSuite struct {
+	suite.Suite
+	group        curve.Curve
+	participants []party.ID
+	reshares     map[party.ID]*common.Reshare
+}
+
+func TestRunReshareTestSuite(t *testing.T) {
+	suite.Run(t, new(ReshareTestSuite))
+}
+
+func (s *ReshareTestSuite) SetupTest() {
+	s.group = curve.Secp256k1{}
+	s.participants = []party.ID{"a", "b", "c"}
+
+	poly := polynomial.NewPolynomial(s.group, 1, sample.Scalar(rand.Reader, s.group))
+	privateShares := make(map[party.ID]curve.Scalar)
+	verificationShares := make(map[party.ID]curve.Point)
+	for _, id := range s.participants {
+		privateShares[id] = poly.Evaluate(id.Scalar(s.group))
+		verificationShares[id] = privateShares[id].ActOnBase()
+	}
+
+	s.reshares = make(map[party.ID]*common.Reshare)
+	for _, id := range s.participants {
+		s.reshares[id] = common.NewReshare(s.group, id, 1, s.participants, privateShares[id], verificationShares)
+	}
+}
+
+func (s *ReshareTestSuite) deliver(from party.ID, messages map[party.ID]*common.ReshareMessage) {
+	for to, msg := range messages {
+		_, err := s.reshares[to].Receive(from, msg)
+		s.Nil(err)
+	}
+}
+
+func (s *ReshareTestSuite) transcripts() map[party.ID][]byte {
+	transcripts := make(map[party.ID][]byte)
+	for id, reshare := range s.reshares {
+		_, _, publicKey, err := reshare.Result()
+		s.Nil(err)
+		transcripts[id], err = reshare.Transcript(publicKey)
+		s.Nil(err)
+	}
+	return transcripts
+}
+
+func (s *ReshareTestSuite) Test_Transcript_MissingCommitments() {
+	_, err := s.reshares["a"].Transcript(s.group.NewPoint())
+
+	s.NotNil(err)
+}
+
+func (s *ReshareTestSuite) Test_Transcript_SameCommitments() {
+	for _, id := range s.participants {
+		messages, err := s.reshares[id].Deal()
+		s.Nil(err)
+		s.deliver(id, messages)
+	}
+
+	transcripts := s.transcripts()
+
+	s.Equal(transcripts["a"], transcripts["b"])
+	s.Equal(transcripts["a"], transcripts["c"])
+}
+
+func (s *ReshareTestSuite) Test_Transcript_DealerSentDifferentCommitments() {
+	for _, id := range s.participants {
+		messages, err := s.reshares[id].Deal()
+		s.Nil(err)
+		if id == "a" {
+			// dealer shares the same secret with another polynomial to party c
+			otherMessages, err := s.reshares[id].Deal()
+			s.Nil(err)
+			messages["c"] = otherMessages["c"]
+		}
+		s.deliver(id, messages)
+	}
+
+	transcripts := s.transcripts()
+
+	s.Equal(transcripts["a"], transcripts["b"])
+	s.NotEqual(transcripts["a"], transcripts["c"])
+}
@@ -0,0 +1,267 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ed25519
+
+import (
+	"fmt"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+// ed25519Order is the order of the prime order subgroup, 2^252 + 27742317777372353535851937790883648493
+var ed25519Order, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+var ed25519Modulus = saferith.ModulusFromBytes(ed25519Order.Bytes())
+var ed25519HalfOrder = new(big.Int).Rsh(ed25519Order, 1)
+
+// cofactorInverse is the inverse of the cofactor 8 modulo the group order used
+// to check that points are in the prime order subgroup
+var cofactorInverse = scalarFromBigInt(new(big.Int).ModInverse(big.NewInt(8), ed25519Order))
+
+// Curve implements multi-party-sig curve.Curve for the prime order subgroup of
+// edwards25519 so FROST keys can be generated for Ed25519 signatures.
+type Curve struct{}
+
+func (Curve) NewPoint() curve.Point {
+	return &Point{value: *edwards25519.NewIdentityPoint()}
+}
+
+func (Curve) NewBasePoint() curve.Point {
+	return &Point{value: *edwards25519.NewGeneratorPoint()}
+}
+
+func (Curve) NewScalar() curve.Scalar {
+	return &Scalar{value: *edwards25519.NewScalar()}
+}
+
+func (Curve) Name() string {
+	return "ed25519"
+}
+
+func (Curve) ScalarBits() int {
+	return 253
+}
+
+func (Curve) SafeScalarBytes() int {
+	return 64
+}
+
+func (Curve) Order() *saferith.Modulus {
+	return ed25519Modulus
+}
+
+// Scalar is a number modulo the order of the edwards25519 prime order subgroup.
+// Scalars are marshalled as big endian bytes as expected by multi-party-sig.
+type Scalar struct {
+	value edwards25519.Scalar
+}
+
+// NewScalarFromHash reduces 64 byte little endian hash output modulo the group order
+// as done for Ed25519 challenges
+func NewScalarFromHash(digest []byte) (*Scalar, error) {
+	s := new(Scalar)
+	_, err := s.value.SetUniformBytes(digest)
+	return s, err
+}
+
+func castScalar(generic curve.Scalar) *Scalar {
+	out, ok := generic.(*Scalar)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to ed25519 scalar: %v", generic))
+	}
+	return out
+}
+
+func (s *Scalar) MarshalBinary() ([]byte, error) {
+	return reverse(s.value.Bytes()), nil
+}
+
+func (s *Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("ed25519 scalar: invalid length %d", len(data))
+	}
+	_, err := s.value.SetCanonicalBytes(reverse(data))
+	return err
+}
+
+// Bytes returns little endian encoding of the scalar used by Ed25519 signatures
+func (s *Scalar) Bytes() []byte {
+	return s.value.Bytes()
+}
+
+func (s *Scalar) Curve() curve.Curve {
+	return Curve{}
+}
+
+func (s *Scalar) Add(that curve.Scalar) curve.Scalar {
+	s.value.Add(&s.value, &castScalar(that).value)
+	return s
+}
+
+func (s *Scalar) Sub(that curve.Scalar) curve.Scalar {
+	s.value.Subtract(&s.value, &castScalar(that).value)
+	return s
+}
+
+func (s *Scalar) Negate() curve.Scalar {
+	s.value.Negate(&s.value)
+	return s
+}
+
+func (s *Scalar) Mul(that curve.Scalar) curve.Scalar {
+	s.value.Multiply(&s.value, &castScalar(that).value)
+	return s
+}
+
+func (s *Scalar) Invert() curve.Scalar {
+	inverse := new(big.Int).ModInverse(s.bigInt(), ed25519Order)
+	if inverse == nil {
+		inverse = new(big.Int)
+	}
+	s.value = scalarFromBigInt(inverse).value
+	return s
+}
+
+func (s *Scalar) Equal(that curve.Scalar) bool {
+	return s.value.Equal(&castScalar(that).value) == 1
+}
+
+func (s *Scalar) IsZero() bool {
+	return s.value.Equal(edwards25519.NewScalar()) == 1
+}
+
+func (s *Scalar) Set(that curve.Scalar) curve.Scalar {
+	s.value.Set(&castScalar(that).value)
+	return s
+}
+
+func (s *Scalar) SetNat(x *saferith.Nat) curve.Scalar {
+	reduced := new(saferith.Nat).Mod(x, ed25519Modulus)
+	s.value = scalarFromBigInt(reduced.Big()).value
+	return s
+}
+
+func (s *Scalar) Act(that curve.Point) curve.Point {
+	out := new(Point)
+	out.value.ScalarMult(&s.value, &castPoint(that).value)
+	return out
+}
+
+func (s *Scalar) ActOnBase() curve.Point {
+	out := new(Point)
+	out.value.ScalarBaseMult(&s.value)
+	return out
+}
+
+func (s *Scalar) IsOverHalfOrder() bool {
+	return s.bigInt().Cmp(ed25519HalfOrder) > 0
+}
+
+func (s *Scalar) bigInt() *big.Int {
+	return new(big.Int).SetBytes(reverse(s.value.Bytes()))
+}
+
+// Point is an element of the edwards25519 prime order subgroup
+type Point struct {
+	value edwards25519.Point
+}
+
+func castPoint(generic curve.Point) *Point {
+	out, ok := generic.(*Point)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to ed25519 point: %v", generic))
+	}
+	return out
+}
+
+func (p *Point) MarshalBinary() ([]byte, error) {
+	return p.value.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the point and rejects points outside of the prime order subgroup
+func (p *Point) UnmarshalBinary(data []byte) error {
+	point, err := new(edwards25519.Point).SetBytes(data)
+	if err != nil {
+		return err
+	}
+
+	torsionFree := new(edwards25519.Point).MultByCofactor(point)
+	torsionFree.ScalarMult(&cofactorInverse.value, torsionFree)
+	if torsionFree.Equal(point) != 1 {
+		return fmt.Errorf("ed25519 point: not in prime order subgroup")
+	}
+
+	p.value.Set(point)
+	return nil
+}
+
+func (p *Point) Curve() curve.Curve {
+	return Curve{}
+}
+
+func (p *Point) Add(that curve.Point) curve.Point {
+	out := new(Point)
+	out.value.Add(&p.value, &castPoint(that).value)
+	return out
+}
+
+func (p *Point) Sub(that curve.Point) curve.Point {
+	out := new(Point)
+	out.value.Subtract(&p.value, &castPoint(that).value)
+	return out
+}
+
+func (p *Point) Negate() curve.Point {
+	out := new(Point)
+	out.value.Negate(&p.value)
+	return out
+}
+
+func (p *Point) Equal(that curve.Point) bool {
+	return p.value.Equal(&castPoint(that).value) == 1
+}
+
+func (p *Point) IsIdentity() bool {
+	return p.value.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// XScalar returns the affine x coordinate of the point reduced modulo the group order,
+// the same value secp256k1 points return for ECDSA signatures.
+func (p *Point) XScalar() curve.Scalar {
+	X, _, Z, _ := p.value.ExtendedCoordinates()
+	x := new(field.Element).Multiply(X, new(field.Element).Invert(Z))
+
+	// x is smaller than 2^255 so it is reduced as a wide little endian value
+	wide := make([]byte, 64)
+	copy(wide, x.Bytes())
+	s := new(Scalar)
+	_, err := s.value.SetUniformBytes(wide)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func scalarFromBigInt(x *big.Int) *Scalar {
+	bytes := make([]byte, 32)
+	x.FillBytes(bytes)
+
+	s := new(Scalar)
+	_, err := s.value.SetCanonicalBytes(reverse(bytes))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func reverse(bytes []byte) []byte {
+	reversed := make([]byte, len(bytes))
+	for i, b := range bytes {
+		reversed[len(bytes)-1-i] = b
+	}
+	return reversed
+}
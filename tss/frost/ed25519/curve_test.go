@@ -0,0 +1,39 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ed25519_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+	"github.com/cronokirby/saferith"
+	"github.com/stretchr/testify/suite"
+)
+
+type CurveTestSuite struct {
+	suite.Suite
+}
+
+func TestRunCurveTestSuite(t *testing.T) {
+	suite.Run(t, new(CurveTestSuite))
+}
+
+func (s *CurveTestSuite) Test_XScalar_BasePoint() {
+	// x coordinate of the edwards25519 base point
+	x, _ := new(big.Int).SetString("15112221349535400772501151409588531511454012693041857206046113283949847762202", 10)
+	order, _ := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	expected := ed25519.Curve{}.NewScalar().SetNat(new(saferith.Nat).SetBig(new(big.Int).Mod(x, order), 256))
+
+	xScalar := ed25519.Curve{}.NewBasePoint().XScalar()
+
+	s.NotNil(xScalar)
+	s.True(xScalar.Equal(expected))
+}
+
+func (s *CurveTestSuite) Test_XScalar_Identity() {
+	xScalar := ed25519.Curve{}.NewPoint().XScalar()
+
+	s.True(xScalar.IsZero())
+}
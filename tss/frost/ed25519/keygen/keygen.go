@@ -0,0 +1,185 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keygen
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+	"github.com/binance-chain/tss-lib/tss"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+type Ed25519KeyshareStorer interface {
+	StoreKeyshareVersion(keyshare keyshare.Ed25519Keyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
+	LockKeyshare()
+	UnlockKeyshare()
+	GetKeyshare() (keyshare.Ed25519Keyshare, error)
+	KeyshareExists() bool
+}
+
+// Keygen generates Ed25519 FROST keyshare for all parties in the peerstore
+type Keygen struct {
+	common.BaseFrostTss
+	storer         Ed25519KeyshareStorer
+	threshold      int
+	subscriptionID comm.SubscriptionID
+	confirmation   *confirmation.Confirmation
+}
+
+func NewKeygen(
+	sessionID string,
+	threshold int,
+	host host.Host,
+	comm comm.Communication,
+	storer Ed25519KeyshareStorer,
+) *Keygen {
+	storer.LockKeyshare()
+	peers := host.Peerstore().Peers()
+	return &Keygen{
+		BaseFrostTss: common.BaseFrostTss{
+			Host:          host,
+			Communication: comm,
+			Peers:         peers,
+			SID:           sessionID,
+			Log:           log.With().Str("SessionID", sessionID).Str("Process", "ed25519-keygen").Logger(),
+			Cancel:        func() {},
+			Done:          make(chan bool),
+		},
+		storer:       storer,
+		threshold:    threshold,
+		confirmation: confirmation.NewConfirmation(host, comm, sessionID, peers),
+	}
+}
+
+// Run initializes the keygen party and runs the keygen tss process.
+//
+// Should be run only after all the participating parties are ready.
+func (k *Keygen) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	ctx, k.Cancel = context.WithCancel(ctx)
+
+	outChn := make(chan tss.Message)
+	msgChn := make(chan *comm.WrappedMessage)
+	k.subscriptionID = k.Communication.Subscribe(k.SessionID(), comm.TssKeyGenMsg, msgChn)
+	k.confirmation.Subscribe()
+
+	var err error
+	k.Handler, err = protocol.NewMultiHandler(
+		frost.Keygen(
+			ed25519.Curve{},
+			party.ID(k.Host.ID().String()),
+			common.PartyIDSFromPeers(append(k.Host.Peerstore().Peers(), k.Host.ID())),
+			k.threshold),
+		[]byte(k.SessionID()))
+	if err != nil {
+		return err
+	}
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error { return k.ProcessInboundMessages(ctx, msgChn) })
+	p.Go(func(ctx context.Context) error { return k.processEndMessage(ctx) })
+	p.Go(func(ctx context.Context) error { return k.ProcessOutboundMessages(ctx, outChn, comm.TssKeyGenMsg) })
+
+	return p.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process and unlocks keyshare.
+func (k *Keygen) Stop() {
+	k.Communication.UnSubscribe(k.subscriptionID)
+	k.confirmation.UnSubscribe()
+	k.storer.UnlockKeyshare()
+	k.Cancel()
+}
+
+// Ready returns true if all parties from the peerstore are ready.
+// Error is returned if excluded peers exist as we need all peers to participate
+// in keygen process.
+func (k *Keygen) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	if len(excludedPeers) > 0 {
+		return false, errors.New("error")
+	}
+
+	return len(readyPeers) == len(k.Host.Peerstore().Peers()), nil
+}
+
+// ValidCoordinators returns all peers in peerstore
+func (k *Keygen) ValidCoordinators() []peer.ID {
+	return k.Peers
+}
+
+func (k *Keygen) StartParams(readyPeers []peer.ID) []byte {
+	return []byte{}
+}
+
+func (k *Keygen) Retryable() bool {
+	return false
+}
+
+// processEndMessage waits for the final message with generated key share, confirms the public key
+// with other parties and stores the key share locally. Existing key share is never replaced,
+// the generated key share has to be activated by the operator in that case.
+func (k *Keygen) processEndMessage(ctx context.Context) error {
+
+	for {
+		select {
+		case <-k.Done:
+			{
+				result, err := k.Handler.Result()
+				if err != nil {
+					return err
+				}
+				config := result.(*frost.Config)
+
+				publicKey, err := config.PublicKey.MarshalBinary()
+				if err != nil {
+					return err
+				}
+				err = k.confirmation.Confirm(ctx, publicKey)
+				if err != nil {
+					return err
+				}
+
+				version, err := k.storer.StoreKeyshareVersion(
+					keyshare.NewEd25519Keyshare(config, k.threshold, k.Peers),
+					keyshare.Metadata{SessionID: k.SessionID()},
+				)
+				if err != nil {
+					return err
+				}
+				if k.storer.KeyshareExists() {
+					k.Log.Warn().Msgf("Keyshare already exists, generated keyshare version %d has to be activated manually", version)
+					k.Cancel()
+					return nil
+				}
+				err = k.storer.ActivateKeyshare(version)
+				if err != nil {
+					return err
+				}
+
+				k.Log.Info().Msgf("Generated public key %s", hex.EncodeToString(publicKey))
+				k.Cancel()
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
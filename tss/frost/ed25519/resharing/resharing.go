@@ -0,0 +1,276 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package resharing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+type startParams struct {
+	PublicKey          []byte
+	ChainKey           []byte
+	VerificationShares map[party.ID][]byte
+}
+
+type Ed25519KeyshareStorer interface {
+	GetKeyshare() (keyshare.Ed25519Keyshare, error)
+	StoreKeyshareVersion(keyshare keyshare.Ed25519Keyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
+	LockKeyshare()
+	UnlockKeyshare()
+}
+
+// Resharing distributes Ed25519 FROST keyshare to new parties without changing the public key
+type Resharing struct {
+	common.BaseFrostTss
+	key            keyshare.Ed25519Keyshare
+	subscriptionID comm.SubscriptionID
+	reshare        *common.Reshare
+	storer         Ed25519KeyshareStorer
+	newThreshold   int
+	topologyHash   string
+	confirmation   *confirmation.Confirmation
+	// deferActivation is set if the reshared keyshare is activated by the caller
+	deferActivation bool
+}
+
+func NewResharing(
+	sessionID string,
+	threshold int,
+	host host.Host,
+	comm comm.Communication,
+	storer Ed25519KeyshareStorer,
+	topologyHash string,
+) *Resharing {
+	storer.LockKeyshare()
+	var key keyshare.Ed25519Keyshare
+	key, err := storer.GetKeyshare()
+	if err != nil {
+		// empty key for parties that don't have one
+		config := frost.EmptyConfig(ed25519.Curve{})
+		config.ID = party.ID(host.ID().Pretty())
+		key = keyshare.Ed25519Keyshare{
+			Key: config,
+		}
+	}
+	key.Key.Threshold = threshold
+
+	peers := host.Peerstore().Peers()
+	return &Resharing{
+		BaseFrostTss: common.BaseFrostTss{
+			Host:          host,
+			Communication: comm,
+			Peers:         peers,
+			SID:           sessionID,
+			Log:           log.With().Str("SessionID", sessionID).Str("Process", "ed25519-resharing").Logger(),
+			Cancel:        func() {},
+			Done:          make(chan bool),
+		},
+		key:          key,
+		storer:       storer,
+		newThreshold: threshold,
+		topologyHash: topologyHash,
+		confirmation: confirmation.NewConfirmation(host, comm, sessionID, peers),
+	}
+}
+
+// DeferActivation stores the reshared keyshare without activating it. Stored version is sent
+// to the result channel so it can be activated together with keyshares reshared in other sessions.
+func (r *Resharing) DeferActivation() {
+	r.deferActivation = true
+}
+
+// Run initializes the signing party and runs the resharing tss process.
+// Params contains peer subset that leaders sends with start message.
+func (r *Resharing) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	ctx, r.Cancel = context.WithCancel(ctx)
+	var err error
+
+	msgChn := make(chan *comm.WrappedMessage)
+	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
+	r.confirmation.Subscribe()
+	startParams, err := r.unmarshallStartParams(params)
+	if err != nil {
+		return err
+	}
+	// initialize verification shares for the new relayer
+	if len(r.key.Key.VerificationShares.Points) == 0 {
+		err = r.initializeKey(startParams)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.reshare = common.NewReshare(
+		ed25519.Curve{},
+		r.key.Key.ID,
+		r.newThreshold,
+		common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID())),
+		r.key.Key.PrivateShare,
+		r.key.Key.VerificationShares.Points,
+	)
+
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error { return r.ProcessReshareMessages(ctx, r.reshare, msgChn) })
+	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx, resultChn) })
+	p.Go(func(ctx context.Context) error { return r.DealReshare(ctx, r.reshare) })
+
+	r.Log.Info().Msgf("Started resharing process")
+	return p.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process and unlocks keyshare.
+func (r *Resharing) Stop() {
+	r.Log.Info().Msgf("Stopping tss process.")
+	r.Communication.UnSubscribe(r.subscriptionID)
+	r.confirmation.UnSubscribe()
+	r.storer.UnlockKeyshare()
+	r.Cancel()
+}
+
+// Ready returns true if all parties from peerstore are ready
+func (r *Resharing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	return len(readyPeers) == len(r.Host.Peerstore().Peers()), nil
+}
+
+func (r *Resharing) ValidCoordinators() []peer.ID {
+	return r.key.Peers
+}
+
+func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
+	publicKey, _ := r.key.Key.PublicKey.MarshalBinary()
+	verificationShares := make(map[party.ID][]byte)
+	for id, point := range r.key.Key.VerificationShares.Points {
+		verificationShares[id], _ = point.MarshalBinary()
+	}
+
+	startParams := &startParams{
+		PublicKey:          publicKey,
+		ChainKey:           r.key.Key.ChainKey,
+		VerificationShares: verificationShares,
+	}
+	paramBytes, _ := json.Marshal(startParams)
+	return paramBytes
+}
+
+func (r *Resharing) unmarshallStartParams(paramBytes []byte) (startParams, error) {
+	var startParams startParams
+	err := json.Unmarshal(paramBytes, &startParams)
+	if err != nil {
+		return startParams, err
+	}
+
+	return startParams, nil
+}
+
+// initializeKey sets public key and verification shares received from
+// the coordinator for parties that don't have a keyshare
+func (r *Resharing) initializeKey(startParams startParams) error {
+	group := ed25519.Curve{}
+	publicKey := group.NewPoint()
+	err := publicKey.UnmarshalBinary(startParams.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	verificationShares := make(map[party.ID]curve.Point)
+	for id, pointBytes := range startParams.VerificationShares {
+		point := group.NewPoint()
+		err := point.UnmarshalBinary(pointBytes)
+		if err != nil {
+			return err
+		}
+		verificationShares[id] = point
+	}
+
+	r.key.Key.PublicKey = publicKey
+	r.key.Key.ChainKey = startParams.ChainKey
+	r.key.Key.VerificationShares = party.NewPointMap(verificationShares)
+	return nil
+}
+
+func (r *Resharing) Retryable() bool {
+	return false
+}
+
+// processEndMessage waits for the final message with generated key share, confirms commitments
+// of all dealers with other parties and stores the key share locally.
+// Key share is activated unless activation is deferred.
+func (r *Resharing) processEndMessage(ctx context.Context, resultChn chan interface{}) error {
+
+	for {
+		select {
+		case <-r.Done:
+			{
+				privateShare, verificationShares, publicKey, err := r.reshare.Result()
+				if err != nil {
+					return err
+				}
+				if !publicKey.Equal(r.key.Key.PublicKey) {
+					return fmt.Errorf("reshared public key doesn't match public key")
+				}
+
+				transcript, err := r.reshare.Transcript(publicKey)
+				if err != nil {
+					return err
+				}
+				err = r.confirmation.Confirm(ctx, transcript)
+				if err != nil {
+					return err
+				}
+
+				config := &frost.Config{
+					ID:                 r.key.Key.ID,
+					Threshold:          r.newThreshold,
+					PrivateShare:       privateShare,
+					PublicKey:          r.key.Key.PublicKey,
+					ChainKey:           r.key.Key.ChainKey,
+					VerificationShares: party.NewPointMap(verificationShares),
+				}
+
+				version, err := r.storer.StoreKeyshareVersion(
+					keyshare.NewEd25519Keyshare(config, r.newThreshold, r.Peers),
+					keyshare.Metadata{SessionID: r.SessionID(), TopologyHash: r.topologyHash},
+				)
+				if err != nil {
+					return err
+				}
+				if r.deferActivation {
+					resultChn <- version
+				} else {
+					err = r.storer.ActivateKeyshare(version)
+					if err != nil {
+						return err
+					}
+				}
+
+				r.Log.Info().Msgf("Refreshed key")
+				r.Cancel()
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
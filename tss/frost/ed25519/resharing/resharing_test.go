@@ -0,0 +1,105 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package resharing_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519/resharing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResharingTestSuite struct {
+	tsstest.CoordinatorTestSuite
+}
+
+func TestRunResharingTestSuite(t *testing.T) {
+	suite.Run(t, new(ResharingTestSuite))
+}
+
+// storer copies the test keyshare of the host into a temporary directory so
+// resharing can store new keyshare versions without changing test keyshares
+func (s *ResharingTestSuite) storer(dir string, i int) *keyshare.Ed25519KeyshareStore {
+	path := filepath.Join(dir, fmt.Sprintf("%d-ed25519.keyshare", i))
+	kb, err := os.ReadFile(fmt.Sprintf("../../../test/keyshares/%d-ed25519.keyshare", i))
+	if err == nil {
+		err = os.WriteFile(path, kb, 0600)
+		s.Nil(err)
+	}
+	return keyshare.NewEd25519KeyshareStore(path)
+}
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+	storers := []*keyshare.Ed25519KeyshareStore{}
+
+	hosts := []host.Host{}
+	for i := 0; i < s.PartyNumber+1; i++ {
+		host, _ := tsstest.NewHost(i)
+		hosts = append(hosts, host)
+	}
+	for _, host := range hosts {
+		for _, peer := range hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	dir := s.T().TempDir()
+	for i, host := range hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		storer := s.storer(dir, i)
+		storers = append(storers, storer)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, storer, "")
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	oldKey, err := storers[0].GetKeyshare()
+	s.Nil(err)
+	oldPublicKey, err := oldKey.PublicKey()
+	s.Nil(err)
+
+	resultChn := make(chan interface{})
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	err = pool.Wait()
+	s.Nil(err)
+
+	for _, storer := range storers {
+		key, err := storer.GetKeyshare()
+		s.Nil(err)
+		publicKey, err := key.PublicKey()
+		s.Nil(err)
+		s.Equal(publicKey, oldPublicKey)
+		s.Len(key.Peers, s.PartyNumber+1)
+	}
+}
@@ -0,0 +1,469 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	stdEd25519 "crypto/ed25519"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"golang.org/x/exp/slices"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+)
+
+// bindingFactorDomain separates binding factor hashes from other uses of SHA-512
+const bindingFactorDomain = "FROST-ED25519-SHA512-v1rho"
+
+const (
+	commitmentRound = 1
+	shareRound      = 2
+)
+
+// Signature is an Ed25519 signature of the message with the index of the message
+type Signature struct {
+	Id        int
+	Signature []byte
+}
+
+type SaveDataFetcher interface {
+	GetKeyshare() (keyshare.Ed25519Keyshare, error)
+	LockKeyshare()
+	UnlockKeyshare()
+}
+
+// signingMessage contains nonce commitments in the first round and
+// the signature share in the second round
+type signingMessage struct {
+	Round int
+	D     []byte `json:",omitempty"`
+	E     []byte `json:",omitempty"`
+	Share []byte `json:",omitempty"`
+}
+
+type nonceCommitment struct {
+	D curve.Point
+	E curve.Point
+}
+
+// Signing generates RFC 8032 compatible Ed25519 signature with the two round FROST protocol.
+// Signers first exchange nonce commitments and then signature shares, which are
+// verified against verification shares so misbehaving signers can be excluded on retry.
+type Signing struct {
+	common.BaseFrostTss
+	util.TieredReadiness
+	id             int
+	coordinator    bool
+	key            keyshare.Ed25519Keyshare
+	msg            []byte
+	resultChn      chan interface{}
+	subscriptionID comm.SubscriptionID
+
+	d              curve.Scalar
+	e              curve.Scalar
+	commitments    map[party.ID]nonceCommitment
+	shares         map[party.ID][]byte
+	bindingFactors map[party.ID]curve.Scalar
+	groupNonce     curve.Point
+	challenge      curve.Scalar
+}
+
+func NewSigning(
+	id int,
+	msg []byte,
+	messageID string,
+	sessionID string,
+	host host.Host,
+	comm comm.Communication,
+	fetcher SaveDataFetcher,
+) (*Signing, error) {
+	fetcher.LockKeyshare()
+	defer fetcher.UnlockKeyshare()
+	key, err := fetcher.GetKeyshare()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signing{
+		BaseFrostTss: common.BaseFrostTss{
+			Host:          host,
+			Communication: comm,
+			Peers:         key.Peers,
+			SID:           sessionID,
+			Log:           log.With().Str("SessionID", sessionID).Str("messageID", messageID).Str("Process", "ed25519-signing").Logger(),
+			Cancel:        func() {},
+			Done:          make(chan bool),
+		},
+		key: key,
+		id:  id,
+		msg: msg,
+	}, nil
+}
+
+// Run initializes the signing party and runs the signing tss process.
+// Params contains peer subset that leaders sends with start message.
+func (s *Signing) Run(
+	ctx context.Context,
+	coordinator bool,
+	resultChn chan interface{},
+	params []byte,
+) error {
+	s.coordinator = coordinator
+	s.resultChn = resultChn
+	ctx, s.Cancel = context.WithCancel(ctx)
+
+	peerSubset, err := s.unmarshallStartParams(params)
+	if err != nil {
+		return err
+	}
+	s.Peers = peerSubset
+	if !util.IsParticipant(s.Host.ID(), peerSubset) {
+		return &tss.SubsetError{Peer: s.Host.ID()}
+	}
+
+	group := ed25519.Curve{}
+	s.d = sample.Scalar(rand.Reader, group)
+	s.e = sample.Scalar(rand.Reader, group)
+	commitment := nonceCommitment{
+		D: s.d.ActOnBase(),
+		E: s.e.ActOnBase(),
+	}
+	s.commitments = map[party.ID]nonceCommitment{s.key.Key.ID: commitment}
+	s.shares = make(map[party.ID][]byte)
+
+	d, _ := commitment.D.MarshalBinary()
+	e, _ := commitment.E.MarshalBinary()
+	commitmentMsg, err := json.Marshal(signingMessage{Round: commitmentRound, D: d, E: e})
+	if err != nil {
+		return err
+	}
+
+	msgChn := make(chan *comm.WrappedMessage)
+	s.subscriptionID = s.Communication.Subscribe(s.SessionID(), comm.TssKeySignMsg, msgChn)
+
+	p := pool.New().WithContext(ctx).WithCancelOnError()
+	p.Go(func(ctx context.Context) error { return s.processInboundMessages(ctx, msgChn) })
+	p.Go(func(ctx context.Context) error { return s.sendCommitment(ctx, commitmentMsg) })
+
+	s.Log.Info().Msgf("Started signing process for message %s", hex.EncodeToString(s.msg))
+	return p.Wait()
+}
+
+// Stop ends all subscriptions created when starting the tss process.
+func (s *Signing) Stop() {
+	s.Log.Info().Msgf("Stopping tss process.")
+	s.Communication.UnSubscribe(s.subscriptionID)
+	s.Cancel()
+}
+
+// Ready returns true if threshold+1 well-behaved parties are ready to start the signing process.
+// Parties with worse reputation are accepted if all parties are ready or if not enough
+// well-behaved parties got ready during the grace period.
+func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
+	return s.TiersReady(s.readyParticipants(readyPeers), s.key.Peers, s.key.Threshold), nil
+}
+
+// ValidCoordinators returns only peers that have a valid keyshare
+func (s *Signing) ValidCoordinators() []peer.ID {
+	return s.key.Peers
+}
+
+// StartParams returns peer subset for this tss process. It is calculated
+// by sorting ready peers by reputation tier and hashes of peer IDs and session ID
+// and chosing ready peers in order until threshold is satisfied.
+func (s *Signing) StartParams(readyPeers []peer.ID) []byte {
+	readyPeers = s.readyParticipants(readyPeers)
+	peers := []peer.ID{}
+	peers = append(peers, readyPeers...)
+
+	sortedPeers := util.SortPeersByTier(peers, s.SessionID(), s.PeerTiers())
+	peerSubset := []peer.ID{}
+	for _, peer := range sortedPeers {
+		peerSubset = append(peerSubset, peer.ID)
+		if len(peerSubset) == s.key.Threshold+1 {
+			break
+		}
+	}
+
+	paramBytes, _ := json.Marshal(peerSubset)
+	return paramBytes
+}
+
+func (s *Signing) unmarshallStartParams(paramBytes []byte) ([]peer.ID, error) {
+	var peerSubset []peer.ID
+	err := json.Unmarshal(paramBytes, &peerSubset)
+	if err != nil {
+		return []peer.ID{}, err
+	}
+
+	return peerSubset, nil
+}
+
+func (s *Signing) Retryable() bool {
+	return true
+}
+
+// sendCommitment broadcasts nonce commitments to the signing subset
+func (s *Signing) sendCommitment(ctx context.Context, commitmentMsg []byte) error {
+	// delay sending messages until everyone is ready to accept them
+	select {
+	case <-time.After(common.STARTUP_PAUSE):
+		return s.Communication.Broadcast(s.Peers, commitmentMsg, comm.TssKeySignMsg, s.SessionID())
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// processInboundMessages collects commitments and signature shares from the signing
+// subset. Signature share is sent after all commitments are received and the signature
+// is aggregated after all signature shares are received.
+func (s *Signing) processInboundMessages(ctx context.Context, msgChn chan *comm.WrappedMessage) error {
+	for {
+		select {
+		case wMsg := <-msgChn:
+			{
+				if !util.IsParticipant(wMsg.From, s.Peers) {
+					continue
+				}
+				s.Log.Debug().Msgf("processed inbound message from %s", wMsg.From)
+
+				from := party.ID(wMsg.From.String())
+				err := s.handleMessage(from, wMsg.Payload)
+				if err != nil {
+					return protocol.Error{Culprits: []party.ID{from}, Err: err}
+				}
+
+				if len(s.commitments) == len(s.Peers) && s.shares[s.key.Key.ID] == nil {
+					err = s.sendShare()
+					if err != nil {
+						return err
+					}
+				}
+
+				if len(s.shares) == len(s.Peers) {
+					return s.aggregate()
+				}
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Signing) handleMessage(from party.ID, payload []byte) error {
+	msg := signingMessage{}
+	err := json.Unmarshal(payload, &msg)
+	if err != nil {
+		return err
+	}
+
+	switch msg.Round {
+	case commitmentRound:
+		{
+			if _, ok := s.commitments[from]; ok {
+				return nil
+			}
+
+			group := ed25519.Curve{}
+			d := group.NewPoint()
+			err := d.UnmarshalBinary(msg.D)
+			if err != nil {
+				return err
+			}
+			e := group.NewPoint()
+			err = e.UnmarshalBinary(msg.E)
+			if err != nil {
+				return err
+			}
+			if d.IsIdentity() || e.IsIdentity() {
+				return errors.New("identity nonce commitment")
+			}
+
+			s.commitments[from] = nonceCommitment{D: d, E: e}
+			return nil
+		}
+	case shareRound:
+		{
+			if _, ok := s.shares[from]; !ok {
+				s.shares[from] = msg.Share
+			}
+			return nil
+		}
+	default:
+		return fmt.Errorf("invalid signing round %d", msg.Round)
+	}
+}
+
+// sendShare calculates the group nonce and challenge from received commitments
+// and broadcasts signature share z = d + e*ρ + λ*s*c
+func (s *Signing) sendShare() error {
+	err := s.computeChallenge()
+	if err != nil {
+		return err
+	}
+
+	group := ed25519.Curve{}
+	lambda := polynomial.Lagrange(group, s.signers())[s.key.Key.ID]
+	share := group.NewScalar().Set(s.e).Mul(s.bindingFactors[s.key.Key.ID])
+	share.Add(s.d)
+	share.Add(group.NewScalar().Set(lambda).Mul(s.key.Key.PrivateShare).Mul(s.challenge))
+
+	shareBytes, err := share.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	s.shares[s.key.Key.ID] = shareBytes
+
+	shareMsg, err := json.Marshal(signingMessage{Round: shareRound, Share: shareBytes})
+	if err != nil {
+		return err
+	}
+	return s.Communication.Broadcast(s.Peers, shareMsg, comm.TssKeySignMsg, s.SessionID())
+}
+
+// computeChallenge calculates binding factors of each signer, the group nonce R
+// and the Ed25519 challenge SHA512(R || A || M)
+func (s *Signing) computeChallenge() error {
+	group := ed25519.Curve{}
+	signers := s.signers()
+	msgHash := sha512.Sum512(s.msg)
+	commitmentHash := sha512.New()
+	for _, id := range signers {
+		d, _ := s.commitments[id].D.MarshalBinary()
+		e, _ := s.commitments[id].E.MarshalBinary()
+		commitmentHash.Write([]byte(id))
+		commitmentHash.Write(d)
+		commitmentHash.Write(e)
+	}
+	encodedCommitments := commitmentHash.Sum(nil)
+
+	s.bindingFactors = make(map[party.ID]curve.Scalar)
+	s.groupNonce = group.NewPoint()
+	for _, id := range signers {
+		h := sha512.New()
+		h.Write([]byte(bindingFactorDomain))
+		h.Write([]byte(id))
+		h.Write(msgHash[:])
+		h.Write(encodedCommitments)
+		bindingFactor, err := ed25519.NewScalarFromHash(h.Sum(nil))
+		if err != nil {
+			return err
+		}
+		s.bindingFactors[id] = bindingFactor
+
+		commitment := s.commitments[id]
+		s.groupNonce = s.groupNonce.Add(commitment.D).Add(bindingFactor.Act(commitment.E))
+	}
+
+	r, _ := s.groupNonce.MarshalBinary()
+	publicKey, _ := s.key.PublicKey()
+	h := sha512.New()
+	h.Write(r)
+	h.Write(publicKey)
+	h.Write(s.msg)
+	challenge, err := ed25519.NewScalarFromHash(h.Sum(nil))
+	if err != nil {
+		return err
+	}
+	s.challenge = challenge
+	return nil
+}
+
+// aggregate verifies signature shares of all signers and sends the
+// aggregated signature to the result channel
+func (s *Signing) aggregate() error {
+	group := ed25519.Curve{}
+	lagrange := polynomial.Lagrange(group, s.signers())
+
+	culprits := []party.ID{}
+	z := group.NewScalar()
+	for _, id := range s.signers() {
+		share := group.NewScalar()
+		err := share.UnmarshalBinary(s.shares[id])
+		if err != nil {
+			culprits = append(culprits, id)
+			continue
+		}
+
+		commitment := s.commitments[id]
+		verificationShare := s.key.Key.VerificationShares.Points[id]
+		if verificationShare == nil {
+			culprits = append(culprits, id)
+			continue
+		}
+		expected := commitment.D.Add(s.bindingFactors[id].Act(commitment.E))
+		expected = expected.Add(group.NewScalar().Set(s.challenge).Mul(lagrange[id]).Act(verificationShare))
+		if !share.ActOnBase().Equal(expected) {
+			culprits = append(culprits, id)
+			continue
+		}
+
+		z.Add(share)
+	}
+	if len(culprits) > 0 {
+		return protocol.Error{Culprits: culprits, Err: errors.New("invalid signature shares")}
+	}
+
+	r, _ := s.groupNonce.MarshalBinary()
+	signature := append(r, z.(*ed25519.Scalar).Bytes()...)
+	publicKey, err := s.key.PublicKey()
+	if err != nil {
+		return err
+	}
+	if !stdEd25519.Verify(publicKey, s.msg, signature) {
+		return errors.New("invalid ed25519 signature")
+	}
+
+	s.Log.Info().Msg("Successfully generated signature")
+	s.resultChn <- Signature{
+		Signature: signature,
+		Id:        s.id,
+	}
+	s.Cancel()
+	return nil
+}
+
+// signers returns sorted party IDs of the signing subset
+func (s *Signing) signers() []party.ID {
+	signers := common.PartyIDSFromPeers(s.Peers)
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	return signers
+}
+
+// readyParticipants returns all ready peers that contain a valid key share
+func (s *Signing) readyParticipants(readyPeers []peer.ID) []peer.ID {
+	readyParticipants := make([]peer.ID, 0)
+	for _, peer := range readyPeers {
+
+		if !slices.Contains(s.key.Peers, peer) {
+			continue
+		}
+
+		readyParticipants = append(readyParticipants, peer)
+	}
+
+	return readyParticipants
+}
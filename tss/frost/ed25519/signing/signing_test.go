@@ -0,0 +1,141 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signing_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ChainSafe/sygma-relayer/comm"
+	"github.com/ChainSafe/sygma-relayer/comm/elector"
+	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss"
+	"github.com/ChainSafe/sygma-relayer/tss/frost/ed25519/signing"
+	tsstest "github.com/ChainSafe/sygma-relayer/tss/test"
+)
+
+type SigningTestSuite struct {
+	tsstest.CoordinatorTestSuite
+	fetchers  []*keyshare.Ed25519KeyshareStore
+	publicKey []byte
+}
+
+func TestRunSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningTestSuite))
+}
+
+func (s *SigningTestSuite) SetupTest() {
+	s.CoordinatorTestSuite.SetupTest()
+	s.fetchers = []*keyshare.Ed25519KeyshareStore{}
+	for i := range s.Hosts {
+		s.fetchers = append(s.fetchers, keyshare.NewEd25519KeyshareStore(fmt.Sprintf("../../../test/keyshares/%d-ed25519.keyshare", i)))
+	}
+
+	key, err := s.fetchers[0].GetKeyshare()
+	s.Nil(err)
+	s.publicKey, err = key.PublicKey()
+	s.Nil(err)
+}
+
+func (s *SigningTestSuite) Test_ValidSigningProcess() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	msg := []byte("Message")
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		signing, err := signing.NewSigning(1, msg, "signing1", "signing1", host, &communication, s.fetchers[i])
+		s.Nil(err)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(s.Hosts))
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	signature := (<-resultChn).(signing.Signature)
+	s.Equal(signature.Id, 1)
+	s.True(ed25519.Verify(s.publicKey, msg, signature.Signature))
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+}
+
+func (s *SigningTestSuite) sign(sessionID string, msg []byte) signing.Signature {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for i, host := range s.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		signing, err := signing.NewSigning(1, msg, sessionID, sessionID, host, &communication, s.fetchers[i])
+		s.Nil(err)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, signing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(s.Hosts))
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := pool.New().WithContext(ctx)
+	for i, coordinator := range coordinators {
+		coordinator := coordinator
+		process := processes[i]
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{process}, resultChn)
+		})
+	}
+
+	signature := (<-resultChn).(signing.Signature)
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	err := pool.Wait()
+	s.Nil(err)
+	return signature
+}
+
+func (s *SigningTestSuite) Test_ThresholdSignatures_VerifiedWithStandardLibrary() {
+	msgs := [][]byte{
+		{},
+		[]byte("Message"),
+		make([]byte, 1024),
+	}
+
+	for i, msg := range msgs {
+		signature := s.sign(fmt.Sprintf("signing-stdlib-%d", i), msg)
+
+		s.Len(signature.Signature, ed25519.SignatureSize)
+		s.True(ed25519.Verify(s.publicKey, msg, signature.Signature))
+		s.False(ed25519.Verify(s.publicKey, append(msg, 1), signature.Signature))
+	}
+}
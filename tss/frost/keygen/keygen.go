@@ -10,6 +10,7 @@ import (
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
 	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
 	"github.com/binance-chain/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -22,10 +23,12 @@ import (
 )
 
 type FrostKeyshareStorer interface {
-	StoreKeyshare(keyshare keyshare.FrostKeyshare) error
+	StoreKeyshareVersion(keyshare keyshare.FrostKeyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
 	LockKeyshare()
 	UnlockKeyshare()
 	GetKeyshare() (keyshare.FrostKeyshare, error)
+	KeyshareExists() bool
 }
 
 type Keygen struct {
@@ -33,6 +36,7 @@ type Keygen struct {
 	storer         FrostKeyshareStorer
 	threshold      int
 	subscriptionID comm.SubscriptionID
+	confirmation   *confirmation.Confirmation
 }
 
 func NewKeygen(
@@ -43,18 +47,20 @@ func NewKeygen(
 	storer FrostKeyshareStorer,
 ) *Keygen {
 	storer.LockKeyshare()
+	peers := host.Peerstore().Peers()
 	return &Keygen{
 		BaseFrostTss: common.BaseFrostTss{
 			Host:          host,
 			Communication: comm,
-			Peers:         host.Peerstore().Peers(),
+			Peers:         peers,
 			SID:           sessionID,
 			Log:           log.With().Str("SessionID", sessionID).Str("Process", "keygen").Logger(),
 			Cancel:        func() {},
 			Done:          make(chan bool),
 		},
-		storer:    storer,
-		threshold: threshold,
+		storer:       storer,
+		threshold:    threshold,
+		confirmation: confirmation.NewConfirmation(host, comm, sessionID, peers),
 	}
 }
 
@@ -72,6 +78,7 @@ func (k *Keygen) Run(
 	outChn := make(chan tss.Message)
 	msgChn := make(chan *comm.WrappedMessage)
 	k.subscriptionID = k.Communication.Subscribe(k.SessionID(), comm.TssKeyGenMsg, msgChn)
+	k.confirmation.Subscribe()
 
 	var err error
 	k.Handler, err = protocol.NewMultiHandler(
@@ -94,6 +101,7 @@ func (k *Keygen) Run(
 // Stop ends all subscriptions created when starting the tss process and unlocks keyshare.
 func (k *Keygen) Stop() {
 	k.Communication.UnSubscribe(k.subscriptionID)
+	k.confirmation.UnSubscribe()
 	k.storer.UnlockKeyshare()
 	k.Cancel()
 }
@@ -122,7 +130,9 @@ func (k *Keygen) Retryable() bool {
 	return false
 }
 
-// processEndMessage waits for the final message with generated key share and stores it locally.
+// processEndMessage waits for the final message with generated key share, confirms the public key
+// with other parties and stores the key share locally. Existing key share is never replaced,
+// the generated key share has to be activated by the operator in that case.
 func (k *Keygen) processEndMessage(ctx context.Context) error {
 
 	for {
@@ -135,7 +145,24 @@ func (k *Keygen) processEndMessage(ctx context.Context) error {
 				}
 				taprootConfig := result.(*frost.TaprootConfig)
 
-				err = k.storer.StoreKeyshare(keyshare.NewFrostKeyshare(taprootConfig, k.threshold, k.Peers))
+				err = k.confirmation.Confirm(ctx, taprootConfig.PublicKey)
+				if err != nil {
+					return err
+				}
+
+				version, err := k.storer.StoreKeyshareVersion(
+					keyshare.NewFrostKeyshare(taprootConfig, k.threshold, k.Peers),
+					keyshare.Metadata{SessionID: k.SessionID()},
+				)
+				if err != nil {
+					return err
+				}
+				if k.storer.KeyshareExists() {
+					k.Log.Warn().Msgf("Keyshare already exists, generated keyshare version %d has to be activated manually", version)
+					k.Cancel()
+					return nil
+				}
+				err = k.storer.ActivateKeyshare(version)
 				if err != nil {
 					return err
 				}
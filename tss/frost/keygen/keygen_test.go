@@ -40,11 +40,13 @@ func (s *KeygenTestSuite) Test_ValidKeygenProcess() {
 		s.MockFrostStorer.EXPECT().LockKeyshare()
 		keygen := keygen.NewKeygen("keygen", s.Threshold, host, &communication, s.MockFrostStorer)
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, keygen)
 	}
 	tsstest.SetupCommunication(communicationMap)
-	s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).Times(3)
+	s.MockFrostStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(1, nil).Times(3)
+	s.MockFrostStorer.EXPECT().KeyshareExists().Return(false).Times(3)
+	s.MockFrostStorer.EXPECT().ActivateKeyshare(1).Times(3)
 	s.MockFrostStorer.EXPECT().UnlockKeyshare().Times(3)
 
 	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
@@ -55,3 +57,36 @@ func (s *KeygenTestSuite) Test_ValidKeygenProcess() {
 	err := pool.Wait()
 	s.Nil(err)
 }
+
+func (s *KeygenTestSuite) Test_ValidKeygenProcess_ExistingKeyshareNotActivated() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	for _, host := range s.CoordinatorTestSuite.Hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		s.MockFrostStorer.EXPECT().LockKeyshare()
+		keygen := keygen.NewKeygen("keygen2", s.Threshold, host, &communication, s.MockFrostStorer)
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, keygen)
+	}
+	tsstest.SetupCommunication(communicationMap)
+	s.MockFrostStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(2, nil).Times(3)
+	s.MockFrostStorer.EXPECT().KeyshareExists().Return(true).Times(3)
+	s.MockFrostStorer.EXPECT().ActivateKeyshare(gomock.Any()).Times(0)
+	s.MockFrostStorer.EXPECT().UnlockKeyshare().Times(3)
+
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		i, coordinator := i, coordinator
+		pool.Go(func(ctx context.Context) error { return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, nil) })
+	}
+
+	err := pool.Wait()
+	s.Nil(err)
+}
@@ -4,31 +4,35 @@
 package resharing
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 
 	"github.com/ChainSafe/sygma-relayer/comm"
 	"github.com/ChainSafe/sygma-relayer/keyshare"
+	"github.com/ChainSafe/sygma-relayer/tss/confirmation"
 	"github.com/ChainSafe/sygma-relayer/tss/frost/common"
-	"github.com/binance-chain/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog/log"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
-	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
 	"github.com/taurusgroup/multi-party-sig/protocols/frost"
 )
 
 type startParams struct {
 	PublicKey          taproot.PublicKey
-	VerificationShares map[party.ID]*curve.Secp256k1Point
+	ChainKey           []byte
+	VerificationShares map[party.ID][]byte
 }
 type FrostKeyshareStorer interface {
 	GetKeyshare() (keyshare.FrostKeyshare, error)
-	StoreKeyshare(keyshare keyshare.FrostKeyshare) error
+	StoreKeyshareVersion(keyshare keyshare.FrostKeyshare, metadata keyshare.Metadata) (int, error)
+	ActivateKeyshare(version int) error
 	LockKeyshare()
 	UnlockKeyshare()
 }
@@ -37,8 +41,13 @@ type Resharing struct {
 	common.BaseFrostTss
 	key            keyshare.FrostKeyshare
 	subscriptionID comm.SubscriptionID
+	reshare        *common.Reshare
 	storer         FrostKeyshareStorer
 	newThreshold   int
+	topologyHash   string
+	confirmation   *confirmation.Confirmation
+	// deferActivation is set if the reshared keyshare is activated by the caller
+	deferActivation bool
 }
 
 func NewResharing(
@@ -47,6 +56,7 @@ func NewResharing(
 	host host.Host,
 	comm comm.Communication,
 	storer FrostKeyshareStorer,
+	topologyHash string,
 ) *Resharing {
 	storer.LockKeyshare()
 	var key keyshare.FrostKeyshare
@@ -65,11 +75,12 @@ func NewResharing(
 	}
 	key.Key.Threshold = threshold
 
+	peers := host.Peerstore().Peers()
 	return &Resharing{
 		BaseFrostTss: common.BaseFrostTss{
 			Host:          host,
 			Communication: comm,
-			Peers:         host.Peerstore().Peers(),
+			Peers:         peers,
 			SID:           sessionID,
 			Log:           log.With().Str("SessionID", sessionID).Str("Process", "resharing").Logger(),
 			Cancel:        func() {},
@@ -78,9 +89,17 @@ func NewResharing(
 		key:          key,
 		storer:       storer,
 		newThreshold: threshold,
+		topologyHash: topologyHash,
+		confirmation: confirmation.NewConfirmation(host, comm, sessionID, peers),
 	}
 }
 
+// DeferActivation stores the reshared keyshare without activating it. Stored version is sent
+// to the result channel so it can be activated together with keyshares reshared in other sessions.
+func (r *Resharing) DeferActivation() {
+	r.deferActivation = true
+}
+
 // Run initializes the signing party and runs the resharing tss process.
 // Params contains peer subset that leaders sends with start message.
 func (r *Resharing) Run(
@@ -92,41 +111,38 @@ func (r *Resharing) Run(
 	ctx, r.Cancel = context.WithCancel(ctx)
 	var err error
 
-	outChn := make(chan tss.Message)
 	msgChn := make(chan *comm.WrappedMessage)
 	r.subscriptionID = r.Communication.Subscribe(r.SessionID(), comm.TssReshareMsg, msgChn)
+	r.confirmation.Subscribe()
 	startParams, err := r.unmarshallStartParams(params)
 	if err != nil {
 		return err
 	}
 	// initialize verification shares for the new relayer
 	if len(r.key.Key.VerificationShares) == 0 {
-		r.key.Key.VerificationShares = startParams.VerificationShares
-		r.key.Key.PublicKey = startParams.PublicKey
-	}
-
-	// Add a new verification share for each party that does not already have one
-	partyIds := common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID()))
-	group := curve.Secp256k1{}
-	for _, k := range partyIds {
-		if r.key.Key.VerificationShares[k] == nil {
-			r.key.Key.VerificationShares[k] = group.NewPoint().(*curve.Secp256k1Point)
+		err = r.initializeKey(startParams)
+		if err != nil {
+			return err
 		}
 	}
 
-	r.Handler, err = protocol.NewMultiHandler(
-		frost.RefreshTaproot(
-			r.key.Key,
-			common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID()))),
-		[]byte(r.SessionID()))
-	if err != nil {
-		return err
+	verificationShares := make(map[party.ID]curve.Point)
+	for id, point := range r.key.Key.VerificationShares {
+		verificationShares[id] = point
 	}
+	r.reshare = common.NewReshare(
+		curve.Secp256k1{},
+		r.key.Key.ID,
+		r.newThreshold,
+		common.PartyIDSFromPeers(append(r.Host.Peerstore().Peers(), r.Host.ID())),
+		r.key.Key.PrivateShare,
+		verificationShares,
+	)
 
 	p := pool.New().WithContext(ctx).WithCancelOnError()
-	p.Go(func(ctx context.Context) error { return r.ProcessInboundMessages(ctx, msgChn) })
-	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx) })
-	p.Go(func(ctx context.Context) error { return r.ProcessOutboundMessages(ctx, outChn, comm.TssReshareMsg) })
+	p.Go(func(ctx context.Context) error { return r.ProcessReshareMessages(ctx, r.reshare, msgChn) })
+	p.Go(func(ctx context.Context) error { return r.processEndMessage(ctx, resultChn) })
+	p.Go(func(ctx context.Context) error { return r.DealReshare(ctx, r.reshare) })
 
 	r.Log.Info().Msgf("Started resharing process")
 	return p.Wait()
@@ -136,6 +152,7 @@ func (r *Resharing) Run(
 func (r *Resharing) Stop() {
 	r.Log.Info().Msgf("Stopping tss process.")
 	r.Communication.UnSubscribe(r.subscriptionID)
+	r.confirmation.UnSubscribe()
 	r.storer.UnlockKeyshare()
 	r.Cancel()
 }
@@ -150,9 +167,15 @@ func (r *Resharing) ValidCoordinators() []peer.ID {
 }
 
 func (r *Resharing) StartParams(readyPeers []peer.ID) []byte {
+	verificationShares := make(map[party.ID][]byte)
+	for id, point := range r.key.Key.VerificationShares {
+		verificationShares[id], _ = point.MarshalBinary()
+	}
+
 	startParams := &startParams{
 		PublicKey:          r.key.Key.PublicKey,
-		VerificationShares: r.key.Key.VerificationShares,
+		ChainKey:           r.key.Key.ChainKey,
+		VerificationShares: verificationShares,
 	}
 	paramBytes, _ := json.Marshal(startParams)
 	return paramBytes
@@ -168,27 +191,85 @@ func (r *Resharing) unmarshallStartParams(paramBytes []byte) (startParams, error
 	return startParams, nil
 }
 
+// initializeKey sets public key and verification shares received from
+// the coordinator for parties that don't have a keyshare
+func (r *Resharing) initializeKey(startParams startParams) error {
+	verificationShares := make(map[party.ID]*curve.Secp256k1Point)
+	for id, pointBytes := range startParams.VerificationShares {
+		point := curve.Secp256k1{}.NewPoint().(*curve.Secp256k1Point)
+		err := point.UnmarshalBinary(pointBytes)
+		if err != nil {
+			return err
+		}
+		verificationShares[id] = point
+	}
+
+	r.key.Key.PublicKey = startParams.PublicKey
+	r.key.Key.ChainKey = startParams.ChainKey
+	r.key.Key.VerificationShares = verificationShares
+	return nil
+}
+
 func (r *Resharing) Retryable() bool {
 	return false
 }
 
-// processEndMessage waits for the final message with generated key share and stores it locally.
-func (r *Resharing) processEndMessage(ctx context.Context) error {
-
+// processEndMessage waits for the final message with generated key share, confirms commitments
+// of all dealers with other parties and stores the key share locally.
+// Key share is activated unless activation is deferred.
+func (r *Resharing) processEndMessage(ctx context.Context, resultChn chan interface{}) error {
 	for {
 		select {
 		case <-r.Done:
 			{
-				result, err := r.Handler.Result()
+				privateShare, verificationShares, publicKey, err := r.reshare.Result()
 				if err != nil {
 					return err
 				}
-				taprootConfig := result.(*frost.TaprootConfig)
+				reshared := publicKey.(*curve.Secp256k1Point)
+				if !reshared.HasEvenY() || !bytes.Equal(reshared.XBytes(), r.key.Key.PublicKey) {
+					return fmt.Errorf(
+						"reshared public key %s doesn't match public key %s",
+						hex.EncodeToString(reshared.XBytes()), hex.EncodeToString(r.key.Key.PublicKey),
+					)
+				}
 
-				err = r.storer.StoreKeyshare(keyshare.NewFrostKeyshare(taprootConfig, r.newThreshold, r.Peers))
+				transcript, err := r.reshare.Transcript(publicKey)
 				if err != nil {
 					return err
 				}
+				err = r.confirmation.Confirm(ctx, transcript)
+				if err != nil {
+					return err
+				}
+
+				taprootConfig := &frost.TaprootConfig{
+					ID:                 r.key.Key.ID,
+					Threshold:          r.newThreshold,
+					PrivateShare:       privateShare.(*curve.Secp256k1Scalar),
+					PublicKey:          r.key.Key.PublicKey,
+					ChainKey:           r.key.Key.ChainKey,
+					VerificationShares: make(map[party.ID]*curve.Secp256k1Point),
+				}
+				for id, point := range verificationShares {
+					taprootConfig.VerificationShares[id] = point.(*curve.Secp256k1Point)
+				}
+
+				version, err := r.storer.StoreKeyshareVersion(
+					keyshare.NewFrostKeyshare(taprootConfig, r.newThreshold, r.Peers),
+					keyshare.Metadata{SessionID: r.SessionID(), TopologyHash: r.topologyHash},
+				)
+				if err != nil {
+					return err
+				}
+				if r.deferActivation {
+					resultChn <- version
+				} else {
+					err = r.storer.ActivateKeyshare(version)
+					if err != nil {
+						return err
+					}
+				}
 
 				r.Log.Info().Msgf("Refreshed key")
 				r.Cancel()
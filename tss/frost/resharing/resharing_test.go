@@ -57,10 +57,11 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_OldAndNewSubset() {
 		s.MockFrostStorer.EXPECT().LockKeyshare()
 		s.MockFrostStorer.EXPECT().UnlockKeyshare()
 		s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
-		s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer)
+		s.MockFrostStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(1, nil)
+		s.MockFrostStorer.EXPECT().ActivateKeyshare(1)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -104,10 +105,11 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_RemovePeer() {
 		s.MockFrostStorer.EXPECT().LockKeyshare()
 		s.MockFrostStorer.EXPECT().UnlockKeyshare()
 		s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
-		s.MockFrostStorer.EXPECT().StoreKeyshare(gomock.Any()).Return(nil)
-		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer)
+		s.MockFrostStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(1, nil)
+		s.MockFrostStorer.EXPECT().ActivateKeyshare(1)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer, "")
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, resharing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -123,3 +125,54 @@ func (s *ResharingTestSuite) Test_ValidResharingProcess_RemovePeer() {
 	err := pool.Wait()
 	s.Nil(err)
 }
+
+func (s *ResharingTestSuite) Test_ValidResharingProcess_DeferredActivation() {
+	communicationMap := make(map[peer.ID]*tsstest.TestCommunication)
+	coordinators := []*tss.Coordinator{}
+	processes := []tss.TssProcess{}
+
+	hosts := []host.Host{}
+	for i := 0; i < s.PartyNumber; i++ {
+		host, _ := tsstest.NewHost(i)
+		hosts = append(hosts, host)
+	}
+	for _, host := range hosts {
+		for _, peer := range hosts {
+			host.Peerstore().AddAddr(peer.ID(), peer.Addrs()[0], peerstore.PermanentAddrTTL)
+		}
+	}
+
+	for i, host := range hosts {
+		communication := tsstest.TestCommunication{
+			Host:          host,
+			Subscriptions: make(map[comm.SubscriptionID]chan *comm.WrappedMessage),
+		}
+		communicationMap[host.ID()] = &communication
+		storer := keyshare.NewFrostKeyshareStore(fmt.Sprintf("../../test/keyshares/%d-frost.keyshare", i))
+		share, err := storer.GetKeyshare()
+		s.MockFrostStorer.EXPECT().LockKeyshare()
+		s.MockFrostStorer.EXPECT().UnlockKeyshare()
+		s.MockFrostStorer.EXPECT().GetKeyshare().Return(share, err)
+		s.MockFrostStorer.EXPECT().StoreKeyshareVersion(gomock.Any(), gomock.Any()).Return(2, nil)
+		resharing := resharing.NewResharing("resharing2", 1, host, &communication, s.MockFrostStorer, "")
+		resharing.DeferActivation()
+		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
+		processes = append(processes, resharing)
+	}
+	tsstest.SetupCommunication(communicationMap)
+
+	resultChn := make(chan interface{}, len(hosts))
+	pool := pool.New().WithContext(context.Background()).WithCancelOnError()
+	for i, coordinator := range coordinators {
+		i, coordinator := i, coordinator
+		pool.Go(func(ctx context.Context) error {
+			return coordinator.Execute(ctx, []tss.TssProcess{processes[i]}, resultChn)
+		})
+	}
+
+	err := pool.Wait()
+	s.Nil(err)
+	s.Equal(len(resultChn), len(hosts))
+	s.Equal(<-resultChn, 2)
+}
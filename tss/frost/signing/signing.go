@@ -39,6 +39,7 @@ type SaveDataFetcher interface {
 
 type Signing struct {
 	common.BaseFrostTss
+	util.TieredReadiness
 	id             int
 	coordinator    bool
 	key            keyshare.FrostKeyshare
@@ -64,17 +65,7 @@ func NewSigning(
 		return nil, err
 	}
 
-	tweakBytes, err := hex.DecodeString(tweak)
-	if err != nil {
-		return nil, err
-	}
-
-	h := &curve.Secp256k1Scalar{}
-	err = h.UnmarshalBinary(tweakBytes)
-	if err != nil {
-		return nil, err
-	}
-	key.Key, err = key.Key.Derive(h, nil)
+	key.Key, err = tweakKey(key, tweak)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +86,30 @@ func NewSigning(
 	}, nil
 }
 
+// TweakedPublicKey returns taproot public key of the keyshare tweaked with the
+// hex encoded tweak which signatures generated with the tweak verify against
+func TweakedPublicKey(key keyshare.FrostKeyshare, tweak string) (taproot.PublicKey, error) {
+	tweakedKey, err := tweakKey(key, tweak)
+	if err != nil {
+		return nil, err
+	}
+	return tweakedKey.PublicKey, nil
+}
+
+func tweakKey(key keyshare.FrostKeyshare, tweak string) (*frost.TaprootConfig, error) {
+	tweakBytes, err := hex.DecodeString(tweak)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &curve.Secp256k1Scalar{}
+	err = h.UnmarshalBinary(tweakBytes)
+	if err != nil {
+		return nil, err
+	}
+	return key.Key.Derive(h, nil)
+}
+
 // Run initializes the signing party and runs the signing tss process.
 // Params contains peer subset that leaders sends with start message.
 func (s *Signing) Run(
@@ -146,10 +161,11 @@ func (s *Signing) Stop() {
 	s.Cancel()
 }
 
-// Ready returns true if threshold+1 parties are ready to start the signing process.
+// Ready returns true if threshold+1 well-behaved parties are ready to start the signing process.
+// Parties with worse reputation are accepted if all parties are ready or if not enough
+// well-behaved parties got ready during the grace period.
 func (s *Signing) Ready(readyPeers []peer.ID, excludedPeers []peer.ID) (bool, error) {
-	readyPeers = s.readyParticipants(readyPeers)
-	return len(readyPeers) == s.key.Threshold+1, nil
+	return s.TiersReady(s.readyParticipants(readyPeers), s.key.Peers, s.key.Threshold), nil
 }
 
 // ValidCoordinators returns only peers that have a valid keyshare
@@ -158,14 +174,14 @@ func (s *Signing) ValidCoordinators() []peer.ID {
 }
 
 // StartParams returns peer subset for this tss process. It is calculated
-// by sorting hashes of peer IDs and session ID and chosing ready peers alphabetically
-// until threshold is satisfied.
+// by sorting ready peers by reputation tier and hashes of peer IDs and session ID
+// and chosing ready peers in order until threshold is satisfied.
 func (s *Signing) StartParams(readyPeers []peer.ID) []byte {
 	readyPeers = s.readyParticipants(readyPeers)
 	peers := []peer.ID{}
 	peers = append(peers, readyPeers...)
 
-	sortedPeers := util.SortPeersForSession(peers, s.SessionID())
+	sortedPeers := util.SortPeersByTier(peers, s.SessionID(), s.PeerTiers())
 	peerSubset := []peer.ID{}
 	for _, peer := range sortedPeers {
 		peerSubset = append(peerSubset, peer.ID)
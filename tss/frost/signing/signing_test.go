@@ -47,6 +47,9 @@ func (s *SigningTestSuite) Test_ValidSigningProcess() {
 	s.Nil(err)
 	tweakedKeyshare, err := testKeyshare.Key.Derive(h, nil)
 	s.Nil(err)
+	tweakedPublicKey, err := signing.TweakedPublicKey(testKeyshare, tweak)
+	s.Nil(err)
+	s.Equal(tweakedPublicKey, tweakedKeyshare.PublicKey)
 
 	msgBytes := []byte("Message")
 	for i, host := range s.Hosts {
@@ -62,7 +65,7 @@ func (s *SigningTestSuite) Test_ValidSigningProcess() {
 			panic(err)
 		}
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory))
+		coordinators = append(coordinators, tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation))
 		processes = append(processes, signing)
 	}
 	tsstest.SetupCommunication(communicationMap)
@@ -123,7 +126,7 @@ func (s *SigningTestSuite) Test_MultipleProcesses() {
 			panic(err)
 		}
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
 		coordinators = append(coordinators, coordinator)
 		processes = append(processes, []tss.TssProcess{signing1, signing2, signing3})
 	}
@@ -181,7 +184,7 @@ func (s *SigningTestSuite) Test_ProcessTimeout() {
 			panic(err)
 		}
 		electorFactory := elector.NewCoordinatorElectorFactory(host, s.BullyConfig)
-		coordinator := tss.NewCoordinator(host, &communication, electorFactory)
+		coordinator := tss.NewCoordinator(host, &communication, electorFactory, s.MockReputation)
 		coordinator.TssTimeout = time.Nanosecond
 		coordinators = append(coordinators, coordinator)
 		processes = append(processes, signing)
@@ -36,13 +36,47 @@ func UnmarshalTssMessage(msgBytes []byte) (*TssMessage, error) {
 	return msg, nil
 }
 
+// BatchTssMessage is a tss message of the local party signing the message at index of the batch
+type BatchTssMessage struct {
+	Index       int    `json:"index"`
+	MsgBytes    []byte `json:"msgBytes"`
+	IsBroadcast bool   `json:"isBroadcast"`
+}
+
+func MarshalBatchTssMessage(index int, msgBytes []byte, isBroadcast bool) ([]byte, error) {
+	batchMsg := &BatchTssMessage{
+		Index:       index,
+		IsBroadcast: isBroadcast,
+		MsgBytes:    msgBytes,
+	}
+
+	msgBytes, err := json.Marshal(batchMsg)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalBatchTssMessage(msgBytes []byte) (*BatchTssMessage, error) {
+	msg := &BatchTssMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
 type StartMessage struct {
-	Params []byte `json:"params"`
+	Params    []byte         `json:"params"`
+	PeerTiers map[string]int `json:"peerTiers,omitempty"`
 }
 
-func MarshalStartMessage(params []byte) ([]byte, error) {
+func MarshalStartMessage(params []byte, peerTiers map[string]int) ([]byte, error) {
 	startSignMessage := &StartMessage{
-		Params: params,
+		Params:    params,
+		PeerTiers: peerTiers,
 	}
 
 	msgBytes, err := json.Marshal(startSignMessage)
@@ -62,3 +96,30 @@ func UnmarshalStartMessage(msgBytes []byte) (*StartMessage, error) {
 
 	return msg, nil
 }
+
+type ReadyMessage struct {
+	PeerScores map[string]float64 `json:"peerScores"`
+}
+
+func MarshalReadyMessage(peerScores map[string]float64) ([]byte, error) {
+	readyMessage := &ReadyMessage{
+		PeerScores: peerScores,
+	}
+
+	msgBytes, err := json.Marshal(readyMessage)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return msgBytes, nil
+}
+
+func UnmarshalReadyMessage(msgBytes []byte) (*ReadyMessage, error) {
+	msg := &ReadyMessage{}
+	err := json.Unmarshal(msgBytes, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
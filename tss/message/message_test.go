@@ -32,6 +32,29 @@ func (s *TssMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 	s.Equal(originalMsg, unmarshaledMsg)
 }
 
+type BatchTssMessageTestSuite struct {
+	suite.Suite
+}
+
+func TestRunBatchTssMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchTssMessageTestSuite))
+}
+
+func (s *BatchTssMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
+	originalMsg := &message.BatchTssMessage{
+		Index:       2,
+		MsgBytes:    []byte{1},
+		IsBroadcast: true,
+	}
+	msgBytes, err := message.MarshalBatchTssMessage(originalMsg.Index, originalMsg.MsgBytes, originalMsg.IsBroadcast)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalBatchTssMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
+
 type StartMessageTestSuite struct {
 	suite.Suite
 }
@@ -44,7 +67,21 @@ func (s *StartMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 	originalMsg := &message.StartMessage{
 		Params: []byte("test"),
 	}
-	msgBytes, err := message.MarshalStartMessage(originalMsg.Params)
+	msgBytes, err := message.MarshalStartMessage(originalMsg.Params, originalMsg.PeerTiers)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalStartMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
+
+func (s *StartMessageTestSuite) Test_UnmarshaledMessageWithTiersShouldBeEqual() {
+	originalMsg := &message.StartMessage{
+		Params:    []byte("test"),
+		PeerTiers: map[string]int{"QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54": 1},
+	}
+	msgBytes, err := message.MarshalStartMessage(originalMsg.Params, originalMsg.PeerTiers)
 	s.Nil(err)
 
 	unmarshaledMsg, err := message.UnmarshalStartMessage(msgBytes)
@@ -52,3 +89,24 @@ func (s *StartMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
 
 	s.Equal(originalMsg, unmarshaledMsg)
 }
+
+type ReadyMessageTestSuite struct {
+	suite.Suite
+}
+
+func TestRunReadyMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(ReadyMessageTestSuite))
+}
+
+func (s *ReadyMessageTestSuite) Test_UnmarshaledMessageShouldBeEqual() {
+	originalMsg := &message.ReadyMessage{
+		PeerScores: map[string]float64{"QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54": 1.5},
+	}
+	msgBytes, err := message.MarshalReadyMessage(originalMsg.PeerScores)
+	s.Nil(err)
+
+	unmarshaledMsg, err := message.UnmarshalReadyMessage(msgBytes)
+	s.Nil(err)
+
+	s.Equal(originalMsg, unmarshaledMsg)
+}
@@ -8,6 +8,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	reputation "github.com/ChainSafe/sygma-relayer/tss/reputation"
+	util "github.com/ChainSafe/sygma-relayer/tss/util"
 	gomock "github.com/golang/mock/gomock"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 )
@@ -131,3 +133,87 @@ func (mr *MockTssProcessMockRecorder) ValidCoordinators() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidCoordinators", reflect.TypeOf((*MockTssProcess)(nil).ValidCoordinators))
 }
+
+// MockReputationAwareProcess is a mock of ReputationAwareProcess interface.
+type MockReputationAwareProcess struct {
+	ctrl     *gomock.Controller
+	recorder *MockReputationAwareProcessMockRecorder
+}
+
+// MockReputationAwareProcessMockRecorder is the mock recorder for MockReputationAwareProcess.
+type MockReputationAwareProcessMockRecorder struct {
+	mock *MockReputationAwareProcess
+}
+
+// NewMockReputationAwareProcess creates a new mock instance.
+func NewMockReputationAwareProcess(ctrl *gomock.Controller) *MockReputationAwareProcess {
+	mock := &MockReputationAwareProcess{ctrl: ctrl}
+	mock.recorder = &MockReputationAwareProcessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReputationAwareProcess) EXPECT() *MockReputationAwareProcessMockRecorder {
+	return m.recorder
+}
+
+// SetPeerTiers mocks base method.
+func (m *MockReputationAwareProcess) SetPeerTiers(peerTiers util.PeerTiers) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPeerTiers", peerTiers)
+}
+
+// SetPeerTiers indicates an expected call of SetPeerTiers.
+func (mr *MockReputationAwareProcessMockRecorder) SetPeerTiers(peerTiers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerTiers", reflect.TypeOf((*MockReputationAwareProcess)(nil).SetPeerTiers), peerTiers)
+}
+
+// MockPeerReputation is a mock of PeerReputation interface.
+type MockPeerReputation struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeerReputationMockRecorder
+}
+
+// MockPeerReputationMockRecorder is the mock recorder for MockPeerReputation.
+type MockPeerReputationMockRecorder struct {
+	mock *MockPeerReputation
+}
+
+// NewMockPeerReputation creates a new mock instance.
+func NewMockPeerReputation(ctrl *gomock.Controller) *MockPeerReputation {
+	mock := &MockPeerReputation{ctrl: ctrl}
+	mock.recorder = &MockPeerReputationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeerReputation) EXPECT() *MockPeerReputationMockRecorder {
+	return m.recorder
+}
+
+// Penalize mocks base method.
+func (m *MockPeerReputation) Penalize(p peer.ID, offence reputation.Offence) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Penalize", p, offence)
+}
+
+// Penalize indicates an expected call of Penalize.
+func (mr *MockPeerReputationMockRecorder) Penalize(p, offence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Penalize", reflect.TypeOf((*MockPeerReputation)(nil).Penalize), p, offence)
+}
+
+// Scores mocks base method.
+func (m *MockPeerReputation) Scores(peers []peer.ID) map[peer.ID]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scores", peers)
+	ret0, _ := ret[0].(map[peer.ID]float64)
+	return ret0
+}
+
+// Scores indicates an expected call of Scores.
+func (mr *MockPeerReputationMockRecorder) Scores(peers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scores", reflect.TypeOf((*MockPeerReputation)(nil).Scores), peers)
+}
@@ -34,6 +34,20 @@ func (m *MockECDSAKeyshareStorer) EXPECT() *MockECDSAKeyshareStorerMockRecorder
 	return m.recorder
 }
 
+// ActivateKeyshare mocks base method.
+func (m *MockECDSAKeyshareStorer) ActivateKeyshare(version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeyshare", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateKeyshare indicates an expected call of ActivateKeyshare.
+func (mr *MockECDSAKeyshareStorerMockRecorder) ActivateKeyshare(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).ActivateKeyshare), version)
+}
+
 // GetKeyshare mocks base method.
 func (m *MockECDSAKeyshareStorer) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +63,20 @@ func (mr *MockECDSAKeyshareStorerMockRecorder) GetKeyshare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).GetKeyshare))
 }
 
+// KeyshareExists mocks base method.
+func (m *MockECDSAKeyshareStorer) KeyshareExists() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyshareExists")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// KeyshareExists indicates an expected call of KeyshareExists.
+func (mr *MockECDSAKeyshareStorerMockRecorder) KeyshareExists() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyshareExists", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).KeyshareExists))
+}
+
 // LockKeyshare mocks base method.
 func (m *MockECDSAKeyshareStorer) LockKeyshare() {
 	m.ctrl.T.Helper()
@@ -61,18 +89,19 @@ func (mr *MockECDSAKeyshareStorerMockRecorder) LockKeyshare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).LockKeyshare))
 }
 
-// StoreKeyshare mocks base method.
-func (m *MockECDSAKeyshareStorer) StoreKeyshare(keyshare keyshare.ECDSAKeyshare) error {
+// StoreKeyshareVersion mocks base method.
+func (m *MockECDSAKeyshareStorer) StoreKeyshareVersion(keyshare keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreKeyshare", keyshare)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "StoreKeyshareVersion", keyshare, metadata)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StoreKeyshare indicates an expected call of StoreKeyshare.
-func (mr *MockECDSAKeyshareStorerMockRecorder) StoreKeyshare(keyshare interface{}) *gomock.Call {
+// StoreKeyshareVersion indicates an expected call of StoreKeyshareVersion.
+func (mr *MockECDSAKeyshareStorerMockRecorder) StoreKeyshareVersion(keyshare, metadata interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshare", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).StoreKeyshare), keyshare)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshareVersion", reflect.TypeOf((*MockECDSAKeyshareStorer)(nil).StoreKeyshareVersion), keyshare, metadata)
 }
 
 // UnlockKeyshare mocks base method.
@@ -34,6 +34,20 @@ func (m *MockFrostKeyshareStorer) EXPECT() *MockFrostKeyshareStorerMockRecorder
 	return m.recorder
 }
 
+// ActivateKeyshare mocks base method.
+func (m *MockFrostKeyshareStorer) ActivateKeyshare(version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeyshare", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateKeyshare indicates an expected call of ActivateKeyshare.
+func (mr *MockFrostKeyshareStorerMockRecorder) ActivateKeyshare(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).ActivateKeyshare), version)
+}
+
 // GetKeyshare mocks base method.
 func (m *MockFrostKeyshareStorer) GetKeyshare() (keyshare.FrostKeyshare, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +63,20 @@ func (mr *MockFrostKeyshareStorerMockRecorder) GetKeyshare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).GetKeyshare))
 }
 
+// KeyshareExists mocks base method.
+func (m *MockFrostKeyshareStorer) KeyshareExists() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyshareExists")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// KeyshareExists indicates an expected call of KeyshareExists.
+func (mr *MockFrostKeyshareStorerMockRecorder) KeyshareExists() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyshareExists", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).KeyshareExists))
+}
+
 // LockKeyshare mocks base method.
 func (m *MockFrostKeyshareStorer) LockKeyshare() {
 	m.ctrl.T.Helper()
@@ -61,18 +89,19 @@ func (mr *MockFrostKeyshareStorerMockRecorder) LockKeyshare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).LockKeyshare))
 }
 
-// StoreKeyshare mocks base method.
-func (m *MockFrostKeyshareStorer) StoreKeyshare(keyshare keyshare.FrostKeyshare) error {
+// StoreKeyshareVersion mocks base method.
+func (m *MockFrostKeyshareStorer) StoreKeyshareVersion(keyshare keyshare.FrostKeyshare, metadata keyshare.Metadata) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreKeyshare", keyshare)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "StoreKeyshareVersion", keyshare, metadata)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StoreKeyshare indicates an expected call of StoreKeyshare.
-func (mr *MockFrostKeyshareStorerMockRecorder) StoreKeyshare(keyshare interface{}) *gomock.Call {
+// StoreKeyshareVersion indicates an expected call of StoreKeyshareVersion.
+func (mr *MockFrostKeyshareStorerMockRecorder) StoreKeyshareVersion(keyshare, metadata interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshare", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).StoreKeyshare), keyshare)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshareVersion", reflect.TypeOf((*MockFrostKeyshareStorer)(nil).StoreKeyshareVersion), keyshare, metadata)
 }
 
 // UnlockKeyshare mocks base method.
@@ -34,6 +34,20 @@ func (m *MockSaveDataStorer) EXPECT() *MockSaveDataStorerMockRecorder {
 	return m.recorder
 }
 
+// ActivateKeyshare mocks base method.
+func (m *MockSaveDataStorer) ActivateKeyshare(version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeyshare", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateKeyshare indicates an expected call of ActivateKeyshare.
+func (mr *MockSaveDataStorerMockRecorder) ActivateKeyshare(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeyshare", reflect.TypeOf((*MockSaveDataStorer)(nil).ActivateKeyshare), version)
+}
+
 // GetKeyshare mocks base method.
 func (m *MockSaveDataStorer) GetKeyshare() (keyshare.ECDSAKeyshare, error) {
 	m.ctrl.T.Helper()
@@ -61,18 +75,19 @@ func (mr *MockSaveDataStorerMockRecorder) LockKeyshare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockKeyshare", reflect.TypeOf((*MockSaveDataStorer)(nil).LockKeyshare))
 }
 
-// StoreKeyshare mocks base method.
-func (m *MockSaveDataStorer) StoreKeyshare(keyshare keyshare.ECDSAKeyshare) error {
+// StoreKeyshareVersion mocks base method.
+func (m *MockSaveDataStorer) StoreKeyshareVersion(keyshare keyshare.ECDSAKeyshare, metadata keyshare.Metadata) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StoreKeyshare", keyshare)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "StoreKeyshareVersion", keyshare, metadata)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// StoreKeyshare indicates an expected call of StoreKeyshare.
-func (mr *MockSaveDataStorerMockRecorder) StoreKeyshare(keyshare interface{}) *gomock.Call {
+// StoreKeyshareVersion indicates an expected call of StoreKeyshareVersion.
+func (mr *MockSaveDataStorerMockRecorder) StoreKeyshareVersion(keyshare, metadata interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshare", reflect.TypeOf((*MockSaveDataStorer)(nil).StoreKeyshare), keyshare)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreKeyshareVersion", reflect.TypeOf((*MockSaveDataStorer)(nil).StoreKeyshareVersion), keyshare, metadata)
 }
 
 // UnlockKeyshare mocks base method.
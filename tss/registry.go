@@ -0,0 +1,236 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/slices"
+)
+
+// PartyWaiter is implemented by tss processes that can report
+// which parties the current round is waiting on
+type PartyWaiter interface {
+	WaitingFor() []peer.ID
+}
+
+// Session is a snapshot of a pending tss session
+type Session struct {
+	SessionID     string    `json:"sessionID"`
+	ProcessType   string    `json:"processType"`
+	Coordinator   string    `json:"coordinator"`
+	ReadyPeers    []string  `json:"readyPeers"`
+	ExcludedPeers []string  `json:"excludedPeers"`
+	StartTime     time.Time `json:"startTime"`
+	Retries       int       `json:"retries"`
+	WaitingFor    []string  `json:"waitingFor"`
+}
+
+type session struct {
+	processes     []TssProcess
+	processType   string
+	coordinator   peer.ID
+	readyPeers    []peer.ID
+	excludedPeers []peer.ID
+	startTime     time.Time
+	retries       int
+	peerTiers     util.PeerTiers
+}
+
+// SessionRegistry tracks tss sessions pending on the relayer
+type SessionRegistry struct {
+	sessions map[string]*session
+	lock     sync.RWMutex
+}
+
+func NewSessionRegistry() *SessionRegistry {
+	return &SessionRegistry{
+		sessions: make(map[string]*session),
+	}
+}
+
+// Sessions returns snapshots of all pending sessions sorted by start time
+func (r *SessionRegistry) Sessions() []Session {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	sessions := make([]Session, 0, len(r.sessions))
+	for sessionID, s := range r.sessions {
+		sessions = append(sessions, s.snapshot(sessionID))
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions
+}
+
+// Session returns snapshot of the pending session with the provided session ID
+func (r *SessionRegistry) Session(sessionID string) (Session, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		return Session{}, false
+	}
+	return s.snapshot(sessionID), true
+}
+
+// ServeHTTP returns pending sessions as JSON. Single session is
+// returned if the sessionID query parameter is provided.
+func (r *SessionRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var response interface{}
+	sessionID := req.URL.Query().Get("sessionID")
+	if sessionID != "" {
+		s, ok := r.Session(sessionID)
+		if !ok {
+			http.Error(w, fmt.Sprintf("session %s not found", sessionID), http.StatusNotFound)
+			return
+		}
+		response = s
+	} else {
+		response = r.Sessions()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// register adds a new session to the registry and
+// fails if the session is already pending
+func (r *SessionRegistry) register(sessionID string, processes []TssProcess) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.sessions[sessionID]; ok {
+		return fmt.Errorf("process already pending")
+	}
+	r.sessions[sessionID] = &session{
+		processes:     processes,
+		processType:   processType(processes[0]),
+		readyPeers:    []peer.ID{},
+		excludedPeers: []peer.ID{},
+		startTime:     time.Now(),
+	}
+	return nil
+}
+
+func (r *SessionRegistry) unregister(sessionID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.sessions, sessionID)
+}
+
+func (r *SessionRegistry) setCoordinator(sessionID string, coordinator peer.ID) {
+	r.update(sessionID, func(s *session) {
+		s.coordinator = coordinator
+		s.readyPeers = []peer.ID{}
+	})
+}
+
+func (r *SessionRegistry) setReadyPeers(sessionID string, readyPeers []peer.ID) {
+	r.update(sessionID, func(s *session) {
+		s.readyPeers = slices.Clone(readyPeers)
+	})
+}
+
+// retry increments session retries and records peers excluded from the retry
+func (r *SessionRegistry) retry(sessionID string, excludedPeers []peer.ID) {
+	r.update(sessionID, func(s *session) {
+		s.retries++
+		s.excludedPeers = slices.Clone(excludedPeers)
+	})
+}
+
+// setPeerTiers records peer tiers agreed in the session start message
+func (r *SessionRegistry) setPeerTiers(sessionID string, peerTiers util.PeerTiers) {
+	r.update(sessionID, func(s *session) {
+		s.peerTiers = peerTiers
+	})
+}
+
+func (r *SessionRegistry) peerTiers(sessionID string) util.PeerTiers {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		return nil
+	}
+	return s.peerTiers
+}
+
+func (r *SessionRegistry) update(sessionID string, updateFn func(s *session)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		return
+	}
+	updateFn(s)
+}
+
+func (s *session) snapshot(sessionID string) Session {
+	waitingFor := make([]peer.ID, 0)
+	for _, process := range s.processes {
+		waiter, ok := process.(PartyWaiter)
+		if !ok {
+			continue
+		}
+		for _, peerID := range waiter.WaitingFor() {
+			if !slices.Contains(waitingFor, peerID) {
+				waitingFor = append(waitingFor, peerID)
+			}
+		}
+	}
+
+	coordinator := ""
+	if s.coordinator != "" {
+		coordinator = s.coordinator.Pretty()
+	}
+	return Session{
+		SessionID:     sessionID,
+		ProcessType:   s.processType,
+		Coordinator:   coordinator,
+		ReadyPeers:    prettyPeers(s.readyPeers),
+		ExcludedPeers: prettyPeers(s.excludedPeers),
+		StartTime:     s.startTime,
+		Retries:       s.retries,
+		WaitingFor:    prettyPeers(waitingFor),
+	}
+}
+
+// processType returns package qualified process type, e.g. ecdsa/signing
+func processType(process TssProcess) string {
+	t := reflect.TypeOf(process)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	return path.Join(path.Base(path.Dir(pkg)), path.Base(pkg))
+}
+
+func prettyPeers(peers []peer.ID) []string {
+	pretty := make([]string, len(peers))
+	for i, peerID := range peers {
+		pretty[i] = peerID.Pretty()
+	}
+	return pretty
+}
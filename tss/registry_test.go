@@ -0,0 +1,111 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mock_tss "github.com/ChainSafe/sygma-relayer/tss/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type waitingProcess struct {
+	*mock_tss.MockTssProcess
+	waitingFor []peer.ID
+}
+
+func (p *waitingProcess) WaitingFor() []peer.ID {
+	return p.waitingFor
+}
+
+type SessionRegistryTestSuite struct {
+	suite.Suite
+	registry *SessionRegistry
+	process  *waitingProcess
+	peerA    peer.ID
+	peerB    peer.ID
+}
+
+func TestRunSessionRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionRegistryTestSuite))
+}
+
+func (s *SessionRegistryTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	s.peerA, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.peerB, _ = peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.registry = NewSessionRegistry()
+	s.process = &waitingProcess{
+		MockTssProcess: mock_tss.NewMockTssProcess(ctrl),
+		waitingFor:     []peer.ID{s.peerB},
+	}
+}
+
+func (s *SessionRegistryTestSuite) Test_Register_SessionAlreadyPending() {
+	err := s.registry.register("1", []TssProcess{s.process})
+	s.Nil(err)
+
+	err = s.registry.register("1", []TssProcess{s.process})
+	s.NotNil(err)
+
+	s.registry.unregister("1")
+	err = s.registry.register("1", []TssProcess{s.process})
+	s.Nil(err)
+}
+
+func (s *SessionRegistryTestSuite) Test_Session_ReturnsSnapshot() {
+	_ = s.registry.register("1", []TssProcess{s.process})
+	s.registry.setCoordinator("1", s.peerA)
+	s.registry.setReadyPeers("1", []peer.ID{s.peerA})
+	s.registry.retry("1", []peer.ID{s.peerB})
+
+	session, ok := s.registry.Session("1")
+
+	s.True(ok)
+	s.Equal(session.SessionID, "1")
+	s.Equal(session.ProcessType, "sygma-relayer/tss")
+	s.Equal(session.Coordinator, s.peerA.Pretty())
+	s.Equal(session.ReadyPeers, []string{s.peerA.Pretty()})
+	s.Equal(session.ExcludedPeers, []string{s.peerB.Pretty()})
+	s.Equal(session.Retries, 1)
+	s.Equal(session.WaitingFor, []string{s.peerB.Pretty()})
+	s.False(session.StartTime.IsZero())
+}
+
+func (s *SessionRegistryTestSuite) Test_SetCoordinator_ResetsReadyPeers() {
+	_ = s.registry.register("1", []TssProcess{s.process})
+	s.registry.setReadyPeers("1", []peer.ID{s.peerA})
+	s.registry.setCoordinator("1", s.peerB)
+
+	session, _ := s.registry.Session("1")
+
+	s.Equal(session.ReadyPeers, []string{})
+}
+
+func (s *SessionRegistryTestSuite) Test_ServeHTTP_ReturnsSessions() {
+	_ = s.registry.register("1", []TssProcess{s.process})
+	_ = s.registry.register("2", []TssProcess{s.process})
+	recorder := httptest.NewRecorder()
+
+	s.registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tss/sessions", nil))
+
+	s.Equal(recorder.Code, http.StatusOK)
+	var sessions []Session
+	err := json.Unmarshal(recorder.Body.Bytes(), &sessions)
+	s.Nil(err)
+	s.Equal(len(sessions), 2)
+}
+
+func (s *SessionRegistryTestSuite) Test_ServeHTTP_SessionNotFound() {
+	recorder := httptest.NewRecorder()
+
+	s.registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/tss/sessions?sessionID=1", nil))
+
+	s.Equal(recorder.Code, http.StatusNotFound)
+}
@@ -0,0 +1,157 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package reputation
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/rs/zerolog/log"
+)
+
+type Offence int
+
+const (
+	Timeout Offence = iota
+	Culprit
+	CommunicationFailure
+)
+
+func (o Offence) String() string {
+	switch o {
+	case Timeout:
+		return "Timeout"
+	case Culprit:
+		return "Culprit"
+	case CommunicationFailure:
+		return "CommunicationFailure"
+	default:
+		return "UnknownOffence"
+	}
+}
+
+var offencePenalties = map[Offence]float64{
+	Timeout:              1,
+	Culprit:              3,
+	CommunicationFailure: 1,
+}
+
+// tierThresholds are lowest penalty scores of tiers 1 and 2
+var tierThresholds = []float64{1, 6}
+
+type ScoreStorer interface {
+	StorePeerScores(scores map[string]store.PeerScore) error
+	PeerScores() (map[string]store.PeerScore, error)
+}
+
+// Reputation tracks peer penalty scores for misbehaviour observed by the relayer.
+// Scores decay exponentially with the configured half-life.
+type Reputation struct {
+	storer   ScoreStorer
+	halfLife time.Duration
+	scores   map[string]store.PeerScore
+	lock     sync.Mutex
+}
+
+func NewReputation(storer ScoreStorer, halfLife time.Duration) (*Reputation, error) {
+	scores, err := storer.PeerScores()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reputation{
+		storer:   storer,
+		halfLife: halfLife,
+		scores:   scores,
+	}, nil
+}
+
+// Penalize increases peer penalty score for the offence and persists scores
+func (r *Reputation) Penalize(p peer.ID, offence Offence) {
+	if p == "" {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	score := r.decayedScore(p, now) + offencePenalties[offence]
+	r.scores[p.Pretty()] = store.PeerScore{
+		Score:     score,
+		UpdatedAt: now,
+	}
+	log.Info().Msgf("Penalized peer %s for %s, new score: %.2f", p.Pretty(), offence, score)
+
+	err := r.storer.StorePeerScores(r.scores)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed storing peer reputation")
+	}
+}
+
+// Scores returns current penalty scores of the provided peers
+func (r *Reputation) Scores(peers []peer.ID) map[peer.ID]float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	scores := make(map[peer.ID]float64)
+	for _, p := range peers {
+		scores[p] = r.decayedScore(p, now)
+	}
+	return scores
+}
+
+func (r *Reputation) decayedScore(p peer.ID, now time.Time) float64 {
+	score, ok := r.scores[p.Pretty()]
+	if !ok {
+		return 0
+	}
+
+	elapsed := now.Sub(score.UpdatedAt)
+	if elapsed <= 0 || r.halfLife <= 0 {
+		return score.Score
+	}
+	return score.Score * math.Pow(0.5, float64(elapsed)/float64(r.halfLife))
+}
+
+// Tier returns reputation tier of the penalty score
+func Tier(score float64) int {
+	tier := 0
+	for _, threshold := range tierThresholds {
+		if score >= threshold {
+			tier++
+		}
+	}
+	return tier
+}
+
+// AggregateTiers calculates peer tiers from the median of scores reported by
+// multiple relayers. Peers missing from a report are considered well-behaved
+// by the reporter.
+func AggregateTiers(reports []map[peer.ID]float64, peers []peer.ID) util.PeerTiers {
+	tiers := make(util.PeerTiers)
+	if len(reports) == 0 {
+		return tiers
+	}
+
+	for _, p := range peers {
+		scores := make([]float64, len(reports))
+		for i, report := range reports {
+			scores[i] = report[p]
+		}
+		sort.Float64s(scores)
+
+		tier := Tier(scores[(len(scores)-1)/2])
+		if tier != 0 {
+			tiers[p] = tier
+		}
+	}
+	return tiers
+}
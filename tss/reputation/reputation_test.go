@@ -0,0 +1,122 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package reputation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/sygma-relayer/store"
+	"github.com/ChainSafe/sygma-relayer/tss/reputation"
+	"github.com/ChainSafe/sygma-relayer/tss/util"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/suite"
+)
+
+type memoryScoreStorer struct {
+	scores map[string]store.PeerScore
+}
+
+func (m *memoryScoreStorer) StorePeerScores(scores map[string]store.PeerScore) error {
+	m.scores = make(map[string]store.PeerScore)
+	for p, score := range scores {
+		m.scores[p] = score
+	}
+	return nil
+}
+
+func (m *memoryScoreStorer) PeerScores() (map[string]store.PeerScore, error) {
+	if m.scores == nil {
+		return nil, errors.New("error")
+	}
+	return m.scores, nil
+}
+
+type ReputationTestSuite struct {
+	suite.Suite
+	storer *memoryScoreStorer
+	peer1  peer.ID
+	peer2  peer.ID
+	peer3  peer.ID
+}
+
+func TestRunReputationTestSuite(t *testing.T) {
+	suite.Run(t, new(ReputationTestSuite))
+}
+
+func (s *ReputationTestSuite) SetupTest() {
+	s.storer = &memoryScoreStorer{scores: make(map[string]store.PeerScore)}
+	s.peer1, _ = peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	s.peer2, _ = peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	s.peer3, _ = peer.Decode("QmYayosTHxL2xa4jyrQ2PmbhGbrkSxsGM1kzXLTT8SsLVy")
+}
+
+func (s *ReputationTestSuite) Test_NewReputation_FailedFetch() {
+	_, err := reputation.NewReputation(&memoryScoreStorer{}, time.Hour)
+
+	s.NotNil(err)
+}
+
+func (s *ReputationTestSuite) Test_Penalize_ScoresPersisted() {
+	r, err := reputation.NewReputation(s.storer, time.Hour)
+	s.Nil(err)
+
+	r.Penalize(s.peer1, reputation.Culprit)
+	r.Penalize(s.peer1, reputation.Timeout)
+	r.Penalize(s.peer2, reputation.CommunicationFailure)
+
+	scores := r.Scores([]peer.ID{s.peer1, s.peer2, s.peer3})
+	s.InDelta(scores[s.peer1], 4, 0.01)
+	s.InDelta(scores[s.peer2], 1, 0.01)
+	s.Equal(scores[s.peer3], 0.0)
+
+	reloaded, err := reputation.NewReputation(s.storer, time.Hour)
+	s.Nil(err)
+	s.InDelta(reloaded.Scores([]peer.ID{s.peer1})[s.peer1], 4, 0.01)
+}
+
+func (s *ReputationTestSuite) Test_Scores_Decay() {
+	s.storer.scores[s.peer1.Pretty()] = store.PeerScore{
+		Score:     4,
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+	}
+	r, err := reputation.NewReputation(s.storer, time.Hour)
+	s.Nil(err)
+
+	scores := r.Scores([]peer.ID{s.peer1})
+
+	s.InDelta(scores[s.peer1], 1, 0.01)
+}
+
+func (s *ReputationTestSuite) Test_Tier() {
+	s.Equal(reputation.Tier(0), 0)
+	s.Equal(reputation.Tier(0.99), 0)
+	s.Equal(reputation.Tier(1), 1)
+	s.Equal(reputation.Tier(6), 2)
+}
+
+func (s *ReputationTestSuite) Test_AggregateTiers_MedianOfReports() {
+	reports := []map[peer.ID]float64{
+		{s.peer1: 10, s.peer2: 10},
+		{s.peer1: 10},
+		{s.peer1: 2, s.peer2: 10},
+	}
+
+	tiers := reputation.AggregateTiers(reports, []peer.ID{s.peer1, s.peer2, s.peer3})
+
+	s.Equal(tiers, util.PeerTiers{s.peer1: 2, s.peer2: 2})
+}
+
+func (s *ReputationTestSuite) Test_AggregateTiers_SingleReporterOutvoted() {
+	reports := []map[peer.ID]float64{
+		{s.peer1: 10},
+		{},
+		{},
+	}
+
+	tiers := reputation.AggregateTiers(reports, []peer.ID{s.peer1})
+
+	s.Equal(tiers, util.PeerTiers{})
+}
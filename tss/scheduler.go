@@ -0,0 +1,255 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// Priority determines the order in which queued tss sessions are started
+type Priority int
+
+const (
+	PriorityIdle Priority = iota - 1
+	PriorityNormal
+	PriorityRetry
+	PriorityKeyManagement
+)
+
+// priorities are ordered from the highest to the lowest priority
+var priorities = []Priority{PriorityKeyManagement, PriorityRetry, PriorityNormal, PriorityIdle}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityIdle:
+		return "Idle"
+	case PriorityNormal:
+		return "Normal"
+	case PriorityRetry:
+		return "Retry"
+	case PriorityKeyManagement:
+		return "KeyManagement"
+	default:
+		return "Unknown"
+	}
+}
+
+// SessionExecutor executes tss processes once the session is scheduled
+type SessionExecutor interface {
+	Execute(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}) error
+	IsCoordinator(ctx context.Context, tssProcesses []TssProcess) bool
+}
+
+// QueueMeter tracks scheduler queue depth and running sessions
+type QueueMeter interface {
+	TrackTssQueueDepth(priority string, domainID uint8, depth int)
+	TrackTssRunningSessions(running int)
+}
+
+type ticket struct {
+	ready chan struct{}
+}
+
+// domainQueue queues tickets per domain and pops them
+// from domains in round-robin order
+type domainQueue struct {
+	domains []uint8
+	tickets map[uint8][]*ticket
+	next    int
+}
+
+func newDomainQueue() *domainQueue {
+	return &domainQueue{
+		domains: make([]uint8, 0),
+		tickets: make(map[uint8][]*ticket),
+	}
+}
+
+func (q *domainQueue) push(domainID uint8, t *ticket) {
+	if len(q.tickets[domainID]) == 0 {
+		q.domains = append(q.domains, domainID)
+	}
+	q.tickets[domainID] = append(q.tickets[domainID], t)
+}
+
+func (q *domainQueue) pop() (*ticket, uint8, bool) {
+	if len(q.domains) == 0 {
+		return nil, 0, false
+	}
+
+	index := q.next % len(q.domains)
+	domainID := q.domains[index]
+	t := q.tickets[domainID][0]
+	q.tickets[domainID] = q.tickets[domainID][1:]
+	q.next = index + 1
+	if len(q.tickets[domainID]) == 0 {
+		q.removeDomain(index)
+	}
+	return t, domainID, true
+}
+
+func (q *domainQueue) remove(domainID uint8, t *ticket) bool {
+	index := slices.Index(q.tickets[domainID], t)
+	if index == -1 {
+		return false
+	}
+
+	q.tickets[domainID] = slices.Delete(q.tickets[domainID], index, index+1)
+	if len(q.tickets[domainID]) == 0 {
+		q.removeDomain(slices.Index(q.domains, domainID))
+	}
+	return true
+}
+
+func (q *domainQueue) removeDomain(index int) {
+	delete(q.tickets, q.domains[index])
+	q.domains = slices.Delete(q.domains, index, index+1)
+	if index < q.next {
+		q.next--
+	}
+}
+
+// Scheduler limits the number of concurrently running tss sessions coordinated by the relayer.
+// Waiting sessions are started by priority and sessions with the same priority are started in
+// round-robin order between destination domains. Idle sessions are started only when the relayer
+// is not running any other tss session. Sessions coordinated by other relayers are not limited,
+// so that the relayer never delays sessions already scheduled by their coordinators.
+type Scheduler struct {
+	executor    SessionExecutor
+	maxSessions int
+	metrics     QueueMeter
+
+	running int
+	queues  map[Priority]*domainQueue
+	lock    sync.Mutex
+}
+
+// NewScheduler creates a tss session scheduler. Number of concurrent
+// sessions is not limited if maxSessions is not positive.
+func NewScheduler(executor SessionExecutor, maxSessions int, metrics QueueMeter) *Scheduler {
+	queues := make(map[Priority]*domainQueue)
+	for _, priority := range priorities {
+		queues[priority] = newDomainQueue()
+	}
+
+	return &Scheduler{
+		executor:    executor,
+		maxSessions: maxSessions,
+		metrics:     metrics,
+		queues:      queues,
+	}
+}
+
+// Execute waits until the session can be started and executes tss processes
+// with the coordinator. Sessions coordinated by other relayers are executed
+// immediately. Error is returned if the context is cancelled while waiting.
+func (s *Scheduler) Execute(
+	ctx context.Context,
+	priority Priority,
+	domainID uint8,
+	tssProcesses []TssProcess,
+	resultChn chan interface{},
+) error {
+	if !s.executor.IsCoordinator(ctx, tssProcesses) {
+		return s.executor.Execute(ctx, tssProcesses, resultChn)
+	}
+
+	err := s.acquire(ctx, priority, domainID)
+	if err != nil {
+		return err
+	}
+	defer s.release()
+
+	return s.executor.Execute(ctx, tssProcesses, resultChn)
+}
+
+func (s *Scheduler) acquire(ctx context.Context, priority Priority, domainID uint8) error {
+	s.lock.Lock()
+	if !s.queued() && s.available() && (priority != PriorityIdle || s.running == 0) {
+		s.running++
+		s.metrics.TrackTssRunningSessions(s.running)
+		s.lock.Unlock()
+		return nil
+	}
+
+	t := &ticket{ready: make(chan struct{})}
+	queue := s.queues[priority]
+	queue.push(domainID, t)
+	s.metrics.TrackTssQueueDepth(priority.String(), domainID, len(queue.tickets[domainID]))
+	s.lock.Unlock()
+
+	select {
+	case <-t.ready:
+		return nil
+	case <-ctx.Done():
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if queue.remove(domainID, t) {
+			s.metrics.TrackTssQueueDepth(priority.String(), domainID, len(queue.tickets[domainID]))
+			return ctx.Err()
+		}
+
+		// ticket was dispatched before the context got cancelled
+		s.running--
+		s.dispatch()
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.running--
+	s.dispatch()
+}
+
+// dispatch starts waiting sessions while there are available slots
+func (s *Scheduler) dispatch() {
+	for s.available() {
+		t, priority, domainID, ok := s.next()
+		if !ok {
+			break
+		}
+
+		s.running++
+		s.metrics.TrackTssQueueDepth(priority.String(), domainID, len(s.queues[priority].tickets[domainID]))
+		close(t.ready)
+	}
+	s.metrics.TrackTssRunningSessions(s.running)
+}
+
+// next pops the ticket with the highest priority. Idle sessions
+// are started only when there are no other running sessions.
+func (s *Scheduler) next() (*ticket, Priority, uint8, bool) {
+	for _, priority := range priorities {
+		if priority == PriorityIdle && s.running != 0 {
+			continue
+		}
+
+		t, domainID, ok := s.queues[priority].pop()
+		if ok {
+			return t, priority, domainID, true
+		}
+	}
+	return nil, 0, 0, false
+}
+
+func (s *Scheduler) available() bool {
+	return s.maxSessions <= 0 || s.running < s.maxSessions
+}
+
+// queued returns true if there are waiting sessions that can be started
+// before idle sessions
+func (s *Scheduler) queued() bool {
+	for priority, queue := range s.queues {
+		if priority != PriorityIdle && len(queue.domains) != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+// The Licensed Work is (c) 2022 Sygma
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tss
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mock_tss "github.com/ChainSafe/sygma-relayer/tss/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
+)
+
+type blockingExecutor struct {
+	started chan string
+	release chan struct{}
+	// sessions coordinated by other relayers
+	participating []string
+}
+
+func (e *blockingExecutor) IsCoordinator(ctx context.Context, tssProcesses []TssProcess) bool {
+	return !slices.Contains(e.participating, tssProcesses[0].SessionID())
+}
+
+func (e *blockingExecutor) Execute(ctx context.Context, tssProcesses []TssProcess, resultChn chan interface{}) error {
+	e.started <- tssProcesses[0].SessionID()
+	select {
+	case <-e.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+type memoryQueueMeter struct {
+	depth   map[string]int
+	running int
+	lock    sync.Mutex
+}
+
+func (m *memoryQueueMeter) TrackTssQueueDepth(priority string, domainID uint8, depth int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.depth[priority] = depth
+}
+
+func (m *memoryQueueMeter) TrackTssRunningSessions(running int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.running = running
+}
+
+func (m *memoryQueueMeter) queueDepth(priority Priority) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.depth[priority.String()]
+}
+
+func (m *memoryQueueMeter) runningSessions() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.running
+}
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	executor  *blockingExecutor
+	meter     *memoryQueueMeter
+	scheduler *Scheduler
+}
+
+func TestRunSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.executor = &blockingExecutor{
+		started: make(chan string, 10),
+		release: make(chan struct{}),
+	}
+	s.meter = &memoryQueueMeter{depth: make(map[string]int)}
+	s.scheduler = NewScheduler(s.executor, 1, s.meter)
+}
+
+func (s *SchedulerTestSuite) process(sessionID string) []TssProcess {
+	process := mock_tss.NewMockTssProcess(s.ctrl)
+	process.EXPECT().SessionID().Return(sessionID).AnyTimes()
+	return []TssProcess{process}
+}
+
+func (s *SchedulerTestSuite) execute(ctx context.Context, priority Priority, domainID uint8, sessionID string) chan error {
+	errChn := make(chan error, 1)
+	go func() {
+		errChn <- s.scheduler.Execute(ctx, priority, domainID, s.process(sessionID), make(chan interface{}))
+	}()
+	return errChn
+}
+
+// enqueue starts execution and waits until the session is queued
+func (s *SchedulerTestSuite) enqueue(priority Priority, domainID uint8, sessionID string) chan error {
+	queued := s.queued()
+	errChn := s.execute(context.Background(), priority, domainID, sessionID)
+	s.Eventually(func() bool { return s.queued() == queued+1 }, time.Second, time.Millisecond)
+	return errChn
+}
+
+func (s *SchedulerTestSuite) queued() int {
+	s.scheduler.lock.Lock()
+	defer s.scheduler.lock.Unlock()
+
+	queued := 0
+	for _, queue := range s.scheduler.queues {
+		for _, tickets := range queue.tickets {
+			queued += len(tickets)
+		}
+	}
+	return queued
+}
+
+func (s *SchedulerTestSuite) startedSessions(count int) []string {
+	sessions := make([]string, 0)
+	for i := 0; i < count; i++ {
+		select {
+		case sessionID := <-s.executor.started:
+			sessions = append(sessions, sessionID)
+			if i < count-1 {
+				s.executor.release <- struct{}{}
+			}
+		case <-time.After(time.Second):
+			s.FailNow("session not started")
+		}
+	}
+	return sessions
+}
+
+func (s *SchedulerTestSuite) Test_Execute_LimitsConcurrentSessions() {
+	first := s.execute(context.Background(), PriorityNormal, 1, "1")
+	s.Equal(<-s.executor.started, "1")
+
+	second := s.enqueue(PriorityNormal, 1, "2")
+	s.Len(s.executor.started, 0)
+	s.Equal(s.meter.runningSessions(), 1)
+
+	s.executor.release <- struct{}{}
+	s.Nil(<-first)
+	s.Equal(<-s.executor.started, "2")
+	s.executor.release <- struct{}{}
+	s.Nil(<-second)
+}
+
+func (s *SchedulerTestSuite) Test_Execute_StartsSessionsByPriority() {
+	_ = s.execute(context.Background(), PriorityNormal, 1, "running")
+	s.Equal(<-s.executor.started, "running")
+
+	_ = s.enqueue(PriorityNormal, 1, "normal")
+	_ = s.enqueue(PriorityRetry, 1, "retry")
+	_ = s.enqueue(PriorityKeyManagement, 0, "keygen")
+	s.executor.release <- struct{}{}
+
+	s.Equal(s.startedSessions(3), []string{"keygen", "retry", "normal"})
+	s.executor.release <- struct{}{}
+}
+
+func (s *SchedulerTestSuite) Test_Execute_RoundRobinBetweenDomains() {
+	_ = s.execute(context.Background(), PriorityNormal, 1, "running")
+	s.Equal(<-s.executor.started, "running")
+
+	_ = s.enqueue(PriorityNormal, 1, "1-1")
+	_ = s.enqueue(PriorityNormal, 1, "1-2")
+	_ = s.enqueue(PriorityNormal, 1, "1-3")
+	_ = s.enqueue(PriorityNormal, 2, "2-1")
+	_ = s.enqueue(PriorityNormal, 3, "3-1")
+	s.executor.release <- struct{}{}
+
+	s.Equal(s.startedSessions(5), []string{"1-1", "2-1", "3-1", "1-2", "1-3"})
+	s.executor.release <- struct{}{}
+}
+
+func (s *SchedulerTestSuite) Test_Execute_StartsIdleSessionsWithoutRunningSessions() {
+	s.scheduler = NewScheduler(s.executor, 2, s.meter)
+	_ = s.execute(context.Background(), PriorityNormal, 1, "running")
+	s.Equal(<-s.executor.started, "running")
+
+	_ = s.enqueue(PriorityIdle, 0, "idle")
+	s.Len(s.executor.started, 0)
+
+	_ = s.execute(context.Background(), PriorityNormal, 1, "normal")
+	s.Equal(<-s.executor.started, "normal")
+
+	s.executor.release <- struct{}{}
+	s.executor.release <- struct{}{}
+	s.Equal(<-s.executor.started, "idle")
+	s.executor.release <- struct{}{}
+}
+
+func (s *SchedulerTestSuite) Test_Execute_CancelledWhileQueued() {
+	_ = s.execute(context.Background(), PriorityNormal, 1, "running")
+	s.Equal(<-s.executor.started, "running")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := s.execute(ctx, PriorityRetry, 1, "cancelled")
+	s.Eventually(func() bool { return s.queued() == 1 }, time.Second, time.Millisecond)
+	s.Equal(s.meter.queueDepth(PriorityRetry), 1)
+
+	cancel()
+	s.ErrorIs(<-cancelled, context.Canceled)
+	s.Equal(s.queued(), 0)
+	s.Equal(s.meter.queueDepth(PriorityRetry), 0)
+
+	s.executor.release <- struct{}{}
+	s.Eventually(func() bool { return s.meter.runningSessions() == 0 }, time.Second, time.Millisecond)
+	s.Len(s.executor.started, 0)
+}
+
+func (s *SchedulerTestSuite) Test_Execute_ParticipationNotLimited() {
+	s.executor.participating = []string{"participating"}
+	_ = s.execute(context.Background(), PriorityNormal, 1, "running")
+	s.Equal(<-s.executor.started, "running")
+
+	participating := s.execute(context.Background(), PriorityIdle, 1, "participating")
+	s.Equal(<-s.executor.started, "participating")
+	s.Equal(s.queued(), 0)
+	s.Equal(s.meter.runningSessions(), 1)
+
+	s.executor.release <- struct{}{}
+	s.executor.release <- struct{}{}
+	s.Nil(<-participating)
+}
+
+func (s *SchedulerTestSuite) Test_Execute_Unlimited() {
+	s.scheduler = NewScheduler(s.executor, 0, s.meter)
+
+	_ = s.execute(context.Background(), PriorityNormal, 1, "1")
+	_ = s.execute(context.Background(), PriorityNormal, 1, "2")
+
+	started := []string{<-s.executor.started, <-s.executor.started}
+	s.ElementsMatch(started, []string{"1", "2"})
+	s.executor.release <- struct{}{}
+	s.executor.release <- struct{}{}
+}
@@ -6,6 +6,8 @@ package tsstest
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/ChainSafe/sygma-relayer/config/relayer"
@@ -28,6 +30,7 @@ type CoordinatorTestSuite struct {
 	MockFrostStorer   *mock_tss.MockFrostKeyshareStorer
 	MockCommunication *mock_comm.MockCommunication
 	MockTssProcess    *mock_tss.MockTssProcess
+	MockReputation    *mock_tss.MockPeerReputation
 
 	Hosts       []host.Host
 	Threshold   int
@@ -41,6 +44,9 @@ func (s *CoordinatorTestSuite) SetupTest() {
 	s.MockFrostStorer = mock_tss.NewMockFrostKeyshareStorer(s.GomockController)
 	s.MockCommunication = mock_comm.NewMockCommunication(s.GomockController)
 	s.MockTssProcess = mock_tss.NewMockTssProcess(s.GomockController)
+	s.MockReputation = mock_tss.NewMockPeerReputation(s.GomockController)
+	s.MockReputation.EXPECT().Scores(gomock.Any()).Return(map[peer.ID]float64{}).AnyTimes()
+	s.MockReputation.EXPECT().Penalize(gomock.Any(), gomock.Any()).AnyTimes()
 	s.PartyNumber = 3
 	s.Threshold = 1
 
@@ -64,8 +70,9 @@ func (s *CoordinatorTestSuite) SetupTest() {
 	}
 }
 
+// NewHost creates host with the test private key at index i
 func NewHost(i int) (host.Host, error) {
-	privBytes, err := os.ReadFile(fmt.Sprintf("../../test/pks/%d.pk", i))
+	privBytes, err := os.ReadFile(filepath.Join(testDir(), "pks", fmt.Sprintf("%d.pk", i)))
 	if err != nil {
 		return nil, err
 	}
@@ -99,3 +106,10 @@ func SetupCommunication(commMap map[peer.ID]*TestCommunication) {
 		comm.PeerCommunications = peerComms
 	}
 }
+
+// testDir returns the directory of the test utilities so test files
+// can be found independently of the test package depth
+func testDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}
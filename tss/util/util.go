@@ -5,10 +5,15 @@ package util
 
 import (
 	"sort"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// ReadyGracePeriod is how long coordinator waits for well-behaved peers
+// after enough peers are ready to start the process
+var ReadyGracePeriod = 30 * time.Second
+
 func SortPeersForSession(peers []peer.ID, sessionID string) SortablePeerSlice {
 	sortedPeers := make(SortablePeerSlice, len(peers))
 	for i, p := range peers {
@@ -22,6 +27,81 @@ func SortPeersForSession(peers []peer.ID, sessionID string) SortablePeerSlice {
 	return sortedPeers
 }
 
+// PeerTiers maps peers to reputation tiers. Tier 0 contains well-behaved
+// peers and peers missing from the map.
+type PeerTiers map[peer.ID]int
+
+// WellBehaved returns peers in the lowest reputation tier
+func (t PeerTiers) WellBehaved(peers []peer.ID) []peer.ID {
+	wellBehaved := make([]peer.ID, 0)
+	for _, p := range peers {
+		if t[p] == 0 {
+			wellBehaved = append(wellBehaved, p)
+		}
+	}
+	return wellBehaved
+}
+
+// TieredReadiness decides when a process can start with respect to reputation
+// tiers of ready peers. Processes embed it to prefer well-behaved peers.
+type TieredReadiness struct {
+	peerTiers  PeerTiers
+	readySince time.Time
+}
+
+// SetPeerTiers sets reputation tiers used to prefer well-behaved peers
+func (r *TieredReadiness) SetPeerTiers(peerTiers PeerTiers) {
+	r.peerTiers = peerTiers
+}
+
+// PeerTiers returns reputation tiers of peers
+func (r *TieredReadiness) PeerTiers() PeerTiers {
+	return r.peerTiers
+}
+
+// TiersReady returns true if threshold+1 well-behaved peers are ready to start the process.
+// Peers with worse reputation are accepted if all peers are ready or if not enough
+// well-behaved peers got ready during the grace period.
+func (r *TieredReadiness) TiersReady(readyPeers []peer.ID, peers []peer.ID, threshold int) bool {
+	if !r.EnoughReady(readyPeers, threshold) {
+		return false
+	}
+	return r.WellBehavedReady(readyPeers, threshold) || r.WaitOver(readyPeers, peers)
+}
+
+// EnoughReady returns true if threshold+1 peers are ready and starts
+// the grace period the first time it happens
+func (r *TieredReadiness) EnoughReady(readyPeers []peer.ID, threshold int) bool {
+	if len(readyPeers) < threshold+1 {
+		return false
+	}
+	if r.readySince.IsZero() {
+		r.readySince = time.Now()
+	}
+	return true
+}
+
+// WellBehavedReady returns true if threshold+1 well-behaved peers are ready
+func (r *TieredReadiness) WellBehavedReady(readyPeers []peer.ID, threshold int) bool {
+	return len(r.peerTiers.WellBehaved(readyPeers)) >= threshold+1
+}
+
+// WaitOver returns true if all peers are ready or if the grace period has passed
+func (r *TieredReadiness) WaitOver(readyPeers []peer.ID, peers []peer.ID) bool {
+	return len(readyPeers) == len(peers) ||
+		(!r.readySince.IsZero() && time.Since(r.readySince) >= ReadyGracePeriod)
+}
+
+// SortPeersByTier sorts peers by reputation tier and then
+// by the session order calculated by SortPeersForSession
+func SortPeersByTier(peers []peer.ID, sessionID string, tiers PeerTiers) SortablePeerSlice {
+	sortedPeers := SortPeersForSession(peers, sessionID)
+	sort.SliceStable(sortedPeers, func(i, j int) bool {
+		return tiers[sortedPeers[i].ID] < tiers[sortedPeers[j].ID]
+	})
+	return sortedPeers
+}
+
 func IsParticipant(peer peer.ID, peers peer.IDSlice) bool {
 	for _, p := range peers {
 		if p.Pretty() == peer.Pretty() {
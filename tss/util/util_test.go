@@ -5,6 +5,7 @@ package util_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/sygma-relayer/tss/util"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -67,3 +68,83 @@ func (s *SortPeersForSessionTestSuite) Test_ValidPeers() {
 		util.PeerMsg{SessionID: "sessionID", ID: peer3},
 	})
 }
+
+func (s *SortPeersForSessionTestSuite) Test_SortPeersByTier() {
+	peer1, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	peer2, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	peer3, _ := peer.Decode("QmYayosTHxL2xa4jyrQ2PmbhGbrkSxsGM1kzXLTT8SsLVy")
+	peers := []peer.ID{peer3, peer2, peer1}
+
+	sortedPeers := util.SortPeersByTier(peers, "sessionID", util.PeerTiers{peer1: 2, peer2: 1})
+
+	s.Equal(sortedPeers, util.SortablePeerSlice{
+		util.PeerMsg{SessionID: "sessionID", ID: peer3},
+		util.PeerMsg{SessionID: "sessionID", ID: peer2},
+		util.PeerMsg{SessionID: "sessionID", ID: peer1},
+	})
+}
+
+func (s *SortPeersForSessionTestSuite) Test_WellBehaved() {
+	peer1, _ := peer.Decode("QmcW3oMdSqoEcjbyd51auqC23vhKX6BqfcZcY2HJ3sKAZR")
+	peer2, _ := peer.Decode("QmZHPnN3CKiTAp8VaJqszbf8m7v4mPh15M421KpVdYHF54")
+	tiers := util.PeerTiers{peer1: 1}
+
+	s.Equal(tiers.WellBehaved([]peer.ID{peer1, peer2}), []peer.ID{peer2})
+}
+
+type TieredReadinessTestSuite struct {
+	suite.Suite
+	peers       []peer.ID
+	gracePeriod time.Duration
+}
+
+func TestRunTieredReadinessTestSuite(t *testing.T) {
+	suite.Run(t, new(TieredReadinessTestSuite))
+}
+
+func (s *TieredReadinessTestSuite) SetupTest() {
+	s.peers = []peer.ID{"QmP1", "QmP2", "QmP3", "QmP4"}
+	s.gracePeriod = util.ReadyGracePeriod
+}
+
+func (s *TieredReadinessTestSuite) TearDownTest() {
+	util.ReadyGracePeriod = s.gracePeriod
+}
+
+func (s *TieredReadinessTestSuite) Test_NotEnoughPeers() {
+	readiness := util.TieredReadiness{}
+
+	s.False(readiness.TiersReady(s.peers[:1], s.peers, 1))
+}
+
+func (s *TieredReadinessTestSuite) Test_WellBehavedPeersReady() {
+	readiness := util.TieredReadiness{}
+	readiness.SetPeerTiers(util.PeerTiers{s.peers[0]: 1})
+
+	s.True(readiness.TiersReady(s.peers[1:3], s.peers, 1))
+	s.Equal(readiness.PeerTiers(), util.PeerTiers{s.peers[0]: 1})
+}
+
+func (s *TieredReadinessTestSuite) Test_WorsePeersReady_WaitsForGracePeriod() {
+	readiness := util.TieredReadiness{}
+	readiness.SetPeerTiers(util.PeerTiers{s.peers[0]: 1})
+
+	s.False(readiness.TiersReady(s.peers[:2], s.peers, 1))
+
+	util.ReadyGracePeriod = 0
+	s.True(readiness.TiersReady(s.peers[:2], s.peers, 1))
+}
+
+func (s *TieredReadinessTestSuite) Test_AllPeersReady() {
+	readiness := util.TieredReadiness{}
+	readiness.SetPeerTiers(util.PeerTiers{s.peers[0]: 1, s.peers[1]: 1, s.peers[2]: 1})
+
+	s.True(readiness.TiersReady(s.peers, s.peers, 1))
+}
+
+func (s *TieredReadinessTestSuite) Test_WaitOver_GracePeriodNotStarted() {
+	readiness := util.TieredReadiness{}
+	util.ReadyGracePeriod = 0
+
+	s.False(readiness.WaitOver(s.peers[:2], s.peers))
+}